@@ -31,6 +31,9 @@ import (
 // +groupName=externaldns.k8s.io
 // +kubebuilder:resource:path=dnsendpoints
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Programmed",type=string,JSONPath=`.status.conditions[?(@.type=="Programmed")].status`
+// +kubebuilder:printcolumn:name="Conflicted",type=string,JSONPath=`.status.conditions[?(@.type=="Conflicted")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:metadata:annotations="api-approved.kubernetes.io=https://github.com/kubernetes-sigs/external-dns/pull/2007"
 // +versionName=v1alpha1
 type DNSEndpoint struct {
@@ -59,4 +62,64 @@ type DNSEndpointStatus struct {
 	// The generation observed by the external-dns controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the state of the endpoints in the DNS provider.
+	// Known condition types are Accepted, Programmed and Conflicted.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Endpoints lists the outcome of the last reconciliation for every endpoint in the spec,
+	// along with the record live in the DNS provider.
+	// +optional
+	Endpoints []EndpointStatus `json:"endpoints,omitempty"`
+}
+
+// EndpointStatus is the observed state of a single endpoint of a DNSEndpoint
+type EndpointStatus struct {
+	// The hostname of the DNS record
+	DNSName string `json:"dnsName"`
+	// RecordType type of record, e.g. CNAME, A, AAAA, SRV, TXT etc
+	RecordType string `json:"recordType"`
+	// Identifier to distinguish multiple records with the same name and type (e.g. Route53 records with routing policies other than 'simple')
+	// +optional
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// The targets live in the DNS provider, empty if the record does not exist
+	// +optional
+	Targets endpoint.Targets `json:"targets,omitempty"`
+	// TTL live in the DNS provider
+	// +optional
+	RecordTTL endpoint.TTL `json:"recordTTL,omitempty"`
+	// State is the outcome of the last reconciliation, e.g. Created, Unchanged, Conflicted or Failed
+	State string `json:"state"`
+	// Message explains the state
+	// +optional
+	Message string `json:"message,omitempty"`
 }
+
+const (
+	// DNSEndpointAccepted is true when all endpoints in the spec are valid.
+	DNSEndpointAccepted = "Accepted"
+	// DNSEndpointProgrammed is true when all accepted endpoints are live in the DNS provider.
+	DNSEndpointProgrammed = "Programmed"
+	// DNSEndpointConflicted is true when at least one endpoint is owned by another owner or claimed by another resource.
+	DNSEndpointConflicted = "Conflicted"
+)
+
+const (
+	// DNSEndpointReasonAccepted is used with the Accepted condition when all endpoints are valid.
+	DNSEndpointReasonAccepted = "Accepted"
+	// DNSEndpointReasonInvalidEndpoints is used with the Accepted condition when endpoints were rejected.
+	DNSEndpointReasonInvalidEndpoints = "InvalidEndpoints"
+	// DNSEndpointReasonProgrammed is used with the Programmed condition when all records are live.
+	DNSEndpointReasonProgrammed = "Programmed"
+	// DNSEndpointReasonProviderError is used with the Programmed condition when the provider rejected changes.
+	DNSEndpointReasonProviderError = "ProviderError"
+	// DNSEndpointReasonPending is used with the Programmed condition when records are not live for other reasons.
+	DNSEndpointReasonPending = "Pending"
+	// DNSEndpointReasonConflicted is used with the Conflicted condition when a record is in conflict.
+	DNSEndpointReasonConflicted = "Conflicted"
+	// DNSEndpointReasonNoConflicts is used with the Conflicted condition when no record is in conflict.
+	DNSEndpointReasonNoConflicts = "NoConflicts"
+)
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+	"sigs.k8s.io/external-dns/source"
+)
+
+const (
+	// auditOrphaned is the finding of ownership information whose record no longer exists
+	auditOrphaned = "orphaned"
+	// auditUnsourced is the finding of a record owned by the owner ID which is not desired by any source
+	auditUnsourced = "unsourced"
+	// auditUnknownOwner is the finding of a record owned by an owner ID which is not known
+	auditUnknownOwner = "unknown-owner"
+
+	// auditExitFindings is the exit code of an audit with findings
+	auditExitFindings = 2
+)
+
+// auditFinding is a record or ownership information reported by the audit.
+type auditFinding struct {
+	Finding       string `json:"finding"`
+	DNSName       string `json:"dnsName"`
+	RecordType    string `json:"recordType"`
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	Owner         string `json:"owner"`
+}
+
+// runAudit audits the registry of the provider against the endpoints of the source, writes the report to w and
+// returns the exit code, which is auditExitFindings if there are findings.
+func runAudit(ctx context.Context, cfg *externaldns.Config, src source.Source, p provider.Provider, w io.Writer) (int, error) {
+	r, err := selectRegistry(cfg, p)
+	if err != nil {
+		return 0, err
+	}
+	findings, err := audit(ctx, r, src, cfg.AuditKnownOwners)
+	if err != nil {
+		return 0, err
+	}
+	if err := writeAuditReport(w, findings, cfg.AuditOutput); err != nil {
+		return 0, err
+	}
+	if len(findings) > 0 {
+		return auditExitFindings, nil
+	}
+	return 0, nil
+}
+
+// audit reads the records of the registry and the endpoints of the source, without modifying anything, and
+// returns the findings sorted by finding, name, type and set identifier:
+//   - orphaned ownership information of all owners, if the registry can report it
+//   - records owned by the owner ID of the registry which are not desired by any endpoint of the source
+//   - records owned by other owner IDs than the one of the registry and the known owners
+func audit(ctx context.Context, r registry.Registry, src source.Source, knownOwners []string) ([]auditFinding, error) {
+	var findings []auditFinding
+
+	if orphanReader, ok := r.(registry.OrphanReader); ok {
+		orphaned, err := orphanReader.OrphanedOwnership(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("reading orphaned ownership: %w", err)
+		}
+		for _, ep := range orphaned {
+			findings = append(findings, newAuditFinding(auditOrphaned, ep))
+		}
+	} else {
+		log.Infof("Registry %T does not report orphaned ownership", r)
+	}
+
+	records, err := r.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := src.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the endpoints are compared like the plan does, after they are adjusted to the provider
+	endpoints, err = r.AdjustEndpoints(endpoints)
+	if err != nil {
+		return nil, err
+	}
+	desired := make(map[endpoint.EndpointKey]struct{}, len(endpoints))
+	for _, ep := range endpoints {
+		desired[auditKey(ep)] = struct{}{}
+	}
+
+	known := map[string]bool{r.OwnerID(): true}
+	for _, owner := range knownOwners {
+		known[owner] = true
+	}
+	for _, ep := range records {
+		owner := ep.Labels[endpoint.OwnerLabelKey]
+		switch {
+		case owner == "":
+			continue
+		case owner == r.OwnerID():
+			if _, ok := desired[auditKey(ep)]; !ok {
+				findings = append(findings, newAuditFinding(auditUnsourced, ep))
+			}
+		case !known[owner]:
+			findings = append(findings, newAuditFinding(auditUnknownOwner, ep))
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Finding != b.Finding {
+			return a.Finding < b.Finding
+		}
+		if a.DNSName != b.DNSName {
+			return a.DNSName < b.DNSName
+		}
+		if a.RecordType != b.RecordType {
+			return a.RecordType < b.RecordType
+		}
+		return a.SetIdentifier < b.SetIdentifier
+	})
+	return findings, nil
+}
+
+func newAuditFinding(finding string, ep *endpoint.Endpoint) auditFinding {
+	return auditFinding{
+		Finding:       finding,
+		DNSName:       ep.DNSName,
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+		Owner:         ep.Labels[endpoint.OwnerLabelKey],
+	}
+}
+
+// auditKey returns the key of the endpoint with a normalized DNS name.
+func auditKey(ep *endpoint.Endpoint) endpoint.EndpointKey {
+	return endpoint.EndpointKey{
+		DNSName:       strings.TrimSuffix(strings.ToLower(ep.DNSName), "."),
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+	}
+}
+
+// writeAuditReport writes the findings to w as a table or as JSON.
+func writeAuditReport(w io.Writer, findings []auditFinding, format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Findings []auditFinding `json:"findings"`
+		}{Findings: append([]auditFinding{}, findings...)})
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FINDING\tTYPE\tNAME\tSET IDENTIFIER\tOWNER")
+	for _, f := range findings {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", f.Finding, f.RecordType, f.DNSName, f.SetIdentifier, f.Owner)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d findings\n", len(findings))
+	return err
+}
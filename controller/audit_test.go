@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func newAuditProvider(t *testing.T) *inmemory.InMemoryProvider {
+	t.Helper()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.com"))
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("a-app.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\""),
+			endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+			endpoint.NewEndpoint("a-old.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\""),
+			endpoint.NewEndpoint("a-gone.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\""),
+			endpoint.NewEndpoint("legacy.example.com", endpoint.RecordTypeA, "1.2.3.6"),
+			endpoint.NewEndpoint("a-legacy.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=legacy\""),
+			endpoint.NewEndpoint("peer.example.com", endpoint.RecordTypeA, "1.2.3.7"),
+			endpoint.NewEndpoint("a-peer.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=peer\""),
+			endpoint.NewEndpoint("manual.example.com", endpoint.RecordTypeA, "1.2.3.8"),
+		},
+	}))
+	return p
+}
+
+func newAuditRegistry(t *testing.T) registry.Registry {
+	t.Helper()
+	r, err := registry.NewTXTRegistry(newAuditProvider(t), "", "", "owner", 0, "", nil, nil, false, nil, true)
+	require.NoError(t, err)
+	return r
+}
+
+func newAuditSource() *testutils.MockSource {
+	src := new(testutils.MockSource)
+	src.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com.", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+	return src
+}
+
+func TestAudit(t *testing.T) {
+	findings, err := audit(context.Background(), newAuditRegistry(t), newAuditSource(), []string{"peer"})
+	require.NoError(t, err)
+	assert.Equal(t, []auditFinding{
+		{Finding: auditOrphaned, DNSName: "a-gone.example.com", RecordType: endpoint.RecordTypeTXT, Owner: "owner"},
+		{Finding: auditUnknownOwner, DNSName: "legacy.example.com", RecordType: endpoint.RecordTypeA, Owner: "legacy"},
+		{Finding: auditUnsourced, DNSName: "old.example.com", RecordType: endpoint.RecordTypeA, Owner: "owner"},
+	}, findings)
+}
+
+func TestRunAudit(t *testing.T) {
+	cfg := &externaldns.Config{
+		Registry:         "txt",
+		TXTOwnerID:       "owner",
+		TXTNewFormatOnly: true,
+	}
+	cfg.AuditKnownOwners = []string{"peer", "legacy"}
+
+	var out bytes.Buffer
+	code, err := runAudit(context.Background(), cfg, newAuditSource(), newAuditProvider(t), &out)
+	require.NoError(t, err)
+	assert.Equal(t, auditExitFindings, code)
+	assert.Contains(t, out.String(), "2 findings")
+
+	src := new(testutils.MockSource)
+	src.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+	}, nil)
+	p := newAuditProvider(t)
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("a-gone.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\"")},
+	}))
+	out.Reset()
+	code, err = runAudit(context.Background(), cfg, src, p, &out)
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out.String(), "0 findings")
+}
+
+func TestWriteAuditReport(t *testing.T) {
+	findings := []auditFinding{
+		{Finding: auditOrphaned, DNSName: "a-gone.example.com", RecordType: endpoint.RecordTypeTXT, Owner: "owner"},
+		{Finding: auditUnsourced, DNSName: "old.example.com", RecordType: endpoint.RecordTypeA, SetIdentifier: "eu", Owner: "owner"},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeAuditReport(&out, findings, "table"))
+	assert.Equal(t, `FINDING    TYPE  NAME                SET IDENTIFIER  OWNER
+orphaned   TXT   a-gone.example.com                  owner
+unsourced  A     old.example.com     eu              owner
+2 findings
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, writeAuditReport(&out, findings, "json"))
+	assert.JSONEq(t, `{"findings": [
+		{"finding": "orphaned", "dnsName": "a-gone.example.com", "recordType": "TXT", "owner": "owner"},
+		{"finding": "unsourced", "dnsName": "old.example.com", "recordType": "A", "setIdentifier": "eu", "owner": "owner"}
+	]}`, out.String())
+
+	out.Reset()
+	require.NoError(t, writeAuditReport(&out, nil, "json"))
+	assert.JSONEq(t, `{"findings": []}`, out.String())
+}
+
+func TestAuditUnsupportedOrphans(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.com"))
+	r, err := registry.NewAWSSDRegistry(p, "owner")
+	require.NoError(t, err)
+
+	findings, err := audit(context.Background(), r, newAuditSource(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
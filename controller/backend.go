@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// Backend is a DNS provider, through its registry, which the controller routes endpoints to.
+// Every backend has its own owner ID and plan.
+type Backend struct {
+	// Name identifies the backend in the provider annotation, logs and metrics
+	Name     string
+	Registry registry.Registry
+	// DomainFilter limits the endpoints routed to the backend and the records managed in it, nil for all
+	DomainFilter endpoint.DomainFilterInterface
+	// Access limits the endpoints routed to the backend to those with the access annotation, "public" or "private".
+	// Endpoints without the annotation are public. Empty for all endpoints.
+	Access string
+	// FlapDamping freezes the records of the backend whose targets flap, nil if disabled
+	FlapDamping *plan.FlapDampingPolicy
+	// DeletionGuard holds back the deletions of the backend exceeding its limits, nil if disabled
+	DeletionGuard *plan.DeletionGuard
+}
+
+// matches returns true if the endpoint with the given access is routed to the backend, unless it has a provider annotation.
+func (b *Backend) matches(ep *endpoint.Endpoint, access string) bool {
+	if b.DomainFilter != nil && !b.DomainFilter.Match(ep.DNSName) {
+		return false
+	}
+	if access == "" {
+		access = externaldns.AccessPublic
+	}
+	return b.Access == "" || b.Access == access
+}
+
+// backends returns the backend of the Registry, followed by the additional backends.
+func (c *Controller) backends() []*Backend {
+	return append([]*Backend{{
+		Name:          externaldns.DefaultBackendName,
+		Registry:      c.Registry,
+		FlapDamping:   c.FlapDamping,
+		DeletionGuard: c.DeletionGuard,
+	}}, c.Backends...)
+}
+
+// routeEndpoints distributes the endpoints to the backends, the first of which is the default backend.
+// An endpoint with a provider annotation is routed to the backends it names, or returned as unrouted if
+// none of them exists. Any other endpoint is routed to all other backends it matches, or to the default
+// backend if it matches none and is public. Private endpoints which match no backend, and endpoints with
+// an invalid access annotation, are returned as unrouted, so that they are never published in public DNS.
+// The routing annotations are removed from copies of the endpoints, and endpoints routed to several
+// backends are copied, since the plans of the backends modify them.
+func routeEndpoints(backends []*Backend, endpoints []*endpoint.Endpoint) (routed [][]*endpoint.Endpoint, unrouted []*endpoint.Endpoint) {
+	routed = make([][]*endpoint.Endpoint, len(backends))
+	for _, ep := range endpoints {
+		names, hasProvider := ep.GetProviderSpecificProperty(annotations.ProviderKey)
+		access, hasAccess := ep.GetProviderSpecificProperty(annotations.AccessKey)
+		if hasProvider || hasAccess {
+			ep = ep.DeepCopy()
+			ep.DeleteProviderSpecificProperty(annotations.ProviderKey)
+			ep.DeleteProviderSpecificProperty(annotations.AccessKey)
+		}
+		if hasAccess && access != externaldns.AccessPublic && access != externaldns.AccessPrivate {
+			log.Warnf("Skipping endpoint %s, its access %q is neither %q nor %q", ep, access, externaldns.AccessPublic, externaldns.AccessPrivate)
+			unrouted = append(unrouted, ep)
+			continue
+		}
+
+		var targets []int
+		if hasProvider {
+			for _, name := range strings.Split(names, ",") {
+				name = strings.TrimSpace(name)
+				for i, b := range backends {
+					if b.Name == name {
+						targets = append(targets, i)
+					}
+				}
+			}
+			if len(targets) == 0 {
+				log.Warnf("Skipping endpoint %s, there is no backend %q", ep, names)
+				unrouted = append(unrouted, ep)
+				continue
+			}
+		} else {
+			for i, b := range backends[1:] {
+				if b.matches(ep, access) {
+					targets = append(targets, i+1)
+				}
+			}
+			if len(targets) == 0 {
+				if access == externaldns.AccessPrivate {
+					log.Warnf("Skipping endpoint %s, there is no private backend for it", ep)
+					unrouted = append(unrouted, ep)
+					continue
+				}
+				targets = []int{0}
+			}
+		}
+
+		for n, i := range targets {
+			if n > 0 {
+				routed[i] = append(routed[i], ep.DeepCopy())
+			} else {
+				routed[i] = append(routed[i], ep)
+			}
+		}
+	}
+	return routed, unrouted
+}
+
+// reconcile calculates the changes of a backend from its current records and the adjusted endpoints
+// routed to it, and applies them. It returns the results of the reconciliation, the calculated changes
+// for the plan export and whether there were changes.
+func (c *Controller) reconcile(ctx context.Context, b *Backend, records, endpoints []*endpoint.Endpoint) ([]*plan.Result, []exportedChange, bool, error) {
+	domainFilter := endpoint.MatchAllDomainFilters{c.DomainFilter, b.Registry.GetDomainFilter()}
+	if b.DomainFilter != nil {
+		domainFilter = append(domainFilter, b.DomainFilter)
+	}
+	// the grace period applies before the change windows, which defer its labels and due deletions,
+	// and the guard only sees the deletions which are applied
+	policies := []plan.Policy{c.Policy}
+	if c.DeletionGrace != nil {
+		policies = append(policies, c.DeletionGrace)
+	}
+	for _, w := range c.ChangeWindows {
+		policies = append(policies, w)
+	}
+	if b.FlapDamping != nil {
+		policies = append(policies, b.FlapDamping)
+	}
+	if b.DeletionGuard != nil {
+		policies = append(policies, b.DeletionGuard)
+	}
+	plan := &plan.Plan{
+		Policies:            policies,
+		Current:             records,
+		Desired:             endpoints,
+		DomainFilter:        domainFilter,
+		ManagedRecords:      c.ManagedRecordTypes,
+		ExcludeRecords:      c.ExcludeRecordTypes,
+		OwnerID:             b.Registry.OwnerID(),
+		Resolver:            c.ConflictResolver,
+		MergeTargetsDomains: c.MergeTargetsDomains,
+		AdoptDomains:        c.AdoptDomains,
+	}
+
+	calculated := plan.Calculate()
+	backendConflictingRecords.Gauge.WithLabelValues(b.Name).Set(float64(len(calculated.Conflicts)))
+	if c.DeletionGrace != nil {
+		backendPendingDeletions.Gauge.WithLabelValues(b.Name).Set(float64(c.DeletionGrace.Pending()))
+	}
+	if len(c.ChangeWindows) > 0 {
+		c.recordDeferredChanges(b, time.Now())
+	}
+	if b.FlapDamping != nil {
+		backendFrozenRecords.Gauge.WithLabelValues(b.Name).Set(float64(b.FlapDamping.Frozen()))
+		backendSuppressedChangesTotal.CounterVec.WithLabelValues(b.Name).Add(float64(b.FlapDamping.Suppressed()))
+	}
+	if b.DeletionGuard != nil {
+		backendHeldDeletions.Gauge.WithLabelValues(b.Name).Set(float64(b.DeletionGuard.Held()))
+	}
+	changes := calculated.Changes
+	var exported []exportedChange
+	if c.PlanExporter != nil {
+		for _, change := range plan.Export(changes) {
+			exported = append(exported, exportedChange{Backend: b.Name, ExportedChange: change})
+		}
+	}
+	if !changes.HasChanges() {
+		return plan.Results(changes, nil), exported, false, nil
+	}
+
+	ctx = context.WithValue(ctx, provider.RecordsContextKey, records)
+	if err := b.Registry.ApplyChanges(ctx, changes); err != nil {
+		registryErrorsTotal.Counter.Inc()
+		deprecatedRegistryErrors.Counter.Inc()
+		backendErrorsTotal.CounterVec.WithLabelValues(b.Name).Inc()
+		c.health.recordFailure(componentProvider, err)
+		return plan.Results(changes, err), exported, true, err
+	}
+	for _, ep := range calculated.Adopted {
+		log.Infof("Adopted unowned %s record %s in backend %s", ep.RecordType, ep.DNSName, b.Name)
+	}
+	backendAdoptedRecordsTotal.CounterVec.WithLabelValues(b.Name).Add(float64(len(calculated.Adopted)))
+	return plan.Results(changes, nil), exported, true, nil
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func dnsNames(endpoints []*endpoint.Endpoint) []string {
+	names := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		names = append(names, ep.DNSName)
+	}
+	return names
+}
+
+func TestRouteEndpoints(t *testing.T) {
+	backends := []*Backend{
+		{Name: "default"},
+		{Name: "private", Access: "private"},
+		{Name: "internal", DomainFilter: endpoint.NewDomainFilter([]string{"internal.example.com"})},
+	}
+
+	private := endpoint.NewEndpoint("private.example.com", endpoint.RecordTypeA, "10.0.0.1").
+		WithProviderSpecific(annotations.AccessKey, "private")
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		private,
+		endpoint.NewEndpoint("public.example.com", endpoint.RecordTypeA, "1.2.3.5").
+			WithProviderSpecific(annotations.AccessKey, "public"),
+		endpoint.NewEndpoint("app.internal.example.com", endpoint.RecordTypeA, "10.0.0.2"),
+		endpoint.NewEndpoint("db.internal.example.com", endpoint.RecordTypeA, "10.0.0.3").
+			WithProviderSpecific(annotations.AccessKey, "private"),
+		endpoint.NewEndpoint("pinned.internal.example.com", endpoint.RecordTypeA, "10.0.0.4").
+			WithProviderSpecific(annotations.ProviderKey, "default"),
+		endpoint.NewEndpoint("both.example.com", endpoint.RecordTypeA, "10.0.0.5").
+			WithProviderSpecific(annotations.ProviderKey, "default, private"),
+		endpoint.NewEndpoint("unknown.example.com", endpoint.RecordTypeA, "10.0.0.6").
+			WithProviderSpecific(annotations.ProviderKey, "unknown"),
+	}
+
+	routed, unrouted := routeEndpoints(backends, endpoints)
+
+	require.Len(t, routed, 3)
+	assert.Equal(t, []string{"app.example.com", "public.example.com", "pinned.internal.example.com", "both.example.com"}, dnsNames(routed[0]))
+	assert.Equal(t, []string{"private.example.com", "db.internal.example.com", "both.example.com"}, dnsNames(routed[1]))
+	assert.Equal(t, []string{"app.internal.example.com", "db.internal.example.com"}, dnsNames(routed[2]))
+	assert.Equal(t, []string{"unknown.example.com"}, dnsNames(unrouted))
+
+	for _, eps := range routed {
+		for _, ep := range eps {
+			assert.Empty(t, ep.ProviderSpecific, ep.DNSName)
+		}
+	}
+	// the source endpoints are not modified, and endpoints routed to several backends are distinct
+	assert.Len(t, private.ProviderSpecific, 1)
+	assert.NotSame(t, routed[0][3], routed[1][2])
+	assert.NotSame(t, routed[1][1], routed[2][1])
+}
+
+func TestRouteEndpointsWithoutMatchingPrivateBackend(t *testing.T) {
+	backends := []*Backend{
+		{Name: "default"},
+		{Name: "private", Access: "private", DomainFilter: endpoint.NewDomainFilter([]string{"internal.example.com"})},
+	}
+
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("db.internal.example.com", endpoint.RecordTypeA, "10.0.0.1").
+			WithProviderSpecific(annotations.AccessKey, "private"),
+		// outside of the domain filter of the private backend
+		endpoint.NewEndpoint("db.example.com", endpoint.RecordTypeA, "10.0.0.2").
+			WithProviderSpecific(annotations.AccessKey, "private"),
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(annotations.AccessKey, "public"),
+		// the access values are case-sensitive
+		endpoint.NewEndpoint("cache.internal.example.com", endpoint.RecordTypeA, "10.0.0.3").
+			WithProviderSpecific(annotations.AccessKey, "Private"),
+		endpoint.NewEndpoint("queue.example.com", endpoint.RecordTypeA, "10.0.0.4").
+			WithProviderSpecific(annotations.AccessKey, "internal"),
+	}
+
+	routed, unrouted := routeEndpoints(backends, endpoints)
+
+	require.Len(t, routed, 2)
+	assert.Equal(t, []string{"app.example.com"}, dnsNames(routed[0]))
+	assert.Equal(t, []string{"db.internal.example.com"}, dnsNames(routed[1]))
+	assert.Equal(t, []string{"db.example.com", "cache.internal.example.com", "queue.example.com"}, dnsNames(unrouted))
+}
+
+func TestRunOnceWithBackends(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("db.example.com", endpoint.RecordTypeA, "10.0.0.1").
+			WithProviderSpecific(annotations.AccessKey, "private"),
+	}, nil)
+
+	public := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("db.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+		},
+	}
+	publicRegistry, err := registry.NewNoopRegistry(public)
+	require.NoError(t, err)
+	private := &filteredMockProvider{}
+	privateRegistry, err := registry.NewNoopRegistry(private)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           publicRegistry,
+		Backends:           []*Backend{{Name: "private", Registry: privateRegistry, Access: "private"}},
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	require.Len(t, public.ApplyChangesCalls, 1)
+	assert.Equal(t, []string{"app.example.com"}, dnsNames(public.ApplyChangesCalls[0].Create))
+	assert.Equal(t, []string{"db.example.com"}, dnsNames(public.ApplyChangesCalls[0].Delete))
+
+	require.Len(t, private.ApplyChangesCalls, 1)
+	assert.Equal(t, []string{"db.example.com"}, dnsNames(private.ApplyChangesCalls[0].Create))
+	assert.Empty(t, private.ApplyChangesCalls[0].Create[0].ProviderSpecific)
+
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, backendEndpointsTotal.Gauge, map[string]string{"backend": "default"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, backendEndpointsTotal.Gauge, map[string]string{"backend": "private"})
+}
+
+// failingRegistry fails to apply any changes.
+type failingRegistry struct {
+	registry.Registry
+}
+
+func (r *failingRegistry) ApplyChanges(_ context.Context, _ *plan.Changes) error {
+	return errors.New("provider unavailable")
+}
+
+func TestRunOnceWithFailingBackend(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("app.internal.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+	}, nil)
+
+	public := &filteredMockProvider{}
+	publicRegistry, err := registry.NewNoopRegistry(public)
+	require.NoError(t, err)
+	internalRegistry, err := registry.NewNoopRegistry(&filteredMockProvider{})
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:   source,
+		Registry: publicRegistry,
+		Backends: []*Backend{{
+			Name:         "internal",
+			Registry:     &failingRegistry{Registry: internalRegistry},
+			DomainFilter: endpoint.NewDomainFilter([]string{"internal.example.com"}),
+		}},
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	// the other backends are reconciled, even though one of them fails
+	assert.EqualError(t, ctrl.RunOnce(context.Background()), "backend internal: provider unavailable")
+	require.Len(t, public.ApplyChangesCalls, 1)
+	assert.Equal(t, []string{"app.example.com"}, dnsNames(public.ApplyChangesCalls[0].Create))
+}
+
+// adjustFailingRegistry fails to adjust any endpoints.
+type adjustFailingRegistry struct {
+	registry.Registry
+}
+
+func (r *adjustFailingRegistry) AdjustEndpoints(_ []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return nil, errors.New("invalid endpoint")
+}
+
+func TestRunOnceWithBackendFailingToAdjustEndpoints(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.internal.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+	}, nil)
+
+	publicRegistry, err := registry.NewNoopRegistry(&filteredMockProvider{})
+	require.NoError(t, err)
+	internalRegistry, err := registry.NewNoopRegistry(&filteredMockProvider{})
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:   source,
+		Registry: publicRegistry,
+		Backends: []*Backend{{
+			Name:         "internal",
+			Registry:     &adjustFailingRegistry{Registry: internalRegistry},
+			DomainFilter: endpoint.NewDomainFilter([]string{"internal.example.com"}),
+		}},
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		health:             newHealthChecker(time.Minute, 0, 0),
+	}
+
+	assert.EqualError(t, ctrl.RunOnce(context.Background()), "backend internal: adjusting endpoints: invalid endpoint")
+	resp, _ := ctrl.health.readiness()
+	assert.Equal(t, componentRegistry, resp.Component)
+	assert.Equal(t, "backend internal: adjusting endpoints: invalid endpoint", resp.Message)
+}
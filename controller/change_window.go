@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// buildChangeWindows creates the change window policies of the --change-window flags.
+func buildChangeWindows(cfg *externaldns.Config) ([]*plan.ChangeWindowPolicy, error) {
+	windows := make([]*plan.ChangeWindowPolicy, 0, len(cfg.ChangeWindows))
+	for _, value := range cfg.ChangeWindows {
+		w, err := externaldns.ParseChangeWindow(value)
+		if err != nil {
+			return nil, err
+		}
+		schedule, err := plan.ParseSchedule(w.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid change window %q: %w", value, err)
+		}
+		policy := &plan.ChangeWindowPolicy{
+			Schedule:    schedule,
+			Duration:    w.Duration,
+			AllowCreate: w.Outside == externaldns.ChangeWindowCreateOnly,
+		}
+		if len(w.DomainFilter) > 0 {
+			policy.DomainFilter = endpoint.NewDomainFilter(w.DomainFilter)
+		}
+		if w.Timezone != "" {
+			if policy.Location, err = time.LoadLocation(w.Timezone); err != nil {
+				return nil, err
+			}
+		}
+		windows = append(windows, policy)
+	}
+	return windows, nil
+}
+
+// recordDeferredChanges exports the changes of the backend deferred by the change windows, and schedules
+// a synchronization at the opening of the next window which deferred changes.
+func (c *Controller) recordDeferredChanges(b *Backend, now time.Time) {
+	var deferred plan.DeferredChanges
+	for _, w := range c.ChangeWindows {
+		d := w.Deferred()
+		deferred.Create += d.Create
+		deferred.Update += d.Update
+		deferred.Delete += d.Delete
+		if d.Total() == 0 {
+			continue
+		}
+		if next := w.Next(now); !next.IsZero() {
+			c.runAtMutex.Lock()
+			if c.windowOpensAt.IsZero() || next.Before(c.windowOpensAt) {
+				c.windowOpensAt = next
+			}
+			c.runAtMutex.Unlock()
+		}
+	}
+	backendDeferredChanges.Gauge.WithLabelValues(b.Name, "create").Set(float64(deferred.Create))
+	backendDeferredChanges.Gauge.WithLabelValues(b.Name, "update").Set(float64(deferred.Update))
+	backendDeferredChanges.Gauge.WithLabelValues(b.Name, "delete").Set(float64(deferred.Delete))
+}
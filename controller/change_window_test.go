@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestBuildChangeWindows(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.ChangeWindows = []string{
+		"domain-filter=example.com;schedule=0 2 * * 6;duration=2h;timezone=Europe/Berlin",
+		"schedule=0 22 * * 1,3;duration=1h;outside=none",
+	}
+	windows, err := buildChangeWindows(cfg)
+	require.NoError(t, err)
+	require.Len(t, windows, 2)
+	assert.True(t, windows[0].AllowCreate)
+	assert.Equal(t, 2*time.Hour, windows[0].Duration)
+	assert.Equal(t, "Europe/Berlin", windows[0].Location.String())
+	assert.True(t, windows[0].DomainFilter.Match("app.example.com"))
+	assert.False(t, windows[0].DomainFilter.Match("app.example.org"))
+	assert.False(t, windows[1].AllowCreate)
+	assert.Nil(t, windows[1].DomainFilter)
+	assert.Equal(t, "0 22 * * 1,3", windows[1].Schedule.String())
+
+	cfg.ChangeWindows = []string{"schedule=0 2 * * 8;duration=2h"}
+	_, err = buildChangeWindows(cfg)
+	assert.ErrorContains(t, err, "out of range")
+}
+
+func TestRunOnceChangeWindow(t *testing.T) {
+	// the window opens in three hours
+	opening := time.Now().UTC().Add(3 * time.Hour).Truncate(time.Minute)
+	schedule, err := plan.ParseSchedule(fmt.Sprintf("%d %d * * *", opening.Minute(), opening.Hour()))
+	require.NoError(t, err)
+
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.prod.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+		endpoint.NewEndpoint("new.prod.example.com", endpoint.RecordTypeA, "1.2.3.6"),
+		endpoint.NewEndpoint("app.dev.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+	}, nil)
+	p := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.prod.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("app.dev.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Interval:           24 * time.Hour,
+		ChangeWindows: []*plan.ChangeWindowPolicy{{
+			DomainFilter: endpoint.NewDomainFilter([]string{"prod.example.com"}),
+			Schedule:     schedule,
+			Duration:     time.Hour,
+			AllowCreate:  true,
+		}},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, p.ApplyChangesCalls, 1)
+	assert.Equal(t, []string{"new.prod.example.com"}, dnsNames(p.ApplyChangesCalls[0].Create))
+	assert.Equal(t, []string{"app.dev.example.com"}, dnsNames(p.ApplyChangesCalls[0].UpdateNew))
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, backendDeferredChanges.Gauge, map[string]string{"backend": "default", "action": "update"})
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, backendDeferredChanges.Gauge, map[string]string{"backend": "default", "action": "create"})
+
+	// the next synchronization runs when the window opens, before the interval
+	assert.Equal(t, opening, ctrl.windowOpensAt)
+	assert.True(t, ctrl.ShouldRunOnce(time.Now()))
+	assert.False(t, ctrl.ShouldRunOnce(opening.Add(-time.Minute)))
+	assert.True(t, ctrl.ShouldRunOnce(opening))
+}
@@ -20,13 +20,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
@@ -136,6 +139,106 @@ var (
 		[]string{"record_type"},
 	)
 
+	backendEndpointsTotal = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "registry",
+			Name:      "backend_endpoints_total",
+			Help:      "Number of Endpoints in the registry of a backend (vector).",
+		},
+		[]string{"backend"},
+	)
+
+	backendErrorsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "registry",
+			Name:      "backend_errors_total",
+			Help:      "Number of Registry errors of a backend (vector).",
+		},
+		[]string{"backend"},
+	)
+
+	backendLastSyncTimestamp = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "backend_last_sync_timestamp_seconds",
+			Help:      "Timestamp of last successful sync with the DNS provider of a backend (vector).",
+		},
+		[]string{"backend"},
+	)
+
+	backendConflictingRecords = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "backend_conflicting_records",
+			Help:      "Number of DNS names of a backend left alone because several resources claim them (vector).",
+		},
+		[]string{"backend"},
+	)
+
+	backendHeldDeletions = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "backend_held_deletions",
+			Help:      "Number of deletions of a backend held back by the deletion guard (vector).",
+		},
+		[]string{"backend"},
+	)
+
+	backendPendingDeletions = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "backend_pending_deletions",
+			Help:      "Number of records of a backend whose deletion is delayed by the grace period (vector).",
+		},
+		[]string{"backend"},
+	)
+
+	backendDeferredChanges = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "backend_deferred_changes",
+			Help:      "Number of changes of a backend deferred until a change window opens (vector).",
+		},
+		[]string{"backend", "action"},
+	)
+
+	backendFrozenRecords = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "backend_frozen_records",
+			Help:      "Number of records of a backend frozen by flap damping (vector).",
+		},
+		[]string{"backend"},
+	)
+
+	backendSuppressedChangesTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "backend_suppressed_changes_total",
+			Help:      "Number of target changes of frozen records of a backend suppressed by flap damping (vector).",
+		},
+		[]string{"backend"},
+	)
+
+	backendAdoptedRecordsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "backend_adopted_records_total",
+			Help:      "Number of unowned records of a backend adopted by ExternalDNS (vector).",
+		},
+		[]string{"backend"},
+	)
+
 	consecutiveSoftErrors = metrics.NewGaugeWithOpts(
 		prometheus.GaugeOpts{
 			Namespace: "external_dns",
@@ -161,6 +264,17 @@ func init() {
 	metrics.RegisterMetric.MustRegister(sourceRecords)
 	metrics.RegisterMetric.MustRegister(verifiedRecords)
 
+	metrics.RegisterMetric.MustRegister(backendEndpointsTotal)
+	metrics.RegisterMetric.MustRegister(backendErrorsTotal)
+	metrics.RegisterMetric.MustRegister(backendLastSyncTimestamp)
+	metrics.RegisterMetric.MustRegister(backendConflictingRecords)
+	metrics.RegisterMetric.MustRegister(backendHeldDeletions)
+	metrics.RegisterMetric.MustRegister(backendPendingDeletions)
+	metrics.RegisterMetric.MustRegister(backendDeferredChanges)
+	metrics.RegisterMetric.MustRegister(backendFrozenRecords)
+	metrics.RegisterMetric.MustRegister(backendSuppressedChangesTotal)
+	metrics.RegisterMetric.MustRegister(backendAdoptedRecordsTotal)
+
 	metrics.RegisterMetric.MustRegister(consecutiveSoftErrors)
 }
 
@@ -170,11 +284,29 @@ func init() {
 // * Ask the Source for the desired list of endpoints.
 // * Take both lists and calculate a Plan to move current towards the desired state.
 // * Tell the DNS provider to apply the changes calculated by the Plan.
+// With additional Backends, the endpoints are routed to the backends and a Plan is calculated for each.
 type Controller struct {
 	Source   source.Source
 	Registry registry.Registry
+	// Backends are additional DNS providers which endpoints are routed to by their provider or access annotation
+	// and the domain filters of the backends. Endpoints not routed to any of them are managed in the Registry.
+	Backends []*Backend
 	// The policy that defines which change to DNS records is allowed
 	Policy plan.Policy
+	// ChangeWindows restrict the changes of the records in their domains to change windows in all backends
+	ChangeWindows []*plan.ChangeWindowPolicy
+	// DeletionGrace delays the deletion of records no longer desired in all backends, nil if disabled
+	DeletionGrace *plan.DeletionGracePolicy
+	// FlapDamping freezes the records of the Registry whose targets flap, nil if disabled
+	FlapDamping *plan.FlapDampingPolicy
+	// DeletionGuard holds back the deletions of the Registry exceeding its limits, nil if disabled
+	DeletionGuard *plan.DeletionGuard
+	// ConflictResolver decides which resource acquires a DNS name claimed by several resources, the plan's default if nil
+	ConflictResolver plan.ConflictResolver
+	// MergeTargetsDomains are domains in which the targets of all resources claiming a DNS name are merged
+	MergeTargetsDomains []string
+	// AdoptDomains are domains in which unowned records desired by a resource are adopted
+	AdoptDomains []string
 	// The interval between individual synchronizations
 	Interval time.Duration
 	// The DomainFilter defines which DNS records to keep or exclude
@@ -185,12 +317,20 @@ type Controller struct {
 	runAtMutex sync.Mutex
 	// The lastRunAt used for throttling and batching reconciliation
 	lastRunAt time.Time
+	// The windowOpensAt is when the next change window with deferred changes opens, zero if none
+	windowOpensAt time.Time
 	// MangedRecordTypes are DNS record types that will be considered for management.
 	ManagedRecordTypes []string
 	// ExcludeRecordTypes are DNS record types that will be excluded from management.
 	ExcludeRecordTypes []string
 	// MinEventSyncInterval is used as a window for batching events
 	MinEventSyncInterval time.Duration
+	// EventRecorder emits Kubernetes Events on the objects the endpoints were generated from, nil if disabled
+	EventRecorder events.Recorder
+	// PlanExporter writes the changes calculated by every synchronization, nil if disabled
+	PlanExporter *PlanExporter
+	// health tracks reconciliation outcomes for the /healthz and /readyz endpoints
+	health *healthChecker
 }
 
 // RunOnce runs a single iteration of a reconciliation loop.
@@ -199,15 +339,26 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 
 	c.runAtMutex.Lock()
 	c.lastRunAt = time.Now()
+	c.windowOpensAt = time.Time{}
 	c.runAtMutex.Unlock()
 
 	regMetrics := newMetricsRecorder()
 
-	regRecords, err := c.Registry.Records(ctx)
-	if err != nil {
-		registryErrorsTotal.Counter.Inc()
-		deprecatedRegistryErrors.Counter.Inc()
-		return err
+	backends := c.backends()
+	records := make([][]*endpoint.Endpoint, len(backends))
+	var regRecords []*endpoint.Endpoint
+	for i, b := range backends {
+		var err error
+		records[i], err = b.Registry.Records(ctx)
+		if err != nil {
+			registryErrorsTotal.Counter.Inc()
+			deprecatedRegistryErrors.Counter.Inc()
+			backendErrorsTotal.CounterVec.WithLabelValues(b.Name).Inc()
+			c.health.recordFailure(componentRegistry, err)
+			return err
+		}
+		backendEndpointsTotal.SetWithLabels(float64(len(records[i])), b.Name)
+		regRecords = append(regRecords, records[i]...)
 	}
 
 	registryEndpointsTotal.Gauge.Set(float64(len(regRecords)))
@@ -220,6 +371,7 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	if err != nil {
 		sourceErrorsTotal.Counter.Inc()
 		deprecatedSourceErrors.Counter.Inc()
+		c.health.recordFailure(componentSource, err)
 		return err
 	}
 
@@ -231,41 +383,107 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	vaMetrics := newMetricsRecorder()
 	countMatchingAddressRecords(vaMetrics, sourceEndpoints, regRecords, verifiedRecords)
 
-	endpoints, err := c.Registry.AdjustEndpoints(sourceEndpoints)
-	if err != nil {
-		return fmt.Errorf("adjusting endpoints: %w", err)
-	}
-	registryFilter := c.Registry.GetDomainFilter()
-
-	plan := &plan.Plan{
-		Policies:       []plan.Policy{c.Policy},
-		Current:        regRecords,
-		Desired:        endpoints,
-		DomainFilter:   endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
-		ManagedRecords: c.ManagedRecordTypes,
-		ExcludeRecords: c.ExcludeRecordTypes,
-		OwnerID:        c.Registry.OwnerID(),
-	}
-
-	plan = plan.Calculate()
-
-	if plan.Changes.HasChanges() {
-		err = c.Registry.ApplyChanges(ctx, plan.Changes)
+	routed, unrouted := routeEndpoints(backends, sourceEndpoints)
+	for i, b := range backends {
+		routed[i], err = b.Registry.AdjustEndpoints(routed[i])
 		if err != nil {
 			registryErrorsTotal.Counter.Inc()
 			deprecatedRegistryErrors.Counter.Inc()
+			backendErrorsTotal.CounterVec.WithLabelValues(b.Name).Inc()
+			err = fmt.Errorf("backend %s: adjusting endpoints: %w", b.Name, err)
+			c.health.recordFailure(componentRegistry, err)
 			return err
 		}
-	} else {
+	}
+
+	results := make([]*plan.Result, 0, len(sourceEndpoints))
+	for _, ep := range unrouted {
+		results = append(results, &plan.Result{
+			Endpoint: ep,
+			Outcome:  plan.OutcomeIgnored,
+			Message:  "record is not routed to any backend",
+		})
+	}
+
+	var errs []error
+	var exported []exportedChange
+	hasChanges := false
+	for i, b := range backends {
+		backendResults, backendExported, changed, err := c.reconcile(ctx, b, records[i], routed[i])
+		results = append(results, backendResults...)
+		exported = append(exported, backendExported...)
+		hasChanges = hasChanges || changed
+		if err != nil {
+			if len(backends) > 1 {
+				err = fmt.Errorf("backend %s: %w", b.Name, err)
+			}
+			errs = append(errs, err)
+			continue
+		}
+		backendLastSyncTimestamp.SetWithLabels(float64(time.Now().Unix()), b.Name)
+	}
+
+	if !hasChanges && len(errs) == 0 {
 		controllerNoChangesTotal.Counter.Inc()
 		log.Info("All records are already up to date")
 	}
 
+	c.reportResults(ctx, results)
+
+	if c.PlanExporter != nil {
+		if err := c.PlanExporter.Export(exported); err != nil {
+			errs = append(errs, fmt.Errorf("exporting plan: %w", err))
+		}
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
 	lastSyncTimestamp.Gauge.SetToCurrentTime()
+	c.health.recordSync(time.Now())
 
 	return nil
 }
 
+// reportResults hands the outcome of a reconciliation to the source, so that it can be
+// written back to the objects the endpoints were generated from, and emits an event on
+// these objects for every record that was changed or could not be changed.
+func (c *Controller) reportResults(ctx context.Context, results []*plan.Result) {
+	if reporter, ok := c.Source.(source.StatusReporter); ok {
+		reporter.ReportStatus(ctx, results)
+	}
+	if c.EventRecorder == nil {
+		return
+	}
+	for _, r := range results {
+		emitEvent(c.EventRecorder, r)
+	}
+}
+
+func emitEvent(recorder events.Recorder, r *plan.Result) {
+	resource := r.Endpoint.Labels[endpoint.ResourceLabelKey]
+	if resource == "" {
+		return
+	}
+	ep := r.Endpoint
+	switch r.Outcome {
+	case plan.OutcomeCreated:
+		recorder.Eventf(resource, corev1.EventTypeNormal, events.ReasonRecordCreated, "Created %s record %s → %s", ep.RecordType, ep.DNSName, strings.Join(ep.Targets, ", "))
+	case plan.OutcomeUpdated:
+		recorder.Eventf(resource, corev1.EventTypeNormal, events.ReasonRecordUpdated, "Updated %s record %s → %s", ep.RecordType, ep.DNSName, strings.Join(ep.Targets, ", "))
+	case plan.OutcomeDeleted:
+		recorder.Eventf(resource, corev1.EventTypeNormal, events.ReasonRecordDeleted, "Deleted %s record %s", ep.RecordType, ep.DNSName)
+	case plan.OutcomeConflicted:
+		recorder.Eventf(resource, corev1.EventTypeWarning, events.ReasonRecordConflicted, "Skipped %s record %s: %s", ep.RecordType, ep.DNSName, r.Message)
+	case plan.OutcomeFailed:
+		recorder.Eventf(resource, corev1.EventTypeWarning, events.ReasonProviderRejected, "%s record %s: %s", ep.RecordType, ep.DNSName, r.Message)
+	}
+}
+
 func earliest(r time.Time, times ...time.Time) time.Time {
 	for _, t := range times {
 		if t.Before(r) {
@@ -337,7 +555,8 @@ func (c *Controller) ScheduleRunOnce(now time.Time) {
 func (c *Controller) ShouldRunOnce(now time.Time) bool {
 	c.runAtMutex.Lock()
 	defer c.runAtMutex.Unlock()
-	if now.Before(c.nextRunAt) {
+	// deferred changes are applied as soon as their change window opens
+	if now.Before(c.nextRunAt) && (c.windowOpensAt.IsZero() || now.Before(c.windowOpensAt)) {
 		return false
 	}
 	c.nextRunAt = now.Add(c.Interval)
@@ -348,6 +567,7 @@ func (c *Controller) ShouldRunOnce(now time.Time) bool {
 func (c *Controller) Run(ctx context.Context) {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
+	c.health.start(time.Now())
 	var softErrorCount int
 	for {
 		if c.ShouldRunOnce(time.Now()) {
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"sort"
 	"sync"
@@ -30,8 +31,11 @@ import (
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
 	"sigs.k8s.io/external-dns/registry"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -553,3 +557,223 @@ func TestToggleRegistry(t *testing.T) {
 	r.failCountMu.Unlock()
 	assert.Equal(t, toggleRegistryFailureCount, finalCount, "failCount should be at least %d", toggleRegistryFailureCount)
 }
+
+type fakeEventRecorder struct {
+	events []string
+}
+
+func (r *fakeEventRecorder) Eventf(resource, eventType, reason, messageFmt string, args ...interface{}) {
+	r.events = append(r.events, fmt.Sprintf("%s %s %s %s", resource, eventType, reason, fmt.Sprintf(messageFmt, args...)))
+}
+
+func TestRunOnceEmitsEvents(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("create.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/app"),
+		endpoint.NewEndpoint("update.example.com", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+		endpoint.NewEndpoint("unchanged.example.com", endpoint.RecordTypeA, "3.3.3.3").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+		endpoint.NewEndpoint("nolabel.example.com", endpoint.RecordTypeA, "4.4.4.4"),
+	}, nil)
+
+	p := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("update.example.com", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+			endpoint.NewEndpoint("unchanged.example.com", endpoint.RecordTypeA, "3.3.3.3").WithLabel(endpoint.ResourceLabelKey, "service/default/app"),
+			endpoint.NewEndpoint("delete.example.com", endpoint.RecordTypeA, "5.5.5.5").WithLabel(endpoint.ResourceLabelKey, "ingress/default/old"),
+		},
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	recorder := &fakeEventRecorder{}
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		EventRecorder:      recorder,
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.ElementsMatch(t, []string{
+		"ingress/default/app Normal RecordCreated Created A record create.example.com → 1.2.3.4",
+		"service/default/app Normal RecordUpdated Updated A record update.example.com → 2.2.2.2",
+		"ingress/default/old Normal RecordDeleted Deleted A record delete.example.com",
+	}, recorder.events)
+}
+
+func TestEmitEventForRejectedChanges(t *testing.T) {
+	ep := endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/app")
+
+	recorder := &fakeEventRecorder{}
+	emitEvent(recorder, &plan.Result{Endpoint: ep, Outcome: plan.OutcomeConflicted, Message: `owned by another owner "other"`})
+	emitEvent(recorder, &plan.Result{Endpoint: ep, Outcome: plan.OutcomeFailed, Message: "provider rejected change: throttled"})
+	emitEvent(recorder, &plan.Result{Endpoint: ep, Live: ep, Outcome: plan.OutcomeUnchanged})
+
+	assert.Equal(t, []string{
+		`ingress/default/app Warning RecordConflicted Skipped A record app.example.com: owned by another owner "other"`,
+		"ingress/default/app Warning ProviderRejected A record app.example.com: provider rejected change: throttled",
+	}, recorder.events)
+}
+
+func TestRunOnceRefusesConflicts(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("shared.example.com", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "ingress/default/a"),
+		endpoint.NewEndpoint("shared.example.com", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "ingress/default/b"),
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "3.3.3.3").
+			WithLabel(endpoint.ResourceLabelKey, "ingress/default/app").
+			WithLabel(endpoint.ResourceCreatedLabelKey, "2024-01-01T00:00:00Z"),
+	}, nil)
+
+	p := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	recorder := &fakeEventRecorder{}
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ConflictResolver:   plan.RefuseConflicts{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		EventRecorder:      recorder,
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, p.ApplyChangesCalls, 1)
+	require.Len(t, p.ApplyChangesCalls[0].Create, 1)
+	assert.Equal(t, "app.example.com", p.ApplyChangesCalls[0].Create[0].DNSName)
+	assert.NotContains(t, p.ApplyChangesCalls[0].Create[0].Labels, endpoint.ResourceCreatedLabelKey)
+
+	assert.ElementsMatch(t, []string{
+		"ingress/default/a Warning RecordConflicted Skipped A record shared.example.com: record is claimed by several resources: ingress/default/a, ingress/default/b",
+		"ingress/default/b Warning RecordConflicted Skipped A record shared.example.com: record is claimed by several resources: ingress/default/a, ingress/default/b",
+		"ingress/default/app Normal RecordCreated Created A record app.example.com → 3.3.3.3",
+	}, recorder.events)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, backendConflictingRecords.Gauge, map[string]string{"backend": "default"})
+}
+
+func TestRunOnceAdoptsRecords(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "ingress/default/app"),
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "ingress/default/www"),
+	}, nil)
+
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.com"))
+	require.NoError(t, p.CreateZone("example.org"))
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "2.2.2.2"),
+		},
+	}))
+	r, err := registry.NewTXTRegistry(p, "", "", "owner", 0, "", nil, nil, false, nil, false)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		AdoptDomains:       []string{"example.com"},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	adopted := promtestutil.ToFloat64(backendAdoptedRecordsTotal.CounterVec.WithLabelValues("default"))
+	hook := testutils.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	testutils.TestHelperLogContains("Adopted unowned A record app.example.com in backend default", hook, t)
+	assert.InDelta(t, adopted+1, promtestutil.ToFloat64(backendAdoptedRecordsTotal.CounterVec.WithLabelValues("default")), 0)
+
+	records, err := r.Records(context.Background())
+	require.NoError(t, err)
+	owners := map[string]string{}
+	for _, ep := range records {
+		owners[ep.DNSName] = ep.Labels[endpoint.OwnerLabelKey]
+	}
+	assert.Equal(t, map[string]string{"app.example.com": "owner", "www.example.org": ""}, owners)
+}
+
+func TestRunOnceDeletionGrace(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil).Once()
+	p := newAuditProvider(t)
+	r, err := registry.NewTXTRegistry(p, "", "", "owner", 0, "", nil, nil, false, nil, true)
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		DomainFilter:       endpoint.NewDomainFilter([]string{"app.example.com", "old.example.com"}),
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		DeletionGrace:      &plan.DeletionGracePolicy{GracePeriod: time.Hour},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, backendPendingDeletions.Gauge, map[string]string{"backend": "default"})
+
+	// the pending deletion is stored in the registry, it survives a restart
+	r, err = registry.NewTXTRegistry(p, "", "", "owner", 0, "", nil, nil, false, nil, true)
+	require.NoError(t, err)
+	records, err := r.Records(context.Background())
+	require.NoError(t, err)
+	pending := map[string]bool{}
+	for _, ep := range records {
+		if _, ok := ep.Labels[endpoint.DeletionPendingLabelKey]; ok {
+			pending[ep.DNSName] = true
+		}
+	}
+	assert.Equal(t, map[string]bool{"old.example.com": true}, pending)
+
+	// the record desired again loses its pending deletion
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+	}, nil)
+	ctrl.Registry = r
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, backendPendingDeletions.Gauge, map[string]string{"backend": "default"})
+	records, err = r.Records(context.Background())
+	require.NoError(t, err)
+	for _, ep := range records {
+		assert.NotContains(t, ep.Labels, endpoint.DeletionPendingLabelKey, ep.DNSName)
+	}
+}
+
+func TestRunOnceFlapDamping(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("pod.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+	}, nil)
+	p := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("pod.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	cfg := externaldns.NewConfig()
+	cfg.FlapDampingMaxChanges = 1
+	cfg.FlapDampingWindow = time.Hour
+	cfg.FlapDampingMaxBackoff = time.Hour
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		FlapDamping:        buildFlapDamping(cfg),
+	}
+
+	suppressed := promtestutil.ToFloat64(backendSuppressedChangesTotal.CounterVec.WithLabelValues("default"))
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, p.ApplyChangesCalls, 1)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, backendFrozenRecords.Gauge, map[string]string{"backend": "default"})
+
+	// the provider did not apply the change, the second change of the targets freezes the record
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 1)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 1, backendFrozenRecords.Gauge, map[string]string{"backend": "default"})
+	assert.InDelta(t, suppressed+1, promtestutil.ToFloat64(backendSuppressedChangesTotal.CounterVec.WithLabelValues("default")), 0)
+}
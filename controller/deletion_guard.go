@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// deletionApprovalAnnotation is the annotation of the --deletion-guard-configmap ConfigMap whose
+// comma separated fingerprints approve the deletions held back by the deletion guards.
+const deletionApprovalAnnotation = "external-dns.alpha.kubernetes.io/approve-deletions"
+
+// setupDeletionGuards gives the controller and every backend its own deletion guard, since a guard
+// remembers the deletions of its previous plan. Nothing is set up when no limit is configured.
+func setupDeletionGuards(cfg *externaldns.Config, ctrl *Controller) error {
+	if cfg.DeletionGuardMaxDeletions == 0 && cfg.DeletionGuardMaxPercent == 0 {
+		return nil
+	}
+
+	var approved func(string) bool
+	if cfg.DeletionGuardConfigMap != "" {
+		client, err := source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout)
+		if err != nil {
+			return err
+		}
+		namespace, name, _ := strings.Cut(cfg.DeletionGuardConfigMap, "/")
+		approved = deletionApproval(client, namespace, name, cfg.RequestTimeout)
+	}
+
+	newGuard := func() *plan.DeletionGuard {
+		return &plan.DeletionGuard{
+			MaxDeletions:        cfg.DeletionGuardMaxDeletions,
+			MaxDeletionsPercent: cfg.DeletionGuardMaxPercent,
+			Confirmations:       cfg.DeletionGuardConfirmations,
+			Approved:            approved,
+		}
+	}
+	ctrl.DeletionGuard = newGuard()
+	for _, b := range ctrl.Backends {
+		b.DeletionGuard = newGuard()
+	}
+	return nil
+}
+
+// deletionApproval returns a function telling whether the deletions with a fingerprint are approved
+// by the annotation of the ConfigMap. The ConfigMap is read whenever deletions are held back, a
+// missing ConfigMap approves nothing.
+func deletionApproval(client kubernetes.Interface, namespace, name string, timeout time.Duration) func(string) bool {
+	return func(fingerprint string) bool {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		cm, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.Warnf("Failed to read the deletion approvals of ConfigMap %s/%s: %v", namespace, name, err)
+			}
+			return false
+		}
+		for _, approved := range strings.Split(cm.Annotations[deletionApprovalAnnotation], ",") {
+			if strings.TrimSpace(approved) == fingerprint {
+				return true
+			}
+		}
+		return false
+	}
+}
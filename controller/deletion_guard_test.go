@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestSetupDeletionGuards(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	ctrl := &Controller{Backends: []*Backend{{Name: "private"}}}
+	require.NoError(t, setupDeletionGuards(cfg, ctrl))
+	assert.Nil(t, ctrl.DeletionGuard)
+	assert.Nil(t, ctrl.Backends[0].DeletionGuard)
+
+	cfg.DeletionGuardMaxPercent = 20
+	cfg.DeletionGuardConfirmations = 3
+	require.NoError(t, setupDeletionGuards(cfg, ctrl))
+	require.NotNil(t, ctrl.DeletionGuard)
+	assert.Equal(t, 20, ctrl.DeletionGuard.MaxDeletionsPercent)
+	assert.Equal(t, 3, ctrl.DeletionGuard.Confirmations)
+	assert.Nil(t, ctrl.DeletionGuard.Approved)
+	// every backend remembers its own deletions
+	require.NotNil(t, ctrl.Backends[0].DeletionGuard)
+	assert.NotSame(t, ctrl.DeletionGuard, ctrl.Backends[0].DeletionGuard)
+}
+
+func TestDeletionApproval(t *testing.T) {
+	client := fake.NewClientset()
+	approved := deletionApproval(client, "external-dns", "deletion-approval", time.Second)
+	assert.False(t, approved("0123456789ab"))
+
+	_, err := client.CoreV1().ConfigMaps("external-dns").Create(context.Background(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "deletion-approval",
+			Namespace:   "external-dns",
+			Annotations: map[string]string{deletionApprovalAnnotation: "ba9876543210, 0123456789ab"},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.True(t, approved("0123456789ab"))
+	assert.True(t, approved("ba9876543210"))
+	assert.False(t, approved("0123456789"))
+}
+
+func TestRunOnceDeletionGuard(t *testing.T) {
+	var records []*endpoint.Endpoint
+	for i := range 4 {
+		records = append(records, endpoint.NewEndpoint(fmt.Sprintf("app-%d.example.com", i), endpoint.RecordTypeA, "1.2.3.4"))
+	}
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app-0.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+	}, nil)
+	p := &filteredMockProvider{RecordsStore: records}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		DeletionGuard:      &plan.DeletionGuard{MaxDeletionsPercent: 50, Confirmations: 2},
+	}
+
+	// 3 of 4 records are deleted, the creation is applied nevertheless
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, p.ApplyChangesCalls, 1)
+	assert.Equal(t, []string{"new.example.com"}, dnsNames(p.ApplyChangesCalls[0].Create))
+	assert.Empty(t, p.ApplyChangesCalls[0].Delete)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 3, backendHeldDeletions.Gauge, map[string]string{"backend": "default"})
+
+	// the second plan with the same deletions confirms them
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, p.ApplyChangesCalls, 2)
+	assert.Len(t, p.ApplyChangesCalls[1].Delete, 3)
+	testutils.TestHelperVerifyMetricsGaugeVectorWithLabels(t, 0, backendHeldDeletions.Gauge, map[string]string{"backend": "default"})
+}
@@ -22,6 +22,8 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
@@ -36,6 +38,7 @@ import (
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns/validation"
+	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
@@ -97,10 +100,37 @@ func Execute() {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	go serveMetrics(cfg.MetricsAddress)
+	health := newHealthChecker(cfg.Interval, cfg.HealthCheckMaxSyncIntervals, cfg.HealthCheckMaxSoftErrors)
+	go serveMetrics(cfg.MetricsAddress, health)
 	go handleSigterm(cancel)
 
-	endpointsSource, err := buildSource(ctx, cfg)
+	if cfg.FromOwner != "" {
+		if err := runOwnershipHandover(ctx, cfg, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	if cfg.TXTMigrate {
+		if err := runTXTMigration(ctx, cfg, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	if cfg.PlanOffline {
+		if err := runOfflinePlan(ctx, cfg, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	recorder, err := buildEventRecorder(ctx, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	endpointsSource, err := buildSource(ctx, cfg, recorder)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -112,6 +142,14 @@ func Execute() {
 		log.Fatal(err)
 	}
 
+	if cfg.Audit {
+		code, err := runAudit(ctx, cfg, endpointsSource, prvdr, os.Stdout)
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(code)
+	}
+
 	if cfg.WebhookServer {
 		webhookapi.StartHTTPApi(prvdr, nil, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, "127.0.0.1:8888")
 		os.Exit(0)
@@ -121,6 +159,16 @@ func Execute() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	ctrl.health = health
+	ctrl.EventRecorder = recorder
+
+	ctrl.Backends, err = buildBackends(ctx, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := setupDeletionGuards(cfg, ctrl); err != nil {
+		log.Fatal(err)
+	}
 
 	if cfg.Once {
 		err := ctrl.RunOnce(ctx)
@@ -138,6 +186,17 @@ func Execute() {
 		ctrl.Source.AddEventHandler(ctx, func() { ctrl.ScheduleRunOnce(time.Now()) })
 	}
 
+	if cfg.EnableLeaderElection {
+		client, err := source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := runWithLeaderElection(ctx, cfg, client, ctrl); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	ctrl.ScheduleRunOnce(time.Now())
 	ctrl.Run(ctx)
 }
@@ -344,22 +403,114 @@ func buildController(cfg *externaldns.Config, src source.Source, p provider.Prov
 	if !ok {
 		return nil, fmt.Errorf("unknown policy: %s", cfg.Policy)
 	}
+	resolver, err := buildConflictResolver(cfg)
+	if err != nil {
+		return nil, err
+	}
 	reg, err := selectRegistry(cfg, p)
 	if err != nil {
 		return nil, err
 	}
+	windows, err := buildChangeWindows(cfg)
+	if err != nil {
+		return nil, err
+	}
 	return &Controller{
 		Source:               src,
 		Registry:             reg,
 		Policy:               policy,
+		ConflictResolver:     resolver,
+		MergeTargetsDomains:  cfg.MergeTargetsDomains,
+		AdoptDomains:         cfg.AdoptDomains,
 		Interval:             cfg.Interval,
 		DomainFilter:         filter,
 		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
 		ExcludeRecordTypes:   cfg.ExcludeDNSRecordTypes,
 		MinEventSyncInterval: cfg.MinEventSyncInterval,
+		PlanExporter:         buildPlanExporter(cfg),
+		DeletionGrace:        buildDeletionGrace(cfg),
+		ChangeWindows:        windows,
+		FlapDamping:          buildFlapDamping(cfg),
 	}, nil
 }
 
+// buildFlapDamping returns the flap damping of --flap-damping-max-changes, or nil when it is not set.
+func buildFlapDamping(cfg *externaldns.Config) *plan.FlapDampingPolicy {
+	if cfg.FlapDampingMaxChanges == 0 {
+		return nil
+	}
+	return &plan.FlapDampingPolicy{
+		MaxChanges: cfg.FlapDampingMaxChanges,
+		Window:     cfg.FlapDampingWindow,
+		MaxBackoff: cfg.FlapDampingMaxBackoff,
+	}
+}
+
+// buildDeletionGrace returns the policy delaying deletions by --deletion-grace-period, or nil when it is not set.
+func buildDeletionGrace(cfg *externaldns.Config) *plan.DeletionGracePolicy {
+	if cfg.DeletionGracePeriod == 0 {
+		return nil
+	}
+	return &plan.DeletionGracePolicy{GracePeriod: cfg.DeletionGracePeriod}
+}
+
+// buildPlanExporter returns the exporter writing the changes to --plan-export-file, or nil when it is not set.
+func buildPlanExporter(cfg *externaldns.Config) *PlanExporter {
+	if cfg.PlanExportFile == "" {
+		return nil
+	}
+	return &PlanExporter{Format: cfg.PlanExportFormat, Path: cfg.PlanExportFile}
+}
+
+// buildConflictResolver creates the conflict resolver of the --conflict-resolution flag, limited to the
+// namespaces of the --conflict-namespace-allowlist flag.
+func buildConflictResolver(cfg *externaldns.Config) (plan.ConflictResolver, error) {
+	resolver, ok := plan.ConflictResolvers[cfg.ConflictResolution]
+	if !ok {
+		return nil, fmt.Errorf("unknown conflict resolution: %s", cfg.ConflictResolution)
+	}
+	if len(cfg.ConflictNamespaceAllowList) == 0 {
+		return resolver, nil
+	}
+	namespaces := make(map[string][]string, len(cfg.ConflictNamespaceAllowList))
+	for domain, list := range cfg.ConflictNamespaceAllowList {
+		for _, namespace := range strings.Split(list, ",") {
+			namespaces[domain] = append(namespaces[domain], strings.TrimSpace(namespace))
+		}
+	}
+	return plan.NamespaceAllowList{Namespaces: namespaces, Resolver: resolver}, nil
+}
+
+// buildBackends creates the additional backends of the --backend flags, each with its own provider and registry
+// configured by the flags of cfg, overridden by the settings of the backend.
+func buildBackends(ctx context.Context, cfg *externaldns.Config) ([]*Backend, error) {
+	backends := make([]*Backend, 0, len(cfg.Backends))
+	for _, value := range cfg.Backends {
+		b, err := externaldns.ParseBackend(value)
+		if err != nil {
+			return nil, err
+		}
+		backendCfg := b.Config(cfg)
+		domainFilter := createDomainFilter(backendCfg)
+		p, err := buildProvider(ctx, backendCfg, domainFilter)
+		if err != nil {
+			return nil, fmt.Errorf("backend %s: %w", b.Name, err)
+		}
+		r, err := selectRegistry(backendCfg, p)
+		if err != nil {
+			return nil, fmt.Errorf("backend %s: %w", b.Name, err)
+		}
+		backends = append(backends, &Backend{
+			Name:         b.Name,
+			Registry:     r,
+			DomainFilter: domainFilter,
+			Access:       b.Access,
+			FlapDamping:  buildFlapDamping(backendCfg),
+		})
+	}
+	return backends, nil
+}
+
 // This function configures the logger format and level based on the provided configuration.
 func configureLogger(cfg *externaldns.Config) {
 	if cfg.LogFormat == "json" {
@@ -374,7 +525,7 @@ func configureLogger(cfg *externaldns.Config) {
 
 // selectRegistry selects the appropriate registry implementation based on the configuration in cfg.
 // It initializes and returns a registry along with any error encountered during setup.
-// Supported registry types include: dynamodb, noop, txt, and aws-sd.
+// Supported registry types include: dynamodb, noop, txt, aws-sd and configmap.
 func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Registry, error) {
 	var r registry.Registry
 	var err error
@@ -395,6 +546,12 @@ func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Regi
 		r, err = registry.NewTXTRegistry(p, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTOwnerID, cfg.TXTCacheInterval, cfg.TXTWildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, cfg.TXTEncryptEnabled, []byte(cfg.TXTEncryptAESKey), cfg.TXTNewFormatOnly)
 	case "aws-sd":
 		r, err = registry.NewAWSSDRegistry(p, cfg.TXTOwnerID)
+	case "configmap":
+		client, clientErr := source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout)
+		if clientErr != nil {
+			return nil, clientErr
+		}
+		r, err = registry.NewConfigMapRegistry(p, cfg.TXTOwnerID, client, cfg.ConfigMapRegistryNamespace, cfg.ConfigMapRegistryName, cfg.ConfigMapRegistryShards)
 	default:
 		log.Fatalf("unknown registry: %s", cfg.Registry)
 	}
@@ -404,9 +561,8 @@ func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Regi
 // buildSource creates and configures the source(s) for endpoint discovery based on the provided configuration.
 // It initializes the source configuration, generates the required sources, and combines them into a single,
 // deduplicated source. Returns the combined source or an error if source creation fails.
-func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, error) {
-	sourceCfg := source.NewSourceConfig(cfg)
-	sources, err := source.ByNames(ctx, &source.SingletonClientGenerator{
+func buildSource(ctx context.Context, cfg *externaldns.Config, recorder events.Recorder) (source.Source, error) {
+	return newSource(ctx, cfg, &source.SingletonClientGenerator{
 		KubeConfig:   cfg.KubeConfig,
 		APIServerURL: cfg.APIServerURL,
 		RequestTimeout: func() time.Duration {
@@ -415,7 +571,14 @@ func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, e
 			}
 			return cfg.RequestTimeout
 		}(),
-	}, cfg.Sources, sourceCfg)
+	}, recorder)
+}
+
+// newSource creates the source(s) of the configuration with the clients of the generator.
+func newSource(ctx context.Context, cfg *externaldns.Config, generator source.ClientGenerator, recorder events.Recorder) (source.Source, error) {
+	sourceCfg := source.NewSourceConfig(cfg)
+	sourceCfg.EventRecorder = recorder
+	sources, err := source.ByNames(ctx, generator, cfg.Sources, sourceCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -425,15 +588,43 @@ func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, e
 	targetFilter := endpoint.NewTargetNetFilterWithExclusions(cfg.TargetNetFilter, cfg.ExcludeTargetNets)
 	combinedSource = source.NewNAT64Source(combinedSource, cfg.NAT64Networks)
 	combinedSource = source.NewTargetFilterSource(combinedSource, targetFilter)
-	return combinedSource, nil
+	// Add PTR records for the addresses in the reverse zones
+	return source.NewPTRSource(combinedSource, cfg.ReverseZones)
+}
+
+// buildEventRecorder returns the recorder emitting Kubernetes Events on source objects,
+// or nil when events are disabled. No events are emitted in dry-run mode, since no
+// record is actually changed.
+func buildEventRecorder(ctx context.Context, cfg *externaldns.Config) (events.Recorder, error) {
+	if !cfg.EmitEvents {
+		return nil, nil
+	}
+	if cfg.DryRun {
+		log.Info("Kubernetes Events are not emitted in dry-run mode")
+		return nil, nil
+	}
+	client, err := source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := source.NewDynamicKubernetesClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return events.NewRecorder(ctx, client, dynamicClient), nil
 }
 
 // RegexDomainFilter overrides DomainFilter
+// The reverse zones are managed in addition to the domains of the DomainFilter.
 func createDomainFilter(cfg *externaldns.Config) endpoint.DomainFilter {
 	if cfg.RegexDomainFilter != nil && cfg.RegexDomainFilter.String() != "" {
 		return endpoint.NewRegexDomainFilter(cfg.RegexDomainFilter, cfg.RegexDomainExclusion)
 	} else {
-		return endpoint.NewDomainFilterWithExclusions(cfg.DomainFilter, cfg.ExcludeDomains)
+		domains := cfg.DomainFilter
+		if reverseZones, err := source.ReverseZoneNames(cfg.ReverseZones); err == nil && endpoint.NewDomainFilter(domains).IsConfigured() {
+			domains = append(slices.Clone(domains), reverseZones...)
+		}
+		return endpoint.NewDomainFilterWithExclusions(domains, cfg.ExcludeDomains)
 	}
 }
 
@@ -448,16 +639,17 @@ func handleSigterm(cancel func()) {
 }
 
 // serveMetrics starts an HTTP server that serves health and metrics endpoints.
-// The /healthz endpoint returns a 200 OK status to indicate the service is healthy.
+// The /healthz endpoint fails when the reconciliation loop stops making progress.
+// The /readyz endpoint succeeds once the first synchronization with the DNS provider succeeded.
+// Both return a JSON document naming the failing component.
 // The /metrics endpoint serves Prometheus metrics.
 // The server listens on the specified address and logs debug information about the endpoints.
-func serveMetrics(address string) {
-	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("OK"))
-	})
+func serveMetrics(address string, health *healthChecker) {
+	http.HandleFunc("/healthz", health.livenessHandler)
+	http.HandleFunc("/readyz", health.readinessHandler)
 
 	log.Debugf("serving 'healthz' on '%s/healthz'", address)
+	log.Debugf("serving 'readyz' on '%s/readyz'", address)
 	log.Debugf("serving 'metrics' on '%s/metrics'", address)
 	log.Debugf("registered '%d' metrics", len(metrics.RegisterMetric.Metrics))
 
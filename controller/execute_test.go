@@ -163,6 +163,23 @@ func TestCreateDomainFilter(t *testing.T) {
 			expectedDomainFilter: endpoint.NewDomainFilterWithExclusions([]string{"example.com"}, []string{"excluded.example.com"}),
 			isConfigured:         true,
 		},
+		{
+			name: "DomainFilterWithReverseZones",
+			cfg: &externaldns.Config{
+				DomainFilter: []string{"example.com"},
+				ReverseZones: []string{"10.0.0.0/24", "10.1.0.0/26"},
+			},
+			expectedDomainFilter: endpoint.NewDomainFilterWithExclusions([]string{"example.com", "0.0.10.in-addr.arpa", "0/26.0.1.10.in-addr.arpa"}, nil),
+			isConfigured:         true,
+		},
+		{
+			name: "ReverseZonesWithoutDomainFilter",
+			cfg: &externaldns.Config{
+				ReverseZones: []string{"10.0.0.0/24"},
+			},
+			expectedDomainFilter: endpoint.NewDomainFilterWithExclusions(nil, nil),
+			isConfigured:         false,
+		},
 		{
 			name: "DomainFilterWithExclusionsOnly",
 			cfg: &externaldns.Config{
@@ -241,7 +258,7 @@ func TestServeMetrics(t *testing.T) {
 	require.NoError(t, err)
 	addresse := fmt.Sprintf("localhost:%d", port)
 
-	go serveMetrics(fmt.Sprintf(":%d", port))
+	go serveMetrics(fmt.Sprintf(":%d", port), newHealthChecker(time.Minute, 0, 0))
 
 	// Wait for the TCP socket to be ready
 	require.Eventually(t, func() bool {
@@ -257,6 +274,10 @@ func TestServeMetrics(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
+	resp, err = http.Get(fmt.Sprintf("http://%s/readyz", addresse))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
 	resp, err = http.Get(fmt.Sprintf("http://%s/metrics", addresse))
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
@@ -465,7 +486,7 @@ func TestBuildSource(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			src, err := buildSource(t.Context(), tt.cfg)
+			src, err := buildSource(t.Context(), tt.cfg, nil)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -496,3 +517,54 @@ func (m *MockProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpo
 func (m *MockProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return nil
 }
+
+func TestBuildBackends(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{
+		"--source=fake",
+		"--provider=inmemory",
+		"--domain-filter=example.com",
+		"--backend=name=private,provider=inmemory,domain-filter=internal.example.com,access=private,txt-owner-id=private",
+		"--backend=name=public,provider=inmemory,txt-owner-id=public",
+	}))
+
+	backends, err := buildBackends(t.Context(), cfg)
+	require.NoError(t, err)
+	require.Len(t, backends, 2)
+
+	assert.Equal(t, "private", backends[0].Name)
+	assert.Equal(t, "private", backends[0].Registry.OwnerID())
+	assert.Equal(t, "private", backends[0].Access)
+	assert.True(t, backends[0].DomainFilter.Match("app.internal.example.com"))
+	assert.False(t, backends[0].DomainFilter.Match("app.example.com"))
+
+	assert.Equal(t, "public", backends[1].Name)
+	assert.Equal(t, "public", backends[1].Registry.OwnerID())
+	assert.Empty(t, backends[1].Access)
+	assert.True(t, backends[1].DomainFilter.Match("app.example.com"))
+
+	cfg.Backends = []string{"name=private,provider=gandi"}
+	_, err = buildBackends(t.Context(), cfg)
+	assert.EqualError(t, err, "backend private: no environment variable GANDI_KEY or GANDI_PAT provided")
+}
+
+func TestBuildConflictResolver(t *testing.T) {
+	cfg := externaldns.NewConfig()
+	cfg.ConflictResolution = "oldest"
+	resolver, err := buildConflictResolver(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, plan.OldestResource{}, resolver)
+
+	cfg.ConflictResolution = "refuse"
+	cfg.ConflictNamespaceAllowList = map[string]string{"example.com": "team-a, team-b"}
+	resolver, err = buildConflictResolver(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, plan.NamespaceAllowList{
+		Namespaces: map[string][]string{"example.com": {"team-a", "team-b"}},
+		Resolver:   plan.RefuseConflicts{},
+	}, resolver)
+
+	cfg.ConflictResolution = "unknown"
+	_, err = buildConflictResolver(cfg)
+	assert.EqualError(t, err, "unknown conflict resolution: unknown")
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// runOwnershipHandover transfers the ownership of the records of --from-owner in the registry of the provider
+// to --to-owner, and writes the ownership changes to w.
+func runOwnershipHandover(ctx context.Context, cfg *externaldns.Config, w io.Writer) error {
+	// the registry reads and filters the records of the previous owner
+	handoverCfg := *cfg
+	handoverCfg.TXTOwnerID = cfg.FromOwner
+
+	domainFilter := createDomainFilter(&handoverCfg)
+	p, err := buildProvider(ctx, &handoverCfg, domainFilter)
+	if err != nil {
+		return err
+	}
+	r, err := selectRegistry(&handoverCfg, p)
+	if err != nil {
+		return err
+	}
+	return transferOwnership(ctx, r, domainFilter, cfg.ToOwner, cfg.DryRun, w)
+}
+
+// transferOwnership transfers the ownership of the records of the registry's owner ID in the domain filter to
+// the owner ID to, and writes the ownership changes to w. In dry-run mode, only the changes are written.
+func transferOwnership(ctx context.Context, r registry.Registry, filter endpoint.DomainFilterInterface, to string, dryRun bool, w io.Writer) error {
+	transferer, ok := r.(registry.OwnershipTransferer)
+	if !ok {
+		return fmt.Errorf("registry %T does not support transferring ownership", r)
+	}
+
+	records, err := r.Records(ctx)
+	if err != nil {
+		return err
+	}
+
+	from := r.OwnerID()
+	var owned []*endpoint.Endpoint
+	for _, ep := range records {
+		if ep.Labels[endpoint.OwnerLabelKey] == from && filter.Match(ep.DNSName) {
+			owned = append(owned, ep)
+		}
+	}
+	sort.SliceStable(owned, func(i, j int) bool {
+		if owned[i].DNSName != owned[j].DNSName {
+			return owned[i].DNSName < owned[j].DNSName
+		}
+		if owned[i].RecordType != owned[j].RecordType {
+			return owned[i].RecordType < owned[j].RecordType
+		}
+		return owned[i].SetIdentifier < owned[j].SetIdentifier
+	})
+
+	for _, ep := range owned {
+		name := ep.DNSName
+		if ep.SetIdentifier != "" {
+			name += " (" + ep.SetIdentifier + ")"
+		}
+		fmt.Fprintf(w, "~ %s %s\n-   owner: %s\n+   owner: %s\n", ep.RecordType, name, from, to)
+	}
+
+	if dryRun {
+		fmt.Fprintf(w, "%d records would be transferred from owner %q to %q\n", len(owned), from, to)
+		return nil
+	}
+	if len(owned) > 0 {
+		if err := transferer.TransferOwnership(ctx, owned, to); err != nil {
+			return fmt.Errorf("transferring ownership from %q to %q: %w", from, to, err)
+		}
+	}
+	fmt.Fprintf(w, "%d records transferred from owner %q to %q\n", len(owned), from, to)
+	return nil
+}
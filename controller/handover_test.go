@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func newHandoverProvider(t *testing.T) *inmemory.InMemoryProvider {
+	t.Helper()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.com"))
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("a-app.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=old\""),
+			endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeCNAME, "app.example.com"),
+			endpoint.NewEndpoint("cname-web.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=old\""),
+			endpoint.NewEndpoint("db.internal.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+			endpoint.NewEndpoint("a-db.internal.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=old\""),
+			endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+			endpoint.NewEndpoint("a-other.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=other\""),
+		},
+	}))
+	return p
+}
+
+func ownersOf(t *testing.T, p *inmemory.InMemoryProvider, owner string) map[string]string {
+	t.Helper()
+	r, err := registry.NewTXTRegistry(p, "", "", owner, 0, "", nil, nil, false, nil, false)
+	require.NoError(t, err)
+	records, err := r.Records(context.Background())
+	require.NoError(t, err)
+	owners := map[string]string{}
+	for _, ep := range records {
+		owners[ep.DNSName] = ep.Labels[endpoint.OwnerLabelKey]
+	}
+	return owners
+}
+
+func TestTransferOwnership(t *testing.T) {
+	p := newHandoverProvider(t)
+	r, err := registry.NewTXTRegistry(p, "", "", "old", time.Hour, "", nil, nil, false, nil, false)
+	require.NoError(t, err)
+	filter := endpoint.NewDomainFilterWithExclusions([]string{"example.com"}, []string{"internal.example.com"})
+
+	var out bytes.Buffer
+	require.NoError(t, transferOwnership(context.Background(), r, filter, "new", true, &out))
+	assert.Equal(t, `~ A app.example.com
+-   owner: old
++   owner: new
+~ CNAME web.example.com
+-   owner: old
++   owner: new
+2 records would be transferred from owner "old" to "new"
+`, out.String())
+	assert.Equal(t, "old", ownersOf(t, p, "old")["app.example.com"])
+
+	out.Reset()
+	require.NoError(t, transferOwnership(context.Background(), r, filter, "new", false, &out))
+	assert.Contains(t, out.String(), `2 records transferred from owner "old" to "new"`)
+	assert.Equal(t, map[string]string{
+		"app.example.com":         "new",
+		"web.example.com":         "new",
+		"db.internal.example.com": "old",
+		"other.example.com":       "other",
+	}, ownersOf(t, p, "new"))
+
+	// the records themselves are unchanged
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	targets := map[string]endpoint.Targets{}
+	for _, ep := range records {
+		if ep.RecordType != endpoint.RecordTypeTXT {
+			targets[ep.DNSName] = ep.Targets
+		}
+	}
+	assert.Equal(t, map[string]endpoint.Targets{
+		"app.example.com":         {"1.2.3.4"},
+		"web.example.com":         {"app.example.com"},
+		"db.internal.example.com": {"10.0.0.1"},
+		"other.example.com":       {"1.2.3.5"},
+	}, targets)
+}
+
+func TestTransferOwnershipUnsupportedRegistry(t *testing.T) {
+	r, err := registry.NewNoopRegistry(newHandoverProvider(t))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	err = transferOwnership(context.Background(), r, endpoint.NewDomainFilter(nil), "new", false, &out)
+	assert.ErrorContains(t, err, "does not support transferring ownership")
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	componentSource     = "source"
+	componentRegistry   = "registry"
+	componentProvider   = "provider"
+	componentController = "controller"
+
+	healthStatusOK        = "ok"
+	healthStatusStandby   = "standby"
+	healthStatusNotReady  = "not-ready"
+	healthStatusUnhealthy = "unhealthy"
+)
+
+// healthResponse is the JSON document served by the /healthz and /readyz endpoints.
+type healthResponse struct {
+	Status                string     `json:"status"`
+	Component             string     `json:"component,omitempty"`
+	Message               string     `json:"message,omitempty"`
+	LastSync              *time.Time `json:"lastSync,omitempty"`
+	ConsecutiveSoftErrors int        `json:"consecutiveSoftErrors"`
+}
+
+// healthChecker tracks the outcome of reconciliation loops so that liveness and
+// readiness probes reflect whether records actually reach the DNS provider.
+// All methods are safe to call on a nil receiver, in which case they are no-ops.
+type healthChecker struct {
+	mu sync.RWMutex
+	// interval is the configured interval between two synchronizations
+	interval time.Duration
+	// maxSyncIntervals is the number of intervals without a successful sync after which
+	// the controller is reported unhealthy, 0 disables the check
+	maxSyncIntervals int
+	// maxSoftErrors is the number of consecutive soft errors after which the controller
+	// is reported unhealthy, 0 disables the check
+	maxSoftErrors int
+
+	running    bool
+	standby    bool
+	startedAt  time.Time
+	lastSync   time.Time
+	softErrors int
+	component  string
+	lastError  string
+}
+
+func newHealthChecker(interval time.Duration, maxSyncIntervals, maxSoftErrors int) *healthChecker {
+	return &healthChecker{
+		interval:         interval,
+		maxSyncIntervals: maxSyncIntervals,
+		maxSoftErrors:    maxSoftErrors,
+	}
+}
+
+// start marks the beginning of the reconciliation loop. The staleness check is measured
+// from this point until the first successful synchronization.
+func (h *healthChecker) start(now time.Time) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.running = true
+	h.standby = false
+	h.startedAt = now
+}
+
+// markStandby reports a replica that is waiting for the leader election lease as healthy
+// and ready, since it is not expected to synchronize anything.
+func (h *healthChecker) markStandby() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.standby = true
+}
+
+// recordSync records a successful synchronization and clears previous failures.
+func (h *healthChecker) recordSync(now time.Time) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastSync = now
+	h.softErrors = 0
+	h.component = ""
+	h.lastError = ""
+}
+
+// recordFailure records a failed synchronization caused by the given component.
+func (h *healthChecker) recordFailure(component string, err error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.component = component
+	h.lastError = err.Error()
+	if errors.Is(err, provider.SoftError) {
+		h.softErrors++
+	}
+}
+
+func (h *healthChecker) response(status string) healthResponse {
+	resp := healthResponse{
+		Status:                status,
+		Component:             h.component,
+		Message:               h.lastError,
+		ConsecutiveSoftErrors: h.softErrors,
+	}
+	if !h.lastSync.IsZero() {
+		lastSync := h.lastSync
+		resp.LastSync = &lastSync
+	}
+	return resp
+}
+
+// liveness reports whether the reconciliation loop makes progress.
+func (h *healthChecker) liveness(now time.Time) (healthResponse, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.standby {
+		return h.response(healthStatusStandby), true
+	}
+	if !h.running {
+		return h.response(healthStatusOK), true
+	}
+
+	if h.maxSoftErrors > 0 && h.softErrors > h.maxSoftErrors {
+		return h.response(healthStatusUnhealthy), false
+	}
+
+	if h.maxSyncIntervals > 0 && h.interval > 0 {
+		since := h.lastSync
+		if since.IsZero() {
+			since = h.startedAt
+		}
+		if now.Sub(since) > time.Duration(h.maxSyncIntervals)*h.interval {
+			resp := h.response(healthStatusUnhealthy)
+			if resp.Component == "" {
+				resp.Component = componentController
+				resp.Message = "no successful synchronization within the allowed number of intervals"
+			}
+			return resp, false
+		}
+	}
+
+	return h.response(healthStatusOK), true
+}
+
+// readiness reports whether at least one synchronization has succeeded.
+func (h *healthChecker) readiness() (healthResponse, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.standby {
+		return h.response(healthStatusStandby), true
+	}
+	if h.lastSync.IsZero() {
+		resp := h.response(healthStatusNotReady)
+		if resp.Component == "" {
+			resp.Component = componentController
+			resp.Message = "waiting for the first successful synchronization"
+		}
+		return resp, false
+	}
+	return h.response(healthStatusOK), true
+}
+
+func (h *healthChecker) livenessHandler(w http.ResponseWriter, _ *http.Request) {
+	resp, ok := h.liveness(time.Now())
+	writeHealthResponse(w, resp, ok)
+}
+
+func (h *healthChecker) readinessHandler(w http.ResponseWriter, _ *http.Request) {
+	resp, ok := h.readiness()
+	writeHealthResponse(w, resp, ok)
+}
+
+func writeHealthResponse(w http.ResponseWriter, resp healthResponse, ok bool) {
+	w.Header().Set("Content-Type", "application/json")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Debugf("failed to write health response: %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func decodeHealthResponse(t *testing.T, rec *httptest.ResponseRecorder) healthResponse {
+	t.Helper()
+	var resp healthResponse
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return resp
+}
+
+func TestHealthCheckerReadiness(t *testing.T) {
+	h := newHealthChecker(time.Minute, 0, 0)
+
+	rec := httptest.NewRecorder()
+	h.readinessHandler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	resp := decodeHealthResponse(t, rec)
+	assert.Equal(t, healthStatusNotReady, resp.Status)
+	assert.Equal(t, componentController, resp.Component)
+
+	h.recordFailure(componentSource, errors.New("informer sync failed"))
+	rec = httptest.NewRecorder()
+	h.readinessHandler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	resp = decodeHealthResponse(t, rec)
+	assert.Equal(t, componentSource, resp.Component)
+	assert.Equal(t, "informer sync failed", resp.Message)
+
+	h.recordSync(time.Now())
+	rec = httptest.NewRecorder()
+	h.readinessHandler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	resp = decodeHealthResponse(t, rec)
+	assert.Equal(t, healthStatusOK, resp.Status)
+	assert.Empty(t, resp.Component)
+	assert.NotNil(t, resp.LastSync)
+}
+
+func TestHealthCheckerLiveness(t *testing.T) {
+	now := time.Now()
+
+	for _, tt := range []struct {
+		name          string
+		checker       func() *healthChecker
+		wantOK        bool
+		wantComponent string
+	}{
+		{
+			name:    "loop not started",
+			checker: func() *healthChecker { return newHealthChecker(time.Minute, 3, 3) },
+			wantOK:  true,
+		},
+		{
+			name: "recent sync",
+			checker: func() *healthChecker {
+				h := newHealthChecker(time.Minute, 3, 3)
+				h.start(now.Add(-time.Hour))
+				h.recordSync(now.Add(-time.Minute))
+				return h
+			},
+			wantOK: true,
+		},
+		{
+			name: "no sync since start within allowed intervals",
+			checker: func() *healthChecker {
+				h := newHealthChecker(time.Minute, 3, 3)
+				h.start(now.Add(-2 * time.Minute))
+				return h
+			},
+			wantOK: true,
+		},
+		{
+			name: "no sync since start for too long",
+			checker: func() *healthChecker {
+				h := newHealthChecker(time.Minute, 3, 3)
+				h.start(now.Add(-4 * time.Minute))
+				return h
+			},
+			wantOK:        false,
+			wantComponent: componentController,
+		},
+		{
+			name: "stale sync reports failing provider",
+			checker: func() *healthChecker {
+				h := newHealthChecker(time.Minute, 3, 0)
+				h.start(now.Add(-time.Hour))
+				h.recordSync(now.Add(-10 * time.Minute))
+				h.recordFailure(componentProvider, errors.New("throttled"))
+				return h
+			},
+			wantOK:        false,
+			wantComponent: componentProvider,
+		},
+		{
+			name: "stale sync ignored when check disabled",
+			checker: func() *healthChecker {
+				h := newHealthChecker(time.Minute, 0, 0)
+				h.start(now.Add(-time.Hour))
+				h.recordSync(now.Add(-30 * time.Minute))
+				return h
+			},
+			wantOK: true,
+		},
+		{
+			name: "too many soft errors",
+			checker: func() *healthChecker {
+				h := newHealthChecker(time.Minute, 0, 2)
+				h.start(now)
+				for i := 0; i < 3; i++ {
+					h.recordFailure(componentRegistry, fmt.Errorf("records: %w", provider.SoftError))
+				}
+				return h
+			},
+			wantOK:        false,
+			wantComponent: componentRegistry,
+		},
+		{
+			name: "hard errors are not counted as soft errors",
+			checker: func() *healthChecker {
+				h := newHealthChecker(time.Minute, 0, 2)
+				h.start(now)
+				for i := 0; i < 3; i++ {
+					h.recordFailure(componentRegistry, errors.New("boom"))
+				}
+				return h
+			},
+			wantOK: true,
+		},
+		{
+			name: "standby replica",
+			checker: func() *healthChecker {
+				h := newHealthChecker(time.Minute, 1, 1)
+				h.markStandby()
+				return h
+			},
+			wantOK: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, ok := tt.checker().liveness(now)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantComponent != "" {
+				assert.Equal(t, tt.wantComponent, resp.Component)
+			}
+		})
+	}
+}
+
+func TestHealthCheckerNilReceiver(t *testing.T) {
+	var h *healthChecker
+	assert.NotPanics(t, func() {
+		h.start(time.Now())
+		h.markStandby()
+		h.recordFailure(componentSource, errors.New("failure"))
+		h.recordSync(time.Now())
+	})
+}
+
+func TestRunOnceRecordsHealth(t *testing.T) {
+	t.Run("source failure", func(t *testing.T) {
+		source := new(testutils.MockSource)
+		source.On("Endpoints").Return([]*endpoint.Endpoint(nil), errors.New("source failed"))
+
+		r, err := registry.NewNoopRegistry(newMockProvider(nil, nil))
+		require.NoError(t, err)
+
+		health := newHealthChecker(time.Minute, 0, 0)
+		ctrl := &Controller{
+			Source:   source,
+			Registry: r,
+			Policy:   &plan.SyncPolicy{},
+			health:   health,
+		}
+
+		require.Error(t, ctrl.RunOnce(context.Background()))
+		resp, ok := health.readiness()
+		assert.False(t, ok)
+		assert.Equal(t, componentSource, resp.Component)
+	})
+
+	t.Run("successful sync", func(t *testing.T) {
+		source := new(testutils.MockSource)
+		source.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+
+		r, err := registry.NewNoopRegistry(newMockProvider(nil, nil))
+		require.NoError(t, err)
+
+		health := newHealthChecker(time.Minute, 0, 0)
+		ctrl := &Controller{
+			Source:   source,
+			Registry: r,
+			Policy:   &plan.SyncPolicy{},
+			health:   health,
+		}
+
+		require.NoError(t, ctrl.RunOnce(context.Background()))
+		_, ok := health.readiness()
+		assert.True(t, ok)
+	})
+}
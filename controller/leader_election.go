@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+// leaderElectionIdentity returns a unique identity for this replica. The hostname is
+// the pod name when running in Kubernetes, the suffix keeps restarted pods apart.
+func leaderElectionIdentity() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine leader election identity: %w", err)
+	}
+	return hostname + "_" + uuid.NewString(), nil
+}
+
+// newLeaderElector creates a Lease based leader elector which calls run while this replica
+// holds the lease. The lease is released when ctx is canceled so that another replica can
+// take over without waiting for the lease to expire.
+func newLeaderElector(cfg *externaldns.Config, client kubernetes.Interface, identity string, run func(ctx context.Context), onLost func()) (*leaderelection.LeaderElector, error) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      cfg.LeaderElectionLeaseName,
+			Namespace: cfg.LeaderElectionNamespace,
+		},
+		Client: client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	return leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		Name:            cfg.LeaderElectionLeaseName,
+		LeaseDuration:   cfg.LeaderElectionLeaseDuration,
+		RenewDeadline:   cfg.LeaderElectionRenewDeadline,
+		RetryPeriod:     cfg.LeaderElectionRetryPeriod,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				log.Infof("Acquired leader election lease %s/%s as %s", cfg.LeaderElectionNamespace, cfg.LeaderElectionLeaseName, identity)
+				run(ctx)
+			},
+			OnStoppedLeading: onLost,
+			OnNewLeader: func(current string) {
+				if current != identity {
+					log.Infof("Leader election lease %s/%s is held by %s", cfg.LeaderElectionNamespace, cfg.LeaderElectionLeaseName, current)
+				}
+			},
+		},
+	})
+}
+
+// runWithLeaderElection runs the controller loop only while this replica is the leader.
+// Followers keep their sources and informers running so they can reconcile as soon as
+// they acquire the lease. Losing the lease while ctx is still active terminates the
+// process, which guarantees that two replicas never reconcile at the same time.
+func runWithLeaderElection(ctx context.Context, cfg *externaldns.Config, client kubernetes.Interface, ctrl *Controller) error {
+	identity, err := leaderElectionIdentity()
+	if err != nil {
+		return err
+	}
+
+	elector, err := newLeaderElector(cfg, client, identity,
+		func(ctx context.Context) {
+			ctrl.ScheduleRunOnce(time.Now())
+			ctrl.Run(ctx)
+		},
+		func() {
+			if ctx.Err() != nil {
+				log.Info("Released leader election lease")
+				return
+			}
+			log.Fatalf("Lost leader election lease %s/%s", cfg.LeaderElectionNamespace, cfg.LeaderElectionLeaseName)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set up leader election: %w", err)
+	}
+
+	ctrl.health.markStandby()
+	log.Infof("Waiting to acquire leader election lease %s/%s as %s", cfg.LeaderElectionNamespace, cfg.LeaderElectionLeaseName, identity)
+	elector.Run(ctx)
+	return nil
+}
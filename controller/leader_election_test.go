@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+func testLeaderElectionConfig() *externaldns.Config {
+	return &externaldns.Config{
+		EnableLeaderElection:        true,
+		LeaderElectionLeaseName:     "external-dns",
+		LeaderElectionNamespace:     "default",
+		LeaderElectionLeaseDuration: 3 * time.Second,
+		LeaderElectionRenewDeadline: 2 * time.Second,
+		LeaderElectionRetryPeriod:   100 * time.Millisecond,
+	}
+}
+
+func TestLeaderElectionIdentity(t *testing.T) {
+	first, err := leaderElectionIdentity()
+	require.NoError(t, err)
+	second, err := leaderElectionIdentity()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+	assert.Contains(t, first, "_")
+}
+
+func TestLeaderElectorRunsOnlyWhileLeading(t *testing.T) {
+	cfg := testLeaderElectionConfig()
+	client := fake.NewClientset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	elector, err := newLeaderElector(cfg, client, "replica-1",
+		func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+		},
+		func() { close(stopped) },
+	)
+	require.NoError(t, err)
+
+	go elector.Run(ctx)
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("leader was not elected in time")
+	}
+
+	lease, err := client.CoordinationV1().Leases("default").Get(ctx, "external-dns", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, lease.Spec.HolderIdentity)
+	assert.Equal(t, "replica-1", *lease.Spec.HolderIdentity)
+
+	cancel()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("leader did not stop in time")
+	}
+}
+
+func TestLeaderElectorFollowerWaits(t *testing.T) {
+	cfg := testLeaderElectionConfig()
+	client := fake.NewClientset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leading := make(chan struct{})
+	leader, err := newLeaderElector(cfg, client, "replica-1",
+		func(ctx context.Context) {
+			close(leading)
+			<-ctx.Done()
+		},
+		func() {},
+	)
+	require.NoError(t, err)
+	go leader.Run(ctx)
+	<-leading
+
+	followerStarted := make(chan struct{})
+	follower, err := newLeaderElector(cfg, client, "replica-2",
+		func(ctx context.Context) { close(followerStarted) },
+		func() {},
+	)
+	require.NoError(t, err)
+
+	followerCtx, followerCancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer followerCancel()
+	follower.Run(followerCtx)
+
+	select {
+	case <-followerStarted:
+		t.Fatal("follower must not run while another replica holds the lease")
+	default:
+	}
+	assert.True(t, strings.HasPrefix(follower.GetLeader(), "replica-1"))
+}
+
+func TestNewLeaderElectorInvalidTimings(t *testing.T) {
+	cfg := testLeaderElectionConfig()
+	cfg.LeaderElectionRenewDeadline = cfg.LeaderElectionLeaseDuration
+
+	_, err := newLeaderElector(cfg, fake.NewClientset(), "replica-1", func(context.Context) {}, func() {})
+	assert.Error(t, err)
+}
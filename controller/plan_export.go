@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// PlanExporter writes the changes calculated by every synchronization as JSON or YAML,
+// e.g. to review them before they are applied or to post them on a pull request.
+type PlanExporter struct {
+	// Format is "json" or "yaml".
+	Format string
+	// Path is the file the changes are written to, which is replaced by every synchronization.
+	// The changes are written to Writer if empty.
+	Path   string
+	Writer io.Writer
+}
+
+// exportedPlan is the document written by the PlanExporter.
+type exportedPlan struct {
+	Summary exportedSummary  `json:"summary"`
+	Changes []exportedChange `json:"changes"`
+}
+
+type exportedSummary struct {
+	Create int `json:"create"`
+	Update int `json:"update"`
+	Delete int `json:"delete"`
+}
+
+// exportedChange is a change of the plan of a backend.
+type exportedChange struct {
+	Backend string `json:"backend"`
+	*plan.ExportedChange
+}
+
+// newExportedPlan returns the document of the given changes.
+func newExportedPlan(changes []exportedChange) *exportedPlan {
+	exported := &exportedPlan{Changes: append([]exportedChange{}, changes...)}
+	for _, c := range changes {
+		switch c.Action {
+		case plan.ActionCreate:
+			exported.Summary.Create++
+		case plan.ActionUpdate:
+			exported.Summary.Update++
+		case plan.ActionDelete:
+			exported.Summary.Delete++
+		}
+	}
+	return exported
+}
+
+// Export writes the changes to the file of the exporter, or to its writer.
+func (e *PlanExporter) Export(changes []exportedChange) error {
+	data, err := json.MarshalIndent(newExportedPlan(changes), "", "  ")
+	if err != nil {
+		return err
+	}
+	if e.Format == "yaml" {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return err
+		}
+	} else {
+		data = append(data, '\n')
+	}
+
+	if e.Path == "" {
+		_, err := e.Writer.Write(data)
+		return err
+	}
+	return writeFileAtomic(e.Path, data)
+}
+
+// writeFileAtomic replaces the file by a new file with the data, so that readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestPlanExporter(t *testing.T) {
+	changes := []exportedChange{
+		{Backend: "default", ExportedChange: &plan.ExportedChange{
+			Action:     plan.ActionCreate,
+			DNSName:    "app.example.com",
+			RecordType: endpoint.RecordTypeA,
+			Targets:    endpoint.Targets{"1.2.3.4"},
+			Reason:     "record does not exist",
+			Owner:      "owner",
+			Resource:   "ingress/default/app",
+		}},
+		{Backend: "default", ExportedChange: &plan.ExportedChange{
+			Action:          plan.ActionDelete,
+			DNSName:         "old.example.com",
+			RecordType:      endpoint.RecordTypeA,
+			PreviousTargets: endpoint.Targets{"1.2.3.5"},
+			Reason:          "record is no longer desired by any resource",
+			Owner:           "owner",
+		}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, (&PlanExporter{Format: "json", Writer: &out}).Export(changes))
+	assert.JSONEq(t, `{
+		"summary": {"create": 1, "update": 0, "delete": 1},
+		"changes": [
+			{"backend": "default", "action": "create", "dnsName": "app.example.com", "recordType": "A", "targets": ["1.2.3.4"],
+			 "reason": "record does not exist", "owner": "owner", "resource": "ingress/default/app"},
+			{"backend": "default", "action": "delete", "dnsName": "old.example.com", "recordType": "A", "previousTargets": ["1.2.3.5"],
+			 "reason": "record is no longer desired by any resource", "owner": "owner"}
+		]
+	}`, out.String())
+
+	// the file is replaced by every export
+	path := filepath.Join(t.TempDir(), "plan.yaml")
+	exporter := &PlanExporter{Format: "yaml", Path: path}
+	require.NoError(t, exporter.Export(changes))
+	require.NoError(t, exporter.Export(changes[:1]))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `changes:
+- action: create
+  backend: default
+  dnsName: app.example.com
+  owner: owner
+  reason: record does not exist
+  recordType: A
+  resource: ingress/default/app
+  targets:
+  - 1.2.3.4
+summary:
+  create: 1
+  delete: 0
+  update: 0
+`, string(data))
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	out.Reset()
+	require.NoError(t, (&PlanExporter{Format: "json", Writer: &out}).Export(nil))
+	assert.JSONEq(t, `{"summary": {"create": 0, "update": 0, "delete": 0}, "changes": []}`, out.String())
+}
+
+func TestRunOncePlanExport(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.ResourceLabelKey, "ingress/default/app"),
+	}, nil)
+	p := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+		},
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		PlanExporter:       &PlanExporter{Format: "json", Writer: &out},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	assert.JSONEq(t, `{
+		"summary": {"create": 1, "update": 0, "delete": 1},
+		"changes": [
+			{"backend": "default", "action": "create", "dnsName": "app.example.com", "recordType": "A", "targets": ["1.2.3.4"],
+			 "reason": "record does not exist", "resource": "ingress/default/app"},
+			{"backend": "default", "action": "delete", "dnsName": "old.example.com", "recordType": "A", "previousTargets": ["1.2.3.5"],
+			 "reason": "record is no longer desired by any resource"}
+		]
+	}`, out.String())
+
+	// a failed export fails the synchronization
+	ctrl.PlanExporter = &PlanExporter{Format: "json", Path: filepath.Join(t.TempDir(), "missing", "plan.json")}
+	assert.ErrorContains(t, ctrl.RunOnce(context.Background()), "exporting plan")
+}
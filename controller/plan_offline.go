@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source/manifest"
+)
+
+// snapshotProvider is a provider serving the records of a snapshot, which ignores all changes.
+type snapshotProvider struct {
+	provider.BaseProvider
+	records []*endpoint.Endpoint
+}
+
+// Records returns copies of the records of the snapshot, since the registries modify them.
+func (p *snapshotProvider) Records(context.Context) ([]*endpoint.Endpoint, error) {
+	records := make([]*endpoint.Endpoint, 0, len(p.records))
+	for _, ep := range p.records {
+		records = append(records, ep.DeepCopy())
+	}
+	return records, nil
+}
+
+// ApplyChanges ignores the changes.
+func (p *snapshotProvider) ApplyChanges(context.Context, *plan.Changes) error {
+	return nil
+}
+
+// runOfflinePlan calculates the changes from the objects of the manifests of --plan-manifest and the records
+// of --plan-records, without a cluster or DNS provider, and writes them to --plan-export-file or w.
+func runOfflinePlan(ctx context.Context, cfg *externaldns.Config, w io.Writer) error {
+	objects, err := manifest.Read(cfg.PlanManifests)
+	if err != nil {
+		return err
+	}
+	generator, err := manifest.NewClientGenerator(objects)
+	if err != nil {
+		return err
+	}
+	src, err := newSource(ctx, cfg, generator, nil)
+	if err != nil {
+		return err
+	}
+	records, err := readRecords(cfg.PlanRecords)
+	if err != nil {
+		return err
+	}
+
+	ctrl, err := buildController(cfg, src, &snapshotProvider{records: records}, createDomainFilter(cfg))
+	if err != nil {
+		return err
+	}
+	ctrl.PlanExporter = &PlanExporter{Format: cfg.PlanExportFormat, Path: cfg.PlanExportFile, Writer: w}
+	return ctrl.RunOnce(ctx)
+}
+
+// readRecords reads the list of records of a JSON or YAML file.
+func readRecords(file string) ([]*endpoint.Endpoint, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []*endpoint.Endpoint
+	if err := yaml.NewYAMLOrJSONDecoder(f, 4096).Decode(&records); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("reading records %s: %w", file, err)
+	}
+	return records, nil
+}
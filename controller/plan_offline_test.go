@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const offlineManifests = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+  namespace: apps
+  annotations:
+    external-dns.alpha.kubernetes.io/hostname: app.example.com
+spec:
+  type: LoadBalancer
+status:
+  loadBalancer:
+    ingress:
+    - ip: 1.2.3.5
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+  annotations:
+    external-dns.alpha.kubernetes.io/target: 1.2.3.6
+spec:
+  rules:
+  - host: web.example.com
+`
+
+const offlineRecords = `[
+  {"dnsName": "app.example.com", "recordType": "A", "targets": ["1.2.3.4"]},
+  {"dnsName": "a-app.example.com", "recordType": "TXT", "targets": ["\"heritage=external-dns,external-dns/owner=owner,external-dns/resource=service/apps/app\""]},
+  {"dnsName": "old.example.com", "recordType": "A", "targets": ["1.2.3.7"]},
+  {"dnsName": "a-old.example.com", "recordType": "TXT", "targets": ["\"heritage=external-dns,external-dns/owner=owner,external-dns/resource=ingress/default/old\""]},
+  {"dnsName": "other.example.com", "recordType": "A", "targets": ["1.2.3.8"]},
+  {"dnsName": "a-other.example.com", "recordType": "TXT", "targets": ["\"heritage=external-dns,external-dns/owner=other\""]}
+]`
+
+func TestRunOfflinePlan(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifests.yaml"), []byte(offlineManifests), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "records.json"), []byte(offlineRecords), 0o644))
+
+	cfg := externaldns.NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{
+		"--source=service",
+		"--source=ingress",
+		"--provider=inmemory",
+		"--txt-owner-id=owner",
+		"--txt-new-format-only",
+		"--plan-offline",
+		"--plan-manifest=" + filepath.Join(dir, "manifests.yaml"),
+		"--plan-records=" + filepath.Join(dir, "records.json"),
+	}))
+
+	var out bytes.Buffer
+	require.NoError(t, runOfflinePlan(context.Background(), cfg, &out))
+	assert.JSONEq(t, `{
+		"summary": {"create": 1, "update": 1, "delete": 1},
+		"changes": [
+			{"backend": "default", "action": "update", "dnsName": "app.example.com", "recordType": "A",
+			 "targets": ["1.2.3.5"], "previousTargets": ["1.2.3.4"], "reason": "targets changed", "owner": "owner", "resource": "service/apps/app"},
+			{"backend": "default", "action": "delete", "dnsName": "old.example.com", "recordType": "A",
+			 "previousTargets": ["1.2.3.7"], "reason": "record is no longer desired by any resource", "owner": "owner", "resource": "ingress/default/old"},
+			{"backend": "default", "action": "create", "dnsName": "web.example.com", "recordType": "A",
+			 "targets": ["1.2.3.6"], "reason": "record does not exist", "owner": "owner", "resource": "ingress/default/web"}
+		]
+	}`, out.String())
+
+	// the plan is written to the export file instead
+	cfg.PlanExportFile = filepath.Join(dir, "plan.yaml")
+	cfg.PlanExportFormat = "yaml"
+	out.Reset()
+	require.NoError(t, runOfflinePlan(context.Background(), cfg, &out))
+	assert.Empty(t, out.String())
+	data, err := os.ReadFile(cfg.PlanExportFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "dnsName: web.example.com")
+
+	cfg.PlanRecords = filepath.Join(dir, "missing.json")
+	assert.Error(t, runOfflinePlan(context.Background(), cfg, &out))
+}
+
+func TestReadRecords(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "records.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- dnsName: app.example.com\n  recordType: A\n  targets: [1.2.3.4]\n  recordTTL: 300\n"), 0o644))
+	records, err := readRecords(path)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "app.example.com", records[0].DNSName)
+	assert.Equal(t, endpoint.Targets{"1.2.3.4"}, records[0].Targets)
+	assert.Equal(t, endpoint.TTL(300), records[0].RecordTTL)
+
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+	records, err = readRecords(path)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	require.NoError(t, os.WriteFile(path, []byte("dnsName: app.example.com\n"), 0o644))
+	_, err = readRecords(path)
+	assert.ErrorContains(t, err, "reading records")
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// runTXTMigration migrates the TXT records of the owner ID in the TXT registry of the provider to the
+// new format and deletes its orphaned TXT records, and writes the changes to w.
+func runTXTMigration(ctx context.Context, cfg *externaldns.Config, w io.Writer) error {
+	domainFilter := createDomainFilter(cfg)
+	p, err := buildProvider(ctx, cfg, domainFilter)
+	if err != nil {
+		return err
+	}
+	r, err := selectRegistry(cfg, p)
+	if err != nil {
+		return err
+	}
+	txtRegistry, ok := r.(*registry.TXTRegistry)
+	if !ok {
+		return fmt.Errorf("registry %T does not support the TXT migration", r)
+	}
+	return migrateTXTRecords(ctx, txtRegistry, cfg.DryRun, w)
+}
+
+// migrateTXTRecords writes the changes of the TXT migration of the registry to w, then applies them.
+// In dry-run mode, only the changes are written.
+func migrateTXTRecords(ctx context.Context, r *registry.TXTRegistry, dryRun bool, w io.Writer) error {
+	migration, err := r.PlanMigration(ctx)
+	if err != nil {
+		return err
+	}
+
+	writeTXTChanges(w, "+", migration.Create, "new format")
+	writeTXTChanges(w, "-", migration.Migrated, "old format")
+	writeTXTChanges(w, "-", migration.Orphaned, "orphaned")
+	deleted := len(migration.Migrated) + len(migration.Orphaned)
+
+	if dryRun {
+		fmt.Fprintf(w, "%d TXT records would be created and %d deleted for owner %q\n", len(migration.Create), deleted, r.OwnerID())
+		return nil
+	}
+	if err := r.Migrate(ctx, migration); err != nil {
+		return fmt.Errorf("migrating the TXT records of owner %q: %w", r.OwnerID(), err)
+	}
+	fmt.Fprintf(w, "%d TXT records created and %d deleted for owner %q\n", len(migration.Create), deleted, r.OwnerID())
+	return nil
+}
+
+// writeTXTChanges writes a line per TXT record, sorted by name, prefixed by op and suffixed by the reason.
+func writeTXTChanges(w io.Writer, op string, records []*endpoint.Endpoint, reason string) {
+	sorted := append([]*endpoint.Endpoint(nil), records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].DNSName != sorted[j].DNSName {
+			return sorted[i].DNSName < sorted[j].DNSName
+		}
+		return sorted[i].SetIdentifier < sorted[j].SetIdentifier
+	})
+	for _, txt := range sorted {
+		name := txt.DNSName
+		if txt.SetIdentifier != "" {
+			name += " (" + txt.SetIdentifier + ")"
+		}
+		fmt.Fprintf(w, "%s TXT %s: %s\n", op, name, reason)
+	}
+}
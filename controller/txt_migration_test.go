@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestMigrateTXTRecords(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.com"))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\""),
+			endpoint.NewEndpoint("a-gone.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\""),
+			endpoint.NewEndpoint("a-other.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=other\""),
+		},
+	}))
+	r, err := registry.NewTXTRegistry(p, "", "", "owner", 0, "", nil, nil, false, nil, true)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, migrateTXTRecords(ctx, r, true, &out))
+	assert.Equal(t, `+ TXT a-app.example.com: new format
+- TXT app.example.com: old format
+- TXT a-gone.example.com: orphaned
+1 TXT records would be created and 2 deleted for owner "owner"
+`, out.String())
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 4)
+
+	out.Reset()
+	require.NoError(t, migrateTXTRecords(ctx, r, false, &out))
+	assert.Contains(t, out.String(), `1 TXT records created and 2 deleted for owner "owner"`)
+	records, err = p.Records(ctx)
+	require.NoError(t, err)
+	names := map[string]string{}
+	for _, ep := range records {
+		names[ep.DNSName] = ep.RecordType
+	}
+	assert.Equal(t, map[string]string{
+		"app.example.com":     endpoint.RecordTypeA,
+		"a-app.example.com":   endpoint.RecordTypeTXT,
+		"a-other.example.com": endpoint.RecordTypeTXT,
+	}, names)
+
+	out.Reset()
+	require.NoError(t, migrateTXTRecords(ctx, r, false, &out))
+	assert.Equal(t, "0 TXT records created and 0 deleted for owner \"owner\"\n", out.String())
+}
@@ -17,8 +17,11 @@ limitations under the License.
 package endpoint
 
 import (
+	"errors"
 	"fmt"
 	"net/netip"
+	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -45,9 +48,18 @@ const (
 	RecordTypeMX = "MX"
 	// RecordTypeNAPTR is a RecordType enum value
 	RecordTypeNAPTR = "NAPTR"
+	// RecordTypeCAA is a RecordType enum value
+	RecordTypeCAA = "CAA"
+	// RecordTypeHTTPS is a RecordType enum value
+	RecordTypeHTTPS = "HTTPS"
+	// RecordTypeSVCB is a RecordType enum value
+	RecordTypeSVCB = "SVCB"
 )
 
 var (
+	// caaTargetRegex matches the flags, the tag and the quoted value of a CAA record target
+	caaTargetRegex = regexp.MustCompile(`^(\d+)\s+([a-zA-Z0-9]+)\s+"(.*)"$`)
+
 	KnownRecordTypes = []string{
 		RecordTypeA,
 		RecordTypeAAAA,
@@ -57,6 +69,9 @@ var (
 		RecordTypePTR,
 		RecordTypeMX,
 		RecordTypeNAPTR,
+		RecordTypeCAA,
+		RecordTypeHTTPS,
+		RecordTypeSVCB,
 	}
 )
 
@@ -141,6 +156,40 @@ func (t Targets) Same(o Targets) bool {
 	return true
 }
 
+// SameForRecordType compares the targets of records of the given type. The targets of CAA, HTTPS and SVCB records
+// are compared by their meaning rather than their formatting, e.g. the order of the SvcParams, and only their
+// CAA tag and target name are case-insensitive. The targets of the other record types are compared with Same.
+func (t Targets) SameForRecordType(recordType string, o Targets) bool {
+	var normalize func(string) string
+	switch recordType {
+	case RecordTypeCAA:
+		normalize = normalizeCAATarget
+	case RecordTypeHTTPS, RecordTypeSVCB:
+		normalize = NormalizeSVCBTarget
+	default:
+		return t.Same(o)
+	}
+	if len(t) != len(o) {
+		return false
+	}
+	nt, no := make([]string, len(t)), make([]string, len(o))
+	for i := range t {
+		nt[i], no[i] = normalize(t[i]), normalize(o[i])
+	}
+	sort.Strings(nt)
+	sort.Strings(no)
+	return slices.Equal(nt, no)
+}
+
+// normalizeCAATarget formats a CAA record target consistently. Targets that cannot be parsed are returned unchanged.
+func normalizeCAATarget(target string) string {
+	flags, tag, value, err := ParseCAATarget(target)
+	if err != nil {
+		return target
+	}
+	return NewCAATarget(flags, tag, value)
+}
+
 // IsLess should fulfill the requirement to compare two targets and choose the 'lesser' one.
 // In the past target was a simple string so simple string comparison could be used. Now we define 'less'
 // as either being the shorter list of targets or where the first entry is less.
@@ -389,6 +438,10 @@ func (e *Endpoint) CheckEndpoint() bool {
 		return e.Targets.ValidateMXRecord()
 	case RecordTypeSRV:
 		return e.Targets.ValidateSRVRecord()
+	case RecordTypeCAA:
+		return e.Targets.ValidateCAARecord()
+	case RecordTypeHTTPS, RecordTypeSVCB:
+		return e.Targets.ValidateSVCBRecord()
 	}
 	return true
 }
@@ -432,3 +485,41 @@ func (t Targets) ValidateSRVRecord() bool {
 	}
 	return true
 }
+
+func (t Targets) ValidateCAARecord() bool {
+	for _, target := range t {
+		// CAA records must have a flags value, a property tag and a quoted property value, e.g. '0 issue "letsencrypt.org"'
+		// as per https://www.rfc-editor.org/rfc/rfc8659.txt
+		if _, _, _, err := ParseCAATarget(target); err != nil {
+			log.Debugf("Invalid CAA record target: %s. %v", target, err)
+			return false
+		}
+	}
+	return true
+}
+
+// ParseCAATarget splits a CAA record target in presentation format, e.g. '0 issue "letsencrypt.org"',
+// into its flags, property tag and unquoted property value.
+func ParseCAATarget(target string) (flags uint8, tag, value string, err error) {
+	m := caaTargetRegex.FindStringSubmatch(strings.TrimSpace(target))
+	if m == nil {
+		return 0, "", "", errors.New(`CAA records must have a flags value, a tag and a quoted value, e.g. '0 issue "letsencrypt.org"'`)
+	}
+
+	f, err := strconv.ParseUint(m[1], 10, 8)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("invalid flags value %q", m[1])
+	}
+	if len(m[2]) > 15 {
+		return 0, "", "", fmt.Errorf("tag %q must not be longer than 15 characters", m[2])
+	}
+	value = strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(m[3])
+
+	return uint8(f), strings.ToLower(m[2]), value, nil
+}
+
+// NewCAATarget returns the target of a CAA record in presentation format, e.g. '0 issue "letsencrypt.org"'.
+func NewCAATarget(flags uint8, tag, value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return fmt.Sprintf(`%d %s "%s"`, flags, strings.ToLower(tag), value)
+}
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewEndpoint(t *testing.T) {
@@ -150,6 +151,32 @@ func TestSameFailures(t *testing.T) {
 	}
 }
 
+func TestSameForRecordType(t *testing.T) {
+	tests := []struct {
+		recordType string
+		a          Targets
+		b          Targets
+		expected   bool
+	}{
+		{RecordTypeCAA, Targets{`0 issue "letsencrypt.org"`}, Targets{`0   ISSUE "letsencrypt.org"`}, true},
+		{RecordTypeCAA, Targets{`0 issue "letsencrypt.org"`}, Targets{`0 issue "LetsEncrypt.org"`}, false},
+		{RecordTypeHTTPS, Targets{`1 . alpn="h2,h3" port="443" ipv4hint="1.2.3.4,5.6.7.8"`}, Targets{"1 . ipv4hint=5.6.7.8,1.2.3.4 alpn=h2,h3 port=443"}, true},
+		{RecordTypeSVCB, Targets{"1 svc.example.com. alpn=h2", "2 backup.example.com. alpn=h2"}, Targets{"2 BACKUP.example.com alpn=h2", "1 svc.example.com alpn=h2"}, true},
+		{RecordTypeHTTPS, Targets{"1 . alpn=h2,h3"}, Targets{"1 . alpn=h3,h2"}, false},
+		{RecordTypeHTTPS, Targets{"1 . alpn=h2 port=443"}, Targets{"1 . alpn=h2 port=8443"}, false},
+		{RecordTypeHTTPS, Targets{"1 . alpn=h2"}, Targets{"1 . alpn=H2"}, false},
+		{RecordTypeHTTPS, Targets{"1 . alpn=h2"}, Targets{"example.com"}, false},
+		{RecordTypeHTTPS, Targets{"1 . alpn=h2"}, Targets{"1 . alpn=h2", "2 . alpn=h2"}, false},
+		{RecordTypeMX, Targets{"10 mx.example.com"}, Targets{"10  mx.example.com"}, false},
+		{RecordTypeMX, Targets{"10 mx.example.com"}, Targets{"10 MX.example.com"}, true},
+		{RecordTypeAAAA, Targets{"2001:db8::1"}, Targets{"2001:0db8:0:0:0:0:0:1"}, true},
+	}
+
+	for _, d := range tests {
+		assert.Equal(t, d.expected, d.a.SameForRecordType(d.recordType, d.b), "%s %v %v", d.recordType, d.a, d.b)
+	}
+}
+
 func TestIsLess(t *testing.T) {
 	testsA := []Targets{
 		{""},
@@ -808,6 +835,51 @@ func TestPDNScheckEndpoint(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			description: "Valid CAA record targets",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeCAA,
+				Targets:    Targets{`0 issue "letsencrypt.org"`, `128 iodef "mailto:security@example.com"`, `0 issuewild ";"`},
+			},
+			expected: true,
+		},
+		{
+			description: "Invalid CAA record with unquoted value",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeCAA,
+				Targets:    Targets{"0 issue letsencrypt.org"},
+			},
+			expected: false,
+		},
+		{
+			description: "Invalid CAA record with out of range flags",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeCAA,
+				Targets:    Targets{`256 issue "letsencrypt.org"`},
+			},
+			expected: false,
+		},
+		{
+			description: "Invalid CAA record with missing tag",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeCAA,
+				Targets:    Targets{`0 "letsencrypt.org"`},
+			},
+			expected: false,
+		},
+		{
+			description: "Invalid CAA record with too long tag",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeCAA,
+				Targets:    Targets{`0 issueissueissueissue "letsencrypt.org"`},
+			},
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -815,3 +887,39 @@ func TestPDNScheckEndpoint(t *testing.T) {
 		assert.Equal(t, tt.expected, actual)
 	}
 }
+
+func TestParseCAATarget(t *testing.T) {
+	for _, tt := range []struct {
+		target string
+		flags  uint8
+		tag    string
+		value  string
+		valid  bool
+	}{
+		{target: `0 issue "letsencrypt.org"`, tag: "issue", value: "letsencrypt.org", valid: true},
+		{target: `  128   ISSUEWILD   "ca.example.net; account=230123"  `, flags: 128, tag: "issuewild", value: "ca.example.net; account=230123", valid: true},
+		{target: `0 iodef "mailto:\"sec\"@example.com"`, tag: "iodef", value: `mailto:"sec"@example.com`, valid: true},
+		{target: `0 issue ""`, tag: "issue", valid: true},
+		{target: `0 issue letsencrypt.org`},
+		{target: `-1 issue "letsencrypt.org"`},
+		{target: `0 is-sue "letsencrypt.org"`},
+		{target: ""},
+	} {
+		t.Run(tt.target, func(t *testing.T) {
+			flags, tag, value, err := ParseCAATarget(tt.target)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.flags, flags)
+			assert.Equal(t, tt.tag, tag)
+			assert.Equal(t, tt.value, value)
+
+			// formatting the parsed values must round trip
+			f, tg, v, err := ParseCAATarget(NewCAATarget(flags, tag, value))
+			require.NoError(t, err)
+			assert.Equal(t, []any{flags, tag, value}, []any{f, tg, v})
+		})
+	}
+}
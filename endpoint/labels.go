@@ -36,6 +36,27 @@ const (
 	ResourceLabelKey = "resource"
 	// OwnedRecordLabelKey is the name of the label that identifies the record that is owned by the labeled TXT registry record
 	OwnedRecordLabelKey = "ownedRecord"
+	// ResourceCreatedLabelKey is the name of the label with the creation timestamp (RFC 3339) of the k8s resource,
+	// used to resolve conflicts between resources. It is removed by the plan before records are stored.
+	ResourceCreatedLabelKey = "resource-created"
+	// ResourcePriorityLabelKey is the name of the label with the conflict priority of the k8s resource,
+	// used to resolve conflicts between resources. It is removed by the plan before records are stored.
+	ResourcePriorityLabelKey = "resource-priority"
+	// MergeTargetsLabelKey is the name of the label which opts the k8s resource into merging its targets with the
+	// targets of other resources claiming the same DNS name. It is removed by the plan before records are stored.
+	MergeTargetsLabelKey = "merge-targets"
+	// AdoptLabelKey is the name of the label which opts the k8s resource into adopting unowned records of its DNS names.
+	// It is removed by the plan before records are stored.
+	AdoptLabelKey = "adopt"
+	// AdoptedLabelKey is the name of the label with which the plan marks a current record it adopted, so that the
+	// registry writes the ownership of the record instead of updating it.
+	AdoptedLabelKey = "adopted"
+	// DeletionPendingLabelKey is the name of the label with the time (RFC 3339) since which a record is no longer
+	// desired, while its deletion is delayed by a grace period. It is stored by the registry.
+	DeletionPendingLabelKey = "deletion-pending"
+	// ResourceLabelSeparator separates the resources in the ResourceLabelKey label of a record set merged from the
+	// targets of several resources
+	ResourceLabelSeparator = "+"
 
 	// AWSSDDescriptionLabel label responsible for storing raw owner/resource combination information in the Labels
 	// supposed to be inserted by AWS SD Provider, and parsed into OwnerLabelKey and ResourceLabelKey key by AWS SD Registry
@@ -0,0 +1,286 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SvcParamKeys in the order of their numeric value, as registered in
+// https://www.iana.org/assignments/dns-svcb/dns-svcb.xhtml
+const (
+	SvcParamMandatory     = "mandatory"
+	SvcParamALPN          = "alpn"
+	SvcParamNoDefaultALPN = "no-default-alpn"
+	SvcParamPort          = "port"
+	SvcParamIPv4Hint      = "ipv4hint"
+	SvcParamECH           = "ech"
+	SvcParamIPv6Hint      = "ipv6hint"
+	SvcParamDoHPath       = "dohpath"
+	SvcParamOHTTP         = "ohttp"
+)
+
+var svcParamKeys = []string{
+	SvcParamMandatory,
+	SvcParamALPN,
+	SvcParamNoDefaultALPN,
+	SvcParamPort,
+	SvcParamIPv4Hint,
+	SvcParamECH,
+	SvcParamIPv6Hint,
+	SvcParamDoHPath,
+	SvcParamOHTTP,
+}
+
+// IsServiceBindingRecordType returns true for the record types defined in RFC 9460, HTTPS and SVCB.
+func IsServiceBindingRecordType(recordType string) bool {
+	return recordType == RecordTypeHTTPS || recordType == RecordTypeSVCB
+}
+
+// svcParamKeyNumber returns the numeric value of a SvcParamKey, either a registered name or "keyNNNNN".
+func svcParamKeyNumber(key string) (int, error) {
+	for i, k := range svcParamKeys {
+		if key == k {
+			return i, nil
+		}
+	}
+	if n, ok := strings.CutPrefix(key, "key"); ok {
+		if v, err := strconv.ParseUint(n, 10, 16); err == nil {
+			return int(v), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown SvcParamKey %q", key)
+}
+
+// svcParamKeyName returns the registered name of a SvcParamKey number, or "keyNNNNN" if it has none.
+func svcParamKeyName(n int) string {
+	if n < len(svcParamKeys) {
+		return svcParamKeys[n]
+	}
+	return "key" + strconv.Itoa(n)
+}
+
+func (t Targets) ValidateSVCBRecord() bool {
+	for _, target := range t {
+		// SVCB and HTTPS records must have a priority, a target name and optional SvcParams, e.g. '1 . alpn=h2,h3'
+		// as per https://www.rfc-editor.org/rfc/rfc9460.txt
+		priority, _, params, err := ParseSVCBTarget(target)
+		if err != nil {
+			log.Debugf("Invalid SVCB record target: %s. %v", target, err)
+			return false
+		}
+		// AliasMode records should not have SvcParams, and as recipients ignore ServiceMode
+		// records beside them an AliasMode record must be the only record of the set
+		if priority == 0 && (len(params) > 0 || len(t) > 1) {
+			log.Debugf("Invalid SVCB record target: %s. AliasMode records must be the only target and have no SvcParams.", target)
+			return false
+		}
+	}
+	return true
+}
+
+// ParseSVCBTarget splits a SVCB or HTTPS record target in presentation format, e.g. '1 . alpn="h2,h3" port=443',
+// into its priority, target name and SvcParams. The returned SvcParams are keyed by their registered name,
+// or "keyNNNNN" for unregistered keys, and their values are unquoted and normalized, so that two targets
+// with the same meaning have the same parameters.
+func ParseSVCBTarget(target string) (priority uint16, targetName string, params map[string]string, err error) {
+	fields, err := splitSVCBTarget(target)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	if len(fields) < 2 {
+		return 0, "", nil, errors.New(`SVCB records must have a priority and a target name, e.g. '1 . alpn=h2'`)
+	}
+
+	p, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return 0, "", nil, fmt.Errorf("invalid priority %q", fields[0])
+	}
+	targetName = strings.ToLower(fields[1])
+	if targetName != "." {
+		targetName = strings.TrimSuffix(targetName, ".") + "."
+	}
+
+	params = map[string]string{}
+	for _, field := range fields[2:] {
+		key, value, _ := strings.Cut(field, "=")
+		n, err := svcParamKeyNumber(strings.ToLower(key))
+		if err != nil {
+			return 0, "", nil, err
+		}
+		key = svcParamKeyName(n)
+		if _, ok := params[key]; ok {
+			return 0, "", nil, fmt.Errorf("duplicate SvcParamKey %q", key)
+		}
+		if params[key], err = normalizeSvcParamValue(key, unquoteSvcParamValue(value)); err != nil {
+			return 0, "", nil, err
+		}
+	}
+
+	if mandatory, ok := params[SvcParamMandatory]; ok {
+		for _, key := range strings.Split(mandatory, ",") {
+			if _, ok := params[key]; !ok {
+				return 0, "", nil, fmt.Errorf("mandatory SvcParamKey %q is missing", key)
+			}
+		}
+	}
+
+	return uint16(p), targetName, params, nil
+}
+
+// NewSVCBTarget returns the target of a SVCB or HTTPS record in presentation format, with the SvcParams
+// in the order of their keys, e.g. '1 svc.example.com. alpn=h2,h3 port=443'.
+func NewSVCBTarget(priority uint16, targetName string, params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ni, _ := svcParamKeyNumber(keys[i])
+		nj, _ := svcParamKeyNumber(keys[j])
+		return ni < nj
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d %s", priority, targetName)
+	for _, key := range keys {
+		sb.WriteString(" " + key)
+		value := params[key]
+		if value == "" {
+			continue
+		}
+		if strings.ContainsAny(value, " \t\"\\;()") {
+			value = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+		}
+		sb.WriteString("=" + value)
+	}
+	return sb.String()
+}
+
+// NormalizeSVCBTarget formats a SVCB or HTTPS record target consistently. Targets that cannot be parsed
+// are returned unchanged.
+func NormalizeSVCBTarget(target string) string {
+	priority, targetName, params, err := ParseSVCBTarget(target)
+	if err != nil {
+		return target
+	}
+	return NewSVCBTarget(priority, targetName, params)
+}
+
+// splitSVCBTarget splits a target on whitespace, keeping quoted parameter values together.
+func splitSVCBTarget(target string) ([]string, error) {
+	var (
+		fields  []string
+		current strings.Builder
+		quoted  bool
+		escaped bool
+	)
+	for _, r := range strings.TrimSpace(target) {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && (r == ' ' || r == '\t'):
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if quoted || escaped {
+		return nil, errors.New("unterminated quoted SvcParam value")
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields, nil
+}
+
+func unquoteSvcParamValue(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		value = value[1 : len(value)-1]
+	}
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(value)
+}
+
+// normalizeSvcParamValue validates the value of a SvcParam and formats it consistently.
+func normalizeSvcParamValue(key, value string) (string, error) {
+	switch key {
+	case SvcParamNoDefaultALPN:
+		if value != "" {
+			return "", fmt.Errorf("SvcParamKey %q must not have a value", key)
+		}
+		return "", nil
+	case SvcParamMandatory:
+		keys := strings.Split(value, ",")
+		numbers := make([]int, 0, len(keys))
+		for _, k := range keys {
+			n, err := svcParamKeyNumber(strings.ToLower(k))
+			if err != nil {
+				return "", err
+			}
+			numbers = append(numbers, n)
+		}
+		sort.Ints(numbers)
+		for i, n := range numbers {
+			keys[i] = svcParamKeyName(n)
+		}
+		return strings.Join(keys, ","), nil
+	case SvcParamALPN:
+		// the order of the protocols is significant, it reflects the preference of the server
+		for _, id := range strings.Split(value, ",") {
+			if id == "" {
+				return "", fmt.Errorf("invalid empty protocol in %q", value)
+			}
+		}
+		return value, nil
+	case SvcParamPort:
+		port, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return "", fmt.Errorf("invalid port %q", value)
+		}
+		return strconv.FormatUint(port, 10), nil
+	case SvcParamIPv4Hint, SvcParamIPv6Hint:
+		hints := strings.Split(value, ",")
+		addrs := make([]netip.Addr, 0, len(hints))
+		for _, hint := range hints {
+			addr, err := netip.ParseAddr(hint)
+			if err != nil || addr.Is4() != (key == SvcParamIPv4Hint) {
+				return "", fmt.Errorf("invalid %s address %q", key, hint)
+			}
+			addrs = append(addrs, addr)
+		}
+		sort.Slice(addrs, func(i, j int) bool { return addrs[i].Less(addrs[j]) })
+		for i, addr := range addrs {
+			hints[i] = addr.String()
+		}
+		return strings.Join(hints, ","), nil
+	}
+	return value, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSVCBTarget(t *testing.T) {
+	for _, tt := range []struct {
+		target     string
+		priority   uint16
+		targetName string
+		params     map[string]string
+		normalized string
+		valid      bool
+	}{
+		{
+			target:     "0 svc.example.com",
+			targetName: "svc.example.com.",
+			params:     map[string]string{},
+			normalized: "0 svc.example.com.",
+			valid:      true,
+		},
+		{
+			target:     `1 . alpn="h3,h2" port="443" ipv6hint="2001:db8::0001" ipv4hint="5.6.7.8,1.2.3.4"`,
+			priority:   1,
+			targetName: ".",
+			params:     map[string]string{"alpn": "h3,h2", "port": "443", "ipv4hint": "1.2.3.4,5.6.7.8", "ipv6hint": "2001:db8::1"},
+			normalized: "1 . alpn=h3,h2 port=443 ipv4hint=1.2.3.4,5.6.7.8 ipv6hint=2001:db8::1",
+			valid:      true,
+		},
+		{
+			target:     `16 SVC.example.com. mandatory=port,alpn alpn=h2 no-default-alpn port=8443 key65000="a b"`,
+			priority:   16,
+			targetName: "svc.example.com.",
+			params:     map[string]string{"mandatory": "alpn,port", "alpn": "h2", "no-default-alpn": "", "port": "8443", "key65000": "a b"},
+			normalized: `16 svc.example.com. mandatory=alpn,port alpn=h2 no-default-alpn port=8443 key65000="a b"`,
+			valid:      true,
+		},
+		{
+			target:     `1 . key1=h2 key3=443`,
+			priority:   1,
+			targetName: ".",
+			params:     map[string]string{"alpn": "h2", "port": "443"},
+			normalized: "1 . alpn=h2 port=443",
+			valid:      true,
+		},
+		{target: "1"},
+		{target: "65536 . alpn=h2"},
+		{target: "1 . port=https"},
+		{target: "1 . port=443 port=8443"},
+		{target: "1 . ipv4hint=2001:db8::1"},
+		{target: "1 . ipv6hint=1.2.3.4"},
+		{target: "1 . no-default-alpn=h2"},
+		{target: "1 . mandatory=port"},
+		{target: "1 . unknown=1"},
+		{target: `1 . alpn="h2`},
+		{target: `0 issue "letsencrypt.org"`},
+	} {
+		t.Run(tt.target, func(t *testing.T) {
+			priority, targetName, params, err := ParseSVCBTarget(tt.target)
+			if !tt.valid {
+				assert.Error(t, err)
+				assert.Equal(t, tt.target, NormalizeSVCBTarget(tt.target))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.priority, priority)
+			assert.Equal(t, tt.targetName, targetName)
+			assert.Equal(t, tt.params, params)
+			assert.Equal(t, tt.normalized, NewSVCBTarget(priority, targetName, params))
+			assert.Equal(t, tt.normalized, NormalizeSVCBTarget(tt.normalized))
+		})
+	}
+}
+
+func TestValidateSVCBRecord(t *testing.T) {
+	for _, tt := range []struct {
+		targets Targets
+		valid   bool
+	}{
+		{targets: Targets{"1 . alpn=h2,h3"}, valid: true},
+		{targets: Targets{"1 svc1.example.com alpn=h2", "2 svc2.example.com alpn=h2"}, valid: true},
+		{targets: Targets{"0 svc.example.com"}, valid: true},
+		{targets: Targets{"0 svc.example.com alpn=h2"}},
+		{targets: Targets{"0 svc.example.com", "1 . alpn=h2"}},
+		{targets: Targets{"1 . port=0x1bb"}},
+	} {
+		t.Run(tt.targets.String(), func(t *testing.T) {
+			assert.Equal(t, tt.valid, tt.targets.ValidateSVCBRecord())
+			assert.Equal(t, tt.valid, NewEndpoint("example.com", RecordTypeHTTPS, tt.targets...).CheckEndpoint())
+			assert.Equal(t, tt.valid, NewEndpoint("_dns.example.com", RecordTypeSVCB, tt.targets...).CheckEndpoint())
+		})
+	}
+}
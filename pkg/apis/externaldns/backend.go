@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultBackendName is the name of the backend of the --provider flag.
+	DefaultBackendName = "default"
+
+	AccessPublic  = "public"
+	AccessPrivate = "private"
+)
+
+// Backend is an additional DNS provider configured with the --backend flag.
+// Endpoints are routed to it by its name, its domain filter and its access.
+type Backend struct {
+	Name         string
+	Provider     string
+	DomainFilter []string
+	ZoneIDFilter []string
+	// Access is "public" or "private" to route only endpoints with this access annotation, empty to route all.
+	// Endpoints without the annotation are public.
+	Access     string
+	TXTOwnerID string
+}
+
+// ParseBackend parses the value of a --backend flag, comma separated key=value pairs,
+// e.g. "name=private,provider=aws,domain-filter=internal.example.com,access=private".
+// The filter keys may be given multiple times.
+func ParseBackend(value string) (Backend, error) {
+	var b Backend
+	for _, pair := range strings.Split(value, ",") {
+		key, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || v == "" {
+			return Backend{}, fmt.Errorf("invalid backend %q: expected key=value, got %q", value, pair)
+		}
+		switch key {
+		case "name":
+			b.Name = v
+		case "provider":
+			b.Provider = v
+		case "domain-filter":
+			b.DomainFilter = append(b.DomainFilter, v)
+		case "zone-id-filter":
+			b.ZoneIDFilter = append(b.ZoneIDFilter, v)
+		case "access":
+			b.Access = v
+		case "txt-owner-id":
+			b.TXTOwnerID = v
+		default:
+			return Backend{}, fmt.Errorf("invalid backend %q: unknown key %q", value, key)
+		}
+	}
+
+	if b.Name == "" {
+		return Backend{}, fmt.Errorf("invalid backend %q: name is required", value)
+	}
+	if b.Name == DefaultBackendName {
+		return Backend{}, fmt.Errorf("invalid backend %q: name %q is reserved for the backend of --provider", value, DefaultBackendName)
+	}
+	if !slices.Contains(Providers, b.Provider) {
+		return Backend{}, fmt.Errorf("invalid backend %q: unknown provider %q", value, b.Provider)
+	}
+	if b.Access != "" && b.Access != AccessPublic && b.Access != AccessPrivate {
+		return Backend{}, fmt.Errorf("invalid backend %q: access must be %q or %q", value, AccessPublic, AccessPrivate)
+	}
+	return b, nil
+}
+
+// Config returns the configuration of the backend's provider and registry: cfg with the provider,
+// filters and owner ID of the backend. The filters and owner ID of cfg are kept if the backend has none.
+func (b Backend) Config(cfg *Config) *Config {
+	c := *cfg
+	c.Provider = b.Provider
+	if len(b.DomainFilter) > 0 {
+		c.DomainFilter = b.DomainFilter
+		c.RegexDomainFilter = defaultConfig.RegexDomainFilter
+		c.RegexDomainExclusion = defaultConfig.RegexDomainExclusion
+	}
+	if len(b.ZoneIDFilter) > 0 {
+		c.ZoneIDFilter = b.ZoneIDFilter
+	}
+	if b.TXTOwnerID != "" {
+		c.TXTOwnerID = b.TXTOwnerID
+	}
+	c.Backends = nil
+	return &c
+}
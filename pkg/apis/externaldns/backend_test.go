@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBackend(t *testing.T) {
+	for _, tt := range []struct {
+		value   string
+		want    Backend
+		wantErr string
+	}{
+		{
+			value: "name=private,provider=aws,access=private,txt-owner-id=private",
+			want:  Backend{Name: "private", Provider: "aws", Access: AccessPrivate, TXTOwnerID: "private"},
+		},
+		{
+			value: "name=public, provider=cloudflare, domain-filter=example.com, domain-filter=example.org, zone-id-filter=abc",
+			want:  Backend{Name: "public", Provider: "cloudflare", DomainFilter: []string{"example.com", "example.org"}, ZoneIDFilter: []string{"abc"}},
+		},
+		{value: "provider=aws", wantErr: "name is required"},
+		{value: "name=default,provider=aws", wantErr: "reserved"},
+		{value: "name=private,provider=bind", wantErr: `unknown provider "bind"`},
+		{value: "name=private,provider=aws,access=internal", wantErr: "access must be"},
+		{value: "name=private,provider=aws,zone=abc", wantErr: `unknown key "zone"`},
+		{value: "name=private,provider", wantErr: "expected key=value"},
+	} {
+		t.Run(tt.value, func(t *testing.T) {
+			b, err := ParseBackend(tt.value)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, b)
+		})
+	}
+}
+
+func TestBackendConfig(t *testing.T) {
+	cfg := &Config{
+		Provider:          "cloudflare",
+		DomainFilter:      []string{"example.com"},
+		RegexDomainFilter: regexp.MustCompile(`example\.com$`),
+		ZoneIDFilter:      []string{"zone"},
+		TXTOwnerID:        "default",
+		TXTPrefix:         "prefix-",
+		Backends:          []string{"name=private,provider=aws"},
+	}
+
+	c := Backend{Name: "private", Provider: "aws"}.Config(cfg)
+	assert.Equal(t, "aws", c.Provider)
+	assert.Equal(t, []string{"example.com"}, c.DomainFilter)
+	assert.Equal(t, cfg.RegexDomainFilter, c.RegexDomainFilter)
+	assert.Equal(t, []string{"zone"}, c.ZoneIDFilter)
+	assert.Equal(t, "default", c.TXTOwnerID)
+	assert.Equal(t, "prefix-", c.TXTPrefix)
+	assert.Empty(t, c.Backends)
+
+	c = Backend{Name: "private", Provider: "aws", DomainFilter: []string{"internal.example.com"}, ZoneIDFilter: []string{"private-zone"}, TXTOwnerID: "private"}.Config(cfg)
+	assert.Equal(t, []string{"internal.example.com"}, c.DomainFilter)
+	assert.Empty(t, c.RegexDomainFilter.String())
+	assert.Equal(t, []string{"private-zone"}, c.ZoneIDFilter)
+	assert.Equal(t, "private", c.TXTOwnerID)
+
+	// the configuration of the default backend is unchanged
+	assert.Equal(t, "cloudflare", cfg.Provider)
+	assert.Equal(t, "default", cfg.TXTOwnerID)
+}
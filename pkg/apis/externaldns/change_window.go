@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ChangeWindowCreateOnly applies the creations of records outside of a change window.
+	ChangeWindowCreateOnly = "create-only"
+	// ChangeWindowNone applies no changes of records outside of a change window.
+	ChangeWindowNone = "none"
+)
+
+// ChangeWindow restricts the changes of the records in its domains to the windows opening at the times
+// of a cron schedule, configured with the --change-window flag.
+type ChangeWindow struct {
+	DomainFilter []string
+	Schedule     string
+	Duration     time.Duration
+	// Outside is the changes applied outside of the windows, "create-only" or "none".
+	Outside  string
+	Timezone string
+}
+
+// ParseChangeWindow parses the value of a --change-window flag, semicolon separated key=value pairs,
+// since the schedule may contain commas, e.g. "domain-filter=example.com;schedule=0 2 * * 6;duration=2h".
+// The domain-filter key may be given multiple times.
+func ParseChangeWindow(value string) (ChangeWindow, error) {
+	w := ChangeWindow{Outside: ChangeWindowCreateOnly}
+	for _, pair := range strings.Split(value, ";") {
+		key, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		v = strings.TrimSpace(v)
+		if !ok || v == "" {
+			return ChangeWindow{}, fmt.Errorf("invalid change window %q: expected key=value, got %q", value, pair)
+		}
+		switch key {
+		case "domain-filter":
+			w.DomainFilter = append(w.DomainFilter, v)
+		case "schedule":
+			w.Schedule = v
+		case "duration":
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return ChangeWindow{}, fmt.Errorf("invalid change window %q: %w", value, err)
+			}
+			w.Duration = d
+		case "outside":
+			w.Outside = v
+		case "timezone":
+			w.Timezone = v
+		default:
+			return ChangeWindow{}, fmt.Errorf("invalid change window %q: unknown key %q", value, key)
+		}
+	}
+
+	if w.Schedule == "" {
+		return ChangeWindow{}, fmt.Errorf("invalid change window %q: schedule is required", value)
+	}
+	if w.Duration < time.Minute {
+		return ChangeWindow{}, fmt.Errorf("invalid change window %q: duration of at least 1m is required", value)
+	}
+	if w.Outside != ChangeWindowCreateOnly && w.Outside != ChangeWindowNone {
+		return ChangeWindow{}, fmt.Errorf("invalid change window %q: outside must be %q or %q", value, ChangeWindowCreateOnly, ChangeWindowNone)
+	}
+	if w.Timezone != "" {
+		if _, err := time.LoadLocation(w.Timezone); err != nil {
+			return ChangeWindow{}, fmt.Errorf("invalid change window %q: %w", value, err)
+		}
+	}
+	return w, nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChangeWindow(t *testing.T) {
+	for _, tt := range []struct {
+		value   string
+		want    ChangeWindow
+		wantErr string
+	}{
+		{
+			value: "domain-filter=example.com;schedule=0 2 * * 6;duration=2h",
+			want:  ChangeWindow{DomainFilter: []string{"example.com"}, Schedule: "0 2 * * 6", Duration: 2 * time.Hour, Outside: ChangeWindowCreateOnly},
+		},
+		{
+			value: "domain-filter=example.com; domain-filter=example.org; schedule=0 22 * * 1,3; duration=30m; outside=none; timezone=UTC",
+			want: ChangeWindow{
+				DomainFilter: []string{"example.com", "example.org"},
+				Schedule:     "0 22 * * 1,3",
+				Duration:     30 * time.Minute,
+				Outside:      ChangeWindowNone,
+				Timezone:     "UTC",
+			},
+		},
+		{value: "duration=2h", wantErr: "schedule is required"},
+		{value: "schedule=0 2 * * 6", wantErr: "duration of at least 1m"},
+		{value: "schedule=0 2 * * 6;duration=30s", wantErr: "duration of at least 1m"},
+		{value: "schedule=0 2 * * 6;duration=two", wantErr: "invalid duration"},
+		{value: "schedule=0 2 * * 6;duration=2h;outside=upsert-only", wantErr: "outside must be"},
+		{value: "schedule=0 2 * * 6;duration=2h;timezone=Mars/Olympus", wantErr: "unknown time zone"},
+		{value: "schedule=0 2 * * 6;duration=2h;zone=abc", wantErr: `unknown key "zone"`},
+		{value: "schedule=0 2 * * 6;duration", wantErr: "expected key=value"},
+	} {
+		t.Run(tt.value, func(t *testing.T) {
+			w, err := ParseChangeWindow(tt.value)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, w)
+		})
+	}
+}
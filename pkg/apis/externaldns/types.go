@@ -68,6 +68,7 @@ type Config struct {
 	ConnectorSourceServer                         string
 	Provider                                      string
 	ProviderCacheTime                             time.Duration
+	Backends                                      []string
 	GoogleProject                                 string
 	GoogleBatchChangeSize                         int
 	GoogleBatchChangeInterval                     time.Duration
@@ -80,6 +81,7 @@ type Config struct {
 	ZoneIDFilter                                  []string
 	TargetNetFilter                               []string
 	ExcludeTargetNets                             []string
+	ReverseZones                                  []string
 	AlibabaCloudConfigFile                        string
 	AlibabaCloudZoneType                          string
 	AWSZoneType                                   string
@@ -139,6 +141,19 @@ type Config struct {
 	TLSClientCert                                 string
 	TLSClientCertKey                              string
 	Policy                                        string
+	ConflictResolution                            string
+	ConflictNamespaceAllowList                    map[string]string
+	MergeTargetsDomains                           []string
+	AdoptDomains                                  []string
+	DeletionGuardMaxDeletions                     int
+	DeletionGuardMaxPercent                       int
+	DeletionGuardConfirmations                    int
+	DeletionGuardConfigMap                        string
+	DeletionGracePeriod                           time.Duration
+	ChangeWindows                                 []string
+	FlapDampingMaxChanges                         int
+	FlapDampingWindow                             time.Duration
+	FlapDampingMaxBackoff                         time.Duration
 	Registry                                      string
 	TXTOwnerID                                    string
 	TXTPrefix                                     string
@@ -146,13 +161,36 @@ type Config struct {
 	TXTEncryptEnabled                             bool
 	TXTEncryptAESKey                              string `secure:"yes"`
 	TXTNewFormatOnly                              bool
+	TXTMigrate                                    bool
+	ConfigMapRegistryNamespace                    string
+	ConfigMapRegistryName                         string
+	ConfigMapRegistryShards                       int
+	FromOwner                                     string
+	ToOwner                                       string
+	Audit                                         bool
+	AuditOutput                                   string
+	AuditKnownOwners                              []string
 	Interval                                      time.Duration
 	MinEventSyncInterval                          time.Duration
 	Once                                          bool
 	DryRun                                        bool
+	PlanExportFile                                string
+	PlanExportFormat                              string
+	PlanOffline                                   bool
+	PlanManifests                                 []string
+	PlanRecords                                   string
 	UpdateEvents                                  bool
+	EmitEvents                                    bool
+	EnableLeaderElection                          bool
+	LeaderElectionLeaseName                       string
+	LeaderElectionNamespace                       string
+	LeaderElectionLeaseDuration                   time.Duration
+	LeaderElectionRenewDeadline                   time.Duration
+	LeaderElectionRetryPeriod                     time.Duration
 	LogFormat                                     string
 	MetricsAddress                                string
+	HealthCheckMaxSyncIntervals                   int
+	HealthCheckMaxSoftErrors                      int
 	LogLevel                                      string
 	TXTCacheInterval                              time.Duration
 	TXTWildcardReplacement                        string
@@ -163,7 +201,13 @@ type Config struct {
 	ExoscaleAPIZone                               string
 	CRDSourceAPIVersion                           string
 	CRDSourceKind                                 string
+	UnstructuredSourceResources                   []string
+	UnstructuredSourceHostnameJSONPath            string
+	UnstructuredSourceTargetJSONPath              string
+	UnstructuredSourceTTLJSONPath                 string
+	UnstructuredSourceRecordTypeJSONPath          string
 	ServiceTypeFilter                             []string
+	ServiceTopologyZones                          []string
 	CFAPIEndpoint                                 string
 	CFUsername                                    string
 	CFPassword                                    string
@@ -216,6 +260,7 @@ type Config struct {
 }
 
 var defaultConfig = &Config{
+	AdoptDomains:                []string{},
 	AkamaiAccessToken:           "",
 	AkamaiClientSecret:          "",
 	AkamaiClientToken:           "",
@@ -242,14 +287,19 @@ var defaultConfig = &Config{
 	AWSZoneMatchParent:          false,
 	AWSZoneTagFilter:            []string{},
 	AWSZoneType:                 "",
+	Audit:                       false,
+	AuditKnownOwners:            []string{},
+	AuditOutput:                 "table",
 	AzureConfigFile:             "/etc/kubernetes/azure.json",
 	AzureResourceGroup:          "",
 	AzureSubscriptionID:         "",
 	AzureZonesCacheDuration:     0 * time.Second,
 	AzureMaxRetriesCount:        3,
+	Backends:                    []string{},
 	CFAPIEndpoint:               "",
 	CFPassword:                  "",
 	CFUsername:                  "",
+	ChangeWindows:               []string{},
 	CloudflareCustomHostnamesCertificateAuthority: "none",
 	CloudflareCustomHostnames:                     false,
 	CloudflareCustomHostnamesMinTLSVersion:        "1.0",
@@ -259,14 +309,26 @@ var defaultConfig = &Config{
 
 	CombineFQDNAndAnnotation:     false,
 	Compatibility:                "",
+	ConfigMapRegistryName:        "external-dns-registry",
+	ConfigMapRegistryNamespace:   "",
+	ConfigMapRegistryShards:      1,
+	ConflictNamespaceAllowList:   map[string]string{},
+	ConflictResolution:           "targets",
 	ConnectorSourceServer:        "localhost:8080",
 	CoreDNSPrefix:                "/skydns/",
 	CRDSourceAPIVersion:          "externaldns.k8s.io/v1alpha1",
 	CRDSourceKind:                "DNSEndpoint",
 	DefaultTargets:               []string{},
+	DeletionGracePeriod:          0,
+	DeletionGuardConfigMap:       "",
+	DeletionGuardConfirmations:   0,
+	DeletionGuardMaxDeletions:    0,
+	DeletionGuardMaxPercent:      0,
 	DigitalOceanAPIPageSize:      50,
 	DomainFilter:                 []string{},
 	DryRun:                       false,
+	EmitEvents:                   false,
+	EnableLeaderElection:         false,
 	ExcludeDNSRecordTypes:        []string{},
 	ExcludeDomains:               []string{},
 	ExcludeTargetNets:            []string{},
@@ -276,7 +338,11 @@ var defaultConfig = &Config{
 	ExoscaleAPISecret:            "",
 	ExoscaleAPIZone:              "ch-gva-2",
 	ExposeInternalIPV6:           true,
+	FlapDampingMaxBackoff:        time.Hour,
+	FlapDampingMaxChanges:        0,
+	FlapDampingWindow:            5 * time.Minute,
 	FQDNTemplate:                 "",
+	FromOwner:                    "",
 	GatewayLabelFilter:           "",
 	GatewayName:                  "",
 	GatewayNamespace:             "",
@@ -289,6 +355,8 @@ var defaultConfig = &Config{
 	GoogleBatchChangeSize:        1000,
 	GoogleProject:                "",
 	GoogleZoneVisibility:         "",
+	HealthCheckMaxSoftErrors:     0,
+	HealthCheckMaxSyncIntervals:  0,
 	IgnoreHostnameAnnotation:     false,
 	IgnoreIngressRulesSpec:       false,
 	IgnoreIngressTLSSpec:         false,
@@ -297,9 +365,15 @@ var defaultConfig = &Config{
 	Interval:                     time.Minute,
 	KubeConfig:                   "",
 	LabelFilter:                  labels.Everything().String(),
+	LeaderElectionLeaseDuration:  15 * time.Second,
+	LeaderElectionLeaseName:      "external-dns",
+	LeaderElectionNamespace:      "default",
+	LeaderElectionRenewDeadline:  10 * time.Second,
+	LeaderElectionRetryPeriod:    2 * time.Second,
 	LogFormat:                    "text",
 	LogLevel:                     logrus.InfoLevel.String(),
 	ManagedDNSRecordTypes:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+	MergeTargetsDomains:          []string{},
 	MetricsAddress:               ":7979",
 	MinEventSyncInterval:         5 * time.Second,
 	Namespace:                    "",
@@ -321,6 +395,11 @@ var defaultConfig = &Config{
 	PiholePassword:               "",
 	PiholeServer:                 "",
 	PiholeTLSInsecureSkipVerify:  false,
+	PlanExportFile:               "",
+	PlanExportFormat:             "json",
+	PlanManifests:                []string{},
+	PlanOffline:                  false,
+	PlanRecords:                  "",
 	PluralCluster:                "",
 	PluralProvider:               "",
 	PodSourceDomain:              "",
@@ -333,6 +412,7 @@ var defaultConfig = &Config{
 	RegexDomainFilter:            regexp.MustCompile(""),
 	Registry:                     "txt",
 	RequestTimeout:               time.Second * 30,
+	ReverseZones:                 []string{},
 	RFC2136BatchChangeSize:       50,
 	RFC2136GSSTSIG:               false,
 	RFC2136Host:                  []string{""},
@@ -357,6 +437,7 @@ var defaultConfig = &Config{
 	TLSCA:                        "",
 	TLSClientCert:                "",
 	TLSClientCertKey:             "",
+	ToOwner:                      "",
 	TraefikDisableLegacy:         false,
 	TraefikDisableNew:            false,
 	TransIPAccountName:           "",
@@ -364,6 +445,7 @@ var defaultConfig = &Config{
 	TXTCacheInterval:             0,
 	TXTEncryptAESKey:             "",
 	TXTEncryptEnabled:            false,
+	TXTMigrate:                   false,
 	TXTNewFormatOnly:             false,
 	TXTOwnerID:                   "default",
 	TXTPrefix:                    "",
@@ -377,10 +459,14 @@ var defaultConfig = &Config{
 	ZoneIDFilter:                 []string{},
 }
 
+// Providers are the names of the supported DNS providers.
+var Providers = []string{"akamai", "alibabacloud", "aws", "aws-sd", "azure", "azure-dns", "azure-private-dns", "civo", "cloudflare", "coredns", "digitalocean", "dnsimple", "exoscale", "gandi", "godaddy", "google", "inmemory", "linode", "ns1", "oci", "ovh", "pdns", "pihole", "plural", "rfc2136", "scaleway", "skydns", "transip", "webhook"}
+
 // NewConfig returns new Config object
 func NewConfig() *Config {
 	return &Config{
-		AWSSDCreateTag: map[string]string{},
+		AWSSDCreateTag:             map[string]string{},
+		ConflictNamespaceAllowList: map[string]string{},
 	}
 }
 
@@ -436,7 +522,7 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("kubeconfig", "Retrieve target cluster configuration from a Kubernetes configuration file (default: auto-detect)").Default(defaultConfig.KubeConfig).StringVar(&cfg.KubeConfig)
 	app.Flag("request-timeout", "Request timeout when calling Kubernetes APIs. 0s means no timeout").Default(defaultConfig.RequestTimeout.String()).DurationVar(&cfg.RequestTimeout)
 	app.Flag("resolve-service-load-balancer-hostname", "Resolve the hostname of LoadBalancer-type Service object to IP addresses in order to create DNS A/AAAA records instead of CNAMEs").BoolVar(&cfg.ResolveServiceLoadBalancerHostname)
-	app.Flag("listen-endpoint-events", "Trigger a reconcile on changes to Endpoints, for Service source (default: false)").BoolVar(&cfg.ListenEndpointEvents)
+	app.Flag("listen-endpoint-events", "Trigger a reconcile on changes to EndpointSlices, for Service source (default: false)").BoolVar(&cfg.ListenEndpointEvents)
 
 	// Flags related to cloud foundry
 	app.Flag("cf-api-endpoint", "The fully-qualified domain name of the cloud foundry instance you are targeting").Default(defaultConfig.CFAPIEndpoint).StringVar(&cfg.CFAPIEndpoint)
@@ -471,8 +557,8 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("ignore-ingress-tls-spec", "Ignore the spec.tls section in Ingress resources (default: false)").BoolVar(&cfg.IgnoreIngressTLSSpec)
 	app.Flag("ignore-non-host-network-pods", "Ignore pods not running on host network when using pod source (default: false)").BoolVar(&cfg.IgnoreNonHostNetworkPods)
 	app.Flag("ingress-class", "Require an Ingress to have this class name (defaults to any class; specify multiple times to allow more than one class)").StringsVar(&cfg.IngressClassNames)
-	app.Flag("label-filter", "Filter resources queried for endpoints by label selector; currently supported by source types crd, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, ingress, node, openshift-route, service and ambassador-host").Default(defaultConfig.LabelFilter).StringVar(&cfg.LabelFilter)
-	managedRecordTypesHelp := fmt.Sprintf("Record types to manage; specify multiple times to include many; (default: %s) (supported records: A, AAAA, CNAME, NS, SRV, TXT)", strings.Join(defaultConfig.ManagedDNSRecordTypes, ","))
+	app.Flag("label-filter", "Filter resources queried for endpoints by label selector; currently supported by source types crd, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, ingress, node, openshift-route, service, ambassador-host and unstructured").Default(defaultConfig.LabelFilter).StringVar(&cfg.LabelFilter)
+	managedRecordTypesHelp := fmt.Sprintf("Record types to manage; specify multiple times to include many; (default: %s) (supported records: A, AAAA, CAA, CNAME, HTTPS, NS, PTR, SRV, SVCB, TXT)", strings.Join(defaultConfig.ManagedDNSRecordTypes, ","))
 	app.Flag("managed-record-types", managedRecordTypesHelp).Default(defaultConfig.ManagedDNSRecordTypes...).StringsVar(&cfg.ManagedDNSRecordTypes)
 	app.Flag("namespace", "Limit resources queried for endpoints to a specific namespace (default: all namespaces)").Default(defaultConfig.Namespace).StringVar(&cfg.Namespace)
 	app.Flag("nat64-networks", "Adding an A record for each AAAA record in NAT64-enabled networks; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.NAT64Networks)
@@ -480,16 +566,23 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("pod-source-domain", "Domain to use for pods records (optional)").Default(defaultConfig.PodSourceDomain).StringVar(&cfg.PodSourceDomain)
 	app.Flag("publish-host-ip", "Allow external-dns to publish host-ip for headless services (optional)").BoolVar(&cfg.PublishHostIP)
 	app.Flag("publish-internal-services", "Allow external-dns to publish DNS records for ClusterIP services (optional)").BoolVar(&cfg.PublishInternal)
+	app.Flag("reverse-zones", "Manage PTR records for the targets of A and AAAA records in the network of a reverse zone, given as CIDR, e.g. 10.0.0.0/24 or the RFC 2317 classless delegation 10.0.0.0/26; specify multiple times for multiple zones (optional)").StringsVar(&cfg.ReverseZones)
 	app.Flag("service-type-filter", "The service types to filter by. Specify multiple times for multiple filters to be applied. (optional, default: all, expected: ClusterIP, NodePort, LoadBalancer or ExternalName)").Default(defaultConfig.ServiceTypeFilter...).StringsVar(&cfg.ServiceTypeFilter)
-	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy")
+	app.Flag("service-topology-zone", "Publish only the endpoints of headless services in a topology zone, as given by their EndpointSlices; specify multiple times for multiple zones (optional, default: all zones)").StringsVar(&cfg.ServiceTopologyZones)
+	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy, unstructured)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy", "unstructured")
 	app.Flag("target-net-filter", "Limit possible targets by a net filter; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.TargetNetFilter)
 	app.Flag("traefik-disable-legacy", "Disable listeners on Resources under the traefik.containo.us API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableLegacy)).BoolVar(&cfg.TraefikDisableLegacy)
 	app.Flag("traefik-disable-new", "Disable listeners on Resources under the traefik.io API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableNew)).BoolVar(&cfg.TraefikDisableNew)
+	app.Flag("unstructured-source-resource", "The resource of the objects for the unstructured source, given as resource.version.group, e.g. `widgets.v1.example.com`; specify multiple times for multiple resources sharing the expressions (required when using unstructured source)").StringsVar(&cfg.UnstructuredSourceResources)
+	app.Flag("unstructured-source-hostname-jsonpath", "JSONPath expression of the hostnames of the objects for the unstructured source, e.g. `{.spec.hostnames[*]}` (optional)").StringVar(&cfg.UnstructuredSourceHostnameJSONPath)
+	app.Flag("unstructured-source-target-jsonpath", "JSONPath expression of the targets of the objects for the unstructured source, e.g. `{.status.addresses[*].value}` (optional)").StringVar(&cfg.UnstructuredSourceTargetJSONPath)
+	app.Flag("unstructured-source-ttl-jsonpath", "JSONPath expression of the TTL of the objects for the unstructured source, in seconds or as a duration (optional)").StringVar(&cfg.UnstructuredSourceTTLJSONPath)
+	app.Flag("unstructured-source-record-type-jsonpath", "JSONPath expression or literal value of the record type of the objects for the unstructured source, e.g. `TXT` (optional, default: inferred from the targets)").StringVar(&cfg.UnstructuredSourceRecordTypeJSONPath)
 
 	// Flags related to providers
-	providers := []string{"akamai", "alibabacloud", "aws", "aws-sd", "azure", "azure-dns", "azure-private-dns", "civo", "cloudflare", "coredns", "digitalocean", "dnsimple", "exoscale", "gandi", "godaddy", "google", "inmemory", "linode", "ns1", "oci", "ovh", "pdns", "pihole", "plural", "rfc2136", "scaleway", "skydns", "transip", "webhook"}
-	app.Flag("provider", "The DNS provider where the DNS records will be created (required, options: "+strings.Join(providers, ", ")+")").Required().PlaceHolder("provider").EnumVar(&cfg.Provider, providers...)
+	app.Flag("provider", "The DNS provider where the DNS records will be created (required, options: "+strings.Join(Providers, ", ")+")").Required().PlaceHolder("provider").EnumVar(&cfg.Provider, Providers...)
 	app.Flag("provider-cache-time", "The time to cache the DNS provider record list requests.").Default(defaultConfig.ProviderCacheTime.String()).DurationVar(&cfg.ProviderCacheTime)
+	app.Flag("backend", "Manage the endpoints routed to an additional DNS provider, given as comma separated key=value pairs of name, provider, domain-filter, zone-id-filter, access (public or private) and txt-owner-id, e.g. name=private,provider=aws,access=private,txt-owner-id=private; endpoints not routed to any backend are managed in the provider; specify multiple times for multiple backends (optional)").StringsVar(&cfg.Backends)
 	app.Flag("domain-filter", "Limit possible target zones by a domain suffix; specify multiple times for multiple domains (optional)").Default("").StringsVar(&cfg.DomainFilter)
 	app.Flag("exclude-domains", "Exclude subdomains (optional)").Default("").StringsVar(&cfg.ExcludeDomains)
 	app.Flag("regex-domain-filter", "Limit possible domains and target zones by a Regex filter; Overrides domain-filter (optional)").Default(defaultConfig.RegexDomainFilter.String()).RegexpVar(&cfg.RegexDomainFilter)
@@ -610,9 +703,22 @@ func App(cfg *Config) *kingpin.Application {
 
 	// Flags related to policies
 	app.Flag("policy", "Modify how DNS records are synchronized between sources and providers (default: sync, options: sync, upsert-only, create-only)").Default(defaultConfig.Policy).EnumVar(&cfg.Policy, "sync", "upsert-only", "create-only")
+	app.Flag("conflict-resolution", "Decide which resource acquires a DNS name claimed by several resources; the resource with the lowest targets, the oldest resource, the resource with the highest conflict-priority annotation, or none, leaving the record alone (default: targets, options: targets, oldest, priority, refuse)").Default(defaultConfig.ConflictResolution).EnumVar(&cfg.ConflictResolution, "targets", "oldest", "priority", "refuse")
+	app.Flag("conflict-namespace-allowlist", "Only allow resources in the given namespaces to claim DNS names in a domain, e.g. example.com=team-a,team-b; the most specific domain applies (optional). The flag can be used multiple times").StringMapVar(&cfg.ConflictNamespaceAllowList)
+	app.Flag("merge-targets-domain", "Merge the targets of all resources claiming the same DNS name in this domain into one record set, instead of resolving the conflict (optional). Resources can also opt in with the merge-targets annotation. The flag can be used multiple times").StringsVar(&cfg.MergeTargetsDomains)
+	app.Flag("adopt-domain", "Adopt unowned records in this domain which are desired by a resource: their ownership is written to the registry and they are managed like records created by ExternalDNS (optional). Resources can also opt in with the adopt annotation. The flag can be used multiple times").StringsVar(&cfg.AdoptDomains)
+	app.Flag("deletion-guard-max-deletions", "Hold back the deletions of a synchronization when there are more of them than this number, until they are confirmed or approved (default: 0, no limit)").Default(strconv.Itoa(defaultConfig.DeletionGuardMaxDeletions)).IntVar(&cfg.DeletionGuardMaxDeletions)
+	app.Flag("deletion-guard-max-percent", "Hold back the deletions of a synchronization when they delete more than this percentage of the owned records, until they are confirmed or approved (default: 0, no limit)").Default(strconv.Itoa(defaultConfig.DeletionGuardMaxPercent)).IntVar(&cfg.DeletionGuardMaxPercent)
+	app.Flag("deletion-guard-confirmations", "Apply held back deletions once this number of consecutive synchronizations planned the same deletions (default: 0, only once approved)").Default(strconv.Itoa(defaultConfig.DeletionGuardConfirmations)).IntVar(&cfg.DeletionGuardConfirmations)
+	app.Flag("deletion-guard-configmap", "The ConfigMap, as namespace/name, whose approve-deletions annotation approves held back deletions by their fingerprint (optional)").Default(defaultConfig.DeletionGuardConfigMap).StringVar(&cfg.DeletionGuardConfigMap)
+	app.Flag("deletion-grace-period", "Delay the deletion of records no longer desired by any resource by this duration, in which they are kept if desired again; requires the txt, dynamodb or configmap registry, which store the pending deletions (default: 0s, disabled)").Default(defaultConfig.DeletionGracePeriod.String()).DurationVar(&cfg.DeletionGracePeriod)
+	app.Flag("change-window", "Restrict the changes of records to the windows opening at the times of a cron schedule, given as semicolon separated key=value pairs of domain-filter, schedule, duration, outside (create-only or none: the changes applied outside of the windows, default create-only) and timezone (default UTC), e.g. domain-filter=example.com;schedule=0 2 * * 6;duration=2h; the other changes are deferred until a window opens; specify multiple times for multiple windows (optional)").StringsVar(&cfg.ChangeWindows)
+	app.Flag("flap-damping-max-changes", "Freeze a record at its targets when they changed more than this number of times within --flap-damping-window, e.g. with pods or nodes which come and go; its target changes are suppressed for a backoff (default: 0, disabled)").Default(strconv.Itoa(defaultConfig.FlapDampingMaxChanges)).IntVar(&cfg.FlapDampingMaxChanges)
+	app.Flag("flap-damping-window", "The time the target changes of a record are counted in, and the first backoff of a frozen record (default: 5m)").Default(defaultConfig.FlapDampingWindow.String()).DurationVar(&cfg.FlapDampingWindow)
+	app.Flag("flap-damping-max-backoff", "The maximum time a record is frozen; the backoff doubles whenever the record is frozen again (default: 1h)").Default(defaultConfig.FlapDampingMaxBackoff.String()).DurationVar(&cfg.FlapDampingMaxBackoff)
 
 	// Flags related to the registry
-	app.Flag("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: txt, noop, dynamodb, aws-sd)").Default(defaultConfig.Registry).EnumVar(&cfg.Registry, "txt", "noop", "dynamodb", "aws-sd")
+	app.Flag("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: txt, noop, dynamodb, aws-sd, configmap)").Default(defaultConfig.Registry).EnumVar(&cfg.Registry, "txt", "noop", "dynamodb", "aws-sd", "configmap")
 	app.Flag("txt-owner-id", "When using the TXT or DynamoDB registry, a name that identifies this instance of ExternalDNS (default: default)").Default(defaultConfig.TXTOwnerID).StringVar(&cfg.TXTOwnerID)
 	app.Flag("txt-prefix", "When using the TXT registry, a custom string that's prefixed to each ownership DNS record (optional). Could contain record type template like '%{record_type}-prefix-'. Mutual exclusive with txt-suffix!").Default(defaultConfig.TXTPrefix).StringVar(&cfg.TXTPrefix)
 	app.Flag("txt-suffix", "When using the TXT registry, a custom string that's suffixed to the host portion of each ownership DNS record (optional). Could contain record type template like '-%{record_type}-suffix'. Mutual exclusive with txt-prefix!").Default(defaultConfig.TXTSuffix).StringVar(&cfg.TXTSuffix)
@@ -620,8 +726,17 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("txt-encrypt-enabled", "When using the TXT registry, set if TXT records should be encrypted before stored (default: disabled)").BoolVar(&cfg.TXTEncryptEnabled)
 	app.Flag("txt-encrypt-aes-key", "When using the TXT registry, set TXT record decryption and encryption 32 byte aes key (required when --txt-encrypt=true)").Default(defaultConfig.TXTEncryptAESKey).StringVar(&cfg.TXTEncryptAESKey)
 	app.Flag("txt-new-format-only", "When using the TXT registry, only use new format records which include record type information (e.g., prefix: 'a-'). Reduces number of TXT records (default: disabled)").BoolVar(&cfg.TXTNewFormatOnly)
+	app.Flag("txt-migrate", "When using the TXT registry, migrate the TXT records of the owner ID to the new format and delete its orphaned TXT records, then exit; the changes are written first, and only written with --dry-run (default: disabled, requires --once)").BoolVar(&cfg.TXTMigrate)
 	app.Flag("dynamodb-region", "When using the DynamoDB registry, the AWS region of the DynamoDB table (optional)").Default(cfg.AWSDynamoDBRegion).StringVar(&cfg.AWSDynamoDBRegion)
 	app.Flag("dynamodb-table", "When using the DynamoDB registry, the name of the DynamoDB table (default: \"external-dns\")").Default(defaultConfig.AWSDynamoDBTable).StringVar(&cfg.AWSDynamoDBTable)
+	app.Flag("configmap-registry-namespace", "When using the ConfigMap registry, the namespace of the ConfigMaps (default: the namespace of the pod, from the POD_NAMESPACE environment variable or the service account)").Default(defaultConfig.ConfigMapRegistryNamespace).StringVar(&cfg.ConfigMapRegistryNamespace)
+	app.Flag("configmap-registry-name", "When using the ConfigMap registry, the prefix of the names of the ConfigMaps, which are suffixed by their shard number (default: external-dns-registry)").Default(defaultConfig.ConfigMapRegistryName).StringVar(&cfg.ConfigMapRegistryName)
+	app.Flag("configmap-registry-shards", "When using the ConfigMap registry, the number of ConfigMaps the records are distributed over; instances sharing the ConfigMaps must use the same number (default: 1)").Default(strconv.Itoa(defaultConfig.ConfigMapRegistryShards)).IntVar(&cfg.ConfigMapRegistryShards)
+	app.Flag("from-owner", "Transfer the ownership of the records of this owner ID in the domain filter to the owner ID of --to-owner, then exit; only the ownership information in the registry is modified, not the records (optional, requires --to-owner)").Default(defaultConfig.FromOwner).StringVar(&cfg.FromOwner)
+	app.Flag("to-owner", "The owner ID which receives the ownership of the records of --from-owner (optional, requires --from-owner)").Default(defaultConfig.ToOwner).StringVar(&cfg.ToOwner)
+	app.Flag("audit", "Audit the registry without modifying anything, then exit: report the ownership information whose record no longer exists, the records owned by the owner ID which are not desired by any source, and the records of unknown owner IDs. Exits with 2 if there are findings (default: disabled)").BoolVar(&cfg.Audit)
+	app.Flag("audit-output", "The format of the audit report (default: table, options: table, json)").Default(defaultConfig.AuditOutput).EnumVar(&cfg.AuditOutput, "table", "json")
+	app.Flag("audit-known-owner", "An owner ID besides --txt-owner-id whose records are not reported by the audit (optional). The flag can be used multiple times").StringsVar(&cfg.AuditKnownOwners)
 
 	// Flags related to the main control loop
 	app.Flag("txt-cache-interval", "The interval between cache synchronizations in duration format (default: disabled)").Default(defaultConfig.TXTCacheInterval.String()).DurationVar(&cfg.TXTCacheInterval)
@@ -629,11 +744,25 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("min-event-sync-interval", "The minimum interval between two consecutive synchronizations triggered from kubernetes events in duration format (default: 5s)").Default(defaultConfig.MinEventSyncInterval.String()).DurationVar(&cfg.MinEventSyncInterval)
 	app.Flag("once", "When enabled, exits the synchronization loop after the first iteration (default: disabled)").BoolVar(&cfg.Once)
 	app.Flag("dry-run", "When enabled, prints DNS record changes rather than actually performing them (default: disabled)").BoolVar(&cfg.DryRun)
+	app.Flag("plan-export-file", "When set, writes the changes calculated by every synchronization to this file, with the reason, owner and resource of every change; the file is replaced by every synchronization (default: disabled)").Default(defaultConfig.PlanExportFile).StringVar(&cfg.PlanExportFile)
+	app.Flag("plan-export-format", "The format of the exported changes (default: json, options: json, yaml)").Default(defaultConfig.PlanExportFormat).EnumVar(&cfg.PlanExportFormat, "json", "yaml")
+	app.Flag("plan-offline", "Calculate the changes from the Kubernetes manifests of --plan-manifest and the records of --plan-records without a cluster or DNS provider, write them to --plan-export-file or stdout, then exit (default: disabled)").BoolVar(&cfg.PlanOffline)
+	app.Flag("plan-manifest", "A file or directory of Kubernetes manifests whose objects are read by the sources with --plan-offline (required with --plan-offline). The flag can be used multiple times").StringsVar(&cfg.PlanManifests)
+	app.Flag("plan-records", "A JSON or YAML file with the list of the records in the DNS provider, including the registry's records, to calculate the changes against with --plan-offline (required with --plan-offline)").Default(defaultConfig.PlanRecords).StringVar(&cfg.PlanRecords)
 	app.Flag("events", "When enabled, in addition to running every interval, the reconciliation loop will get triggered when supported sources change (default: disabled)").BoolVar(&cfg.UpdateEvents)
+	app.Flag("emit-events", "When enabled, emits Kubernetes Events on the source objects when their DNS records are created, updated, deleted or rejected (default: disabled)").BoolVar(&cfg.EmitEvents)
+	app.Flag("enable-leader-election", "When enabled, only the replica holding the leader election lease runs the reconciliation loop; other replicas wait with their sources synced (default: disabled)").BoolVar(&cfg.EnableLeaderElection)
+	app.Flag("leader-election-lease-name", "The name of the Lease object used for leader election (default: external-dns)").Default(defaultConfig.LeaderElectionLeaseName).StringVar(&cfg.LeaderElectionLeaseName)
+	app.Flag("leader-election-namespace", "The namespace of the Lease object used for leader election (default: default)").Default(defaultConfig.LeaderElectionNamespace).StringVar(&cfg.LeaderElectionNamespace)
+	app.Flag("leader-election-lease-duration", "The duration that non-leader replicas wait before trying to acquire an expired lease in duration format (default: 15s)").Default(defaultConfig.LeaderElectionLeaseDuration.String()).DurationVar(&cfg.LeaderElectionLeaseDuration)
+	app.Flag("leader-election-renew-deadline", "The duration that the leader retries refreshing the lease before giving up leadership in duration format (default: 10s)").Default(defaultConfig.LeaderElectionRenewDeadline.String()).DurationVar(&cfg.LeaderElectionRenewDeadline)
+	app.Flag("leader-election-retry-period", "The duration replicas wait between attempts to acquire or renew the lease in duration format (default: 2s)").Default(defaultConfig.LeaderElectionRetryPeriod.String()).DurationVar(&cfg.LeaderElectionRetryPeriod)
 
 	// Miscellaneous flags
 	app.Flag("log-format", "The format in which log messages are printed (default: text, options: text, json)").Default(defaultConfig.LogFormat).EnumVar(&cfg.LogFormat, "text", "json")
 	app.Flag("metrics-address", "Specify where to serve the metrics and health check endpoint (default: :7979)").Default(defaultConfig.MetricsAddress).StringVar(&cfg.MetricsAddress)
+	app.Flag("health-check-max-sync-intervals", "The number of intervals without a successful synchronization after which the /healthz endpoint reports unhealthy (default: 0, disabled)").Default(strconv.Itoa(defaultConfig.HealthCheckMaxSyncIntervals)).IntVar(&cfg.HealthCheckMaxSyncIntervals)
+	app.Flag("health-check-max-soft-errors", "The number of consecutive soft errors after which the /healthz endpoint reports unhealthy (default: 0, disabled)").Default(strconv.Itoa(defaultConfig.HealthCheckMaxSoftErrors)).IntVar(&cfg.HealthCheckMaxSoftErrors)
 	app.Flag("log-level", "Set the level of logging. (default: info, options: panic, debug, info, warning, error, fatal)").Default(defaultConfig.LogLevel).EnumVar(&cfg.LogLevel, allLogLevelsAsStrings()...)
 
 	// Webhook provider
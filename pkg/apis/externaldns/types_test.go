@@ -69,6 +69,9 @@ var (
 		AWSSDServiceCleanup:                    false,
 		AWSSDCreateTag:                         map[string]string{},
 		AWSDynamoDBTable:                       "external-dns",
+		ConfigMapRegistryNamespace:             "",
+		ConfigMapRegistryName:                  "external-dns-registry",
+		ConfigMapRegistryShards:                1,
 		AzureConfigFile:                        "/etc/kubernetes/azure.json",
 		AzureResourceGroup:                     "",
 		AzureSubscriptionID:                    "",
@@ -97,16 +100,29 @@ var (
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "",
 		Policy:                                        "sync",
+		ConflictResolution:                            "targets",
+		ConflictNamespaceAllowList:                    map[string]string{},
+		FlapDampingWindow:                             5 * time.Minute,
+		FlapDampingMaxBackoff:                         time.Hour,
 		Registry:                                      "txt",
 		TXTOwnerID:                                    "default",
 		TXTPrefix:                                     "",
 		TXTCacheInterval:                              0,
 		TXTNewFormatOnly:                              false,
+		TXTMigrate:                                    false,
+		AuditOutput:                                   "table",
 		Interval:                                      time.Minute,
 		MinEventSyncInterval:                          5 * time.Second,
 		Once:                                          false,
 		DryRun:                                        false,
+		PlanExportFormat:                              "json",
 		UpdateEvents:                                  false,
+		EmitEvents:                                    false,
+		LeaderElectionLeaseName:                       "external-dns",
+		LeaderElectionNamespace:                       "default",
+		LeaderElectionLeaseDuration:                   15 * time.Second,
+		LeaderElectionRenewDeadline:                   10 * time.Second,
+		LeaderElectionRetryPeriod:                     2 * time.Second,
 		LogFormat:                                     "text",
 		MetricsAddress:                                ":7979",
 		LogLevel:                                      logrus.InfoLevel.String(),
@@ -159,6 +175,8 @@ var (
 		ZoneIDFilter:                           []string{"/hostedzone/ZTST1", "/hostedzone/ZTST2"},
 		TargetNetFilter:                        []string{"10.0.0.0/9", "10.1.0.0/9"},
 		ExcludeTargetNets:                      []string{"1.0.0.0/9", "1.1.0.0/9"},
+		ReverseZones:                           []string{"10.0.0.0/24", "10.1.0.0/26"},
+		Backends:                               []string{"name=private,provider=aws,access=private"},
 		AlibabaCloudConfigFile:                 "/etc/kubernetes/alibaba-cloud.json",
 		AWSZoneType:                            "private",
 		AWSZoneTagFilter:                       []string{"tag=foo"},
@@ -177,6 +195,9 @@ var (
 		AWSSDServiceCleanup:                    true,
 		AWSSDCreateTag:                         map[string]string{"key1": "value1", "key2": "value2"},
 		AWSDynamoDBTable:                       "custom-table",
+		ConfigMapRegistryNamespace:             "external-dns",
+		ConfigMapRegistryName:                  "dns-owners",
+		ConfigMapRegistryShards:                4,
 		AzureConfigFile:                        "azure.json",
 		AzureResourceGroup:                     "arg",
 		AzureSubscriptionID:                    "arg",
@@ -209,18 +230,52 @@ var (
 		TLSClientCertKey:                              "/path/to/key.pem",
 		PodSourceDomain:                               "example.org",
 		Policy:                                        "upsert-only",
+		ConflictResolution:                            "refuse",
+		ConflictNamespaceAllowList:                    map[string]string{"example.com": "team-a,team-b", "example.org": "team-c"},
+		MergeTargetsDomains:                           []string{"global.example.com", "global.example.org"},
+		AdoptDomains:                                  []string{"legacy.example.com"},
+		DeletionGuardMaxDeletions:                     50,
+		DeletionGuardMaxPercent:                       20,
+		DeletionGuardConfirmations:                    3,
+		DeletionGuardConfigMap:                        "external-dns/deletion-approval",
+		DeletionGracePeriod:                           10 * time.Minute,
+		ChangeWindows:                                 []string{"domain-filter=example.com;schedule=0 2 * * 6;duration=2h", "schedule=0 22 * * 1,3;duration=1h;outside=none"},
+		FlapDampingMaxChanges:                         3,
+		FlapDampingWindow:                             10 * time.Minute,
+		FlapDampingMaxBackoff:                         2 * time.Hour,
+		ServiceTopologyZones:                          []string{"eu-west-1a", "eu-west-1b"},
+		FromOwner:                                     "old-owner",
+		ToOwner:                                       "new-owner",
 		Registry:                                      "noop",
 		TXTOwnerID:                                    "owner-1",
 		TXTPrefix:                                     "associated-txt-record",
 		TXTCacheInterval:                              12 * time.Hour,
 		TXTNewFormatOnly:                              true,
+		TXTMigrate:                                    true,
+		Audit:                                         true,
+		AuditOutput:                                   "json",
+		AuditKnownOwners:                              []string{"owner-2", "owner-3"},
 		Interval:                                      10 * time.Minute,
 		MinEventSyncInterval:                          50 * time.Second,
 		Once:                                          true,
 		DryRun:                                        true,
+		PlanExportFile:                                "/tmp/plan.yaml",
+		PlanExportFormat:                              "yaml",
+		PlanOffline:                                   true,
+		PlanManifests:                                 []string{"/manifests/app.yaml", "/manifests/base"},
+		PlanRecords:                                   "/tmp/records.json",
 		UpdateEvents:                                  true,
+		EmitEvents:                                    true,
+		EnableLeaderElection:                          true,
+		LeaderElectionLeaseName:                       "external-dns-test",
+		LeaderElectionNamespace:                       "kube-system",
+		LeaderElectionLeaseDuration:                   30 * time.Second,
+		LeaderElectionRenewDeadline:                   20 * time.Second,
+		LeaderElectionRetryPeriod:                     5 * time.Second,
 		LogFormat:                                     "json",
 		MetricsAddress:                                "127.0.0.1:9099",
+		HealthCheckMaxSyncIntervals:                   5,
+		HealthCheckMaxSoftErrors:                      3,
 		LogLevel:                                      logrus.DebugLevel.String(),
 		ConnectorSourceServer:                         "localhost:8081",
 		ExoscaleAPIEnvironment:                        "api1",
@@ -229,6 +284,11 @@ var (
 		ExoscaleAPISecret:                             "2",
 		CRDSourceAPIVersion:                           "test.k8s.io/v1alpha1",
 		CRDSourceKind:                                 "Endpoint",
+		UnstructuredSourceResources:                   []string{"widgets.v1.example.com", "gadgets.v1.example.com"},
+		UnstructuredSourceHostnameJSONPath:            "{.spec.hostnames[*]}",
+		UnstructuredSourceTargetJSONPath:              "{.status.addresses[*].value}",
+		UnstructuredSourceTTLJSONPath:                 "{.spec.ttl}",
+		UnstructuredSourceRecordTypeJSONPath:          "TXT",
 		NS1Endpoint:                                   "https://api.example.com/v1",
 		NS1IgnoreSSL:                                  true,
 		TransIPAccountName:                            "transip",
@@ -333,6 +393,9 @@ func TestParseFlags(t *testing.T) {
 				"--target-net-filter=10.1.0.0/9",
 				"--exclude-target-net=1.0.0.0/9",
 				"--exclude-target-net=1.1.0.0/9",
+				"--reverse-zones=10.0.0.0/24",
+				"--reverse-zones=10.1.0.0/26",
+				"--backend=name=private,provider=aws,access=private",
 				"--aws-zone-type=private",
 				"--aws-zone-tags=tag=foo",
 				"--aws-zone-match-parent",
@@ -353,19 +416,62 @@ func TestParseFlags(t *testing.T) {
 				"--no-aws-evaluate-target-health",
 				"--pihole-api-version=6",
 				"--policy=upsert-only",
+				"--conflict-resolution=refuse",
+				"--conflict-namespace-allowlist=example.com=team-a,team-b",
+				"--conflict-namespace-allowlist=example.org=team-c",
+				"--merge-targets-domain=global.example.com",
+				"--merge-targets-domain=global.example.org",
+				"--adopt-domain=legacy.example.com",
+				"--deletion-guard-max-deletions=50",
+				"--deletion-guard-max-percent=20",
+				"--deletion-guard-confirmations=3",
+				"--deletion-guard-configmap=external-dns/deletion-approval",
+				"--deletion-grace-period=10m",
+				"--change-window=domain-filter=example.com;schedule=0 2 * * 6;duration=2h",
+				"--change-window=schedule=0 22 * * 1,3;duration=1h;outside=none",
+				"--flap-damping-max-changes=3",
+				"--flap-damping-window=10m",
+				"--flap-damping-max-backoff=2h",
+				"--service-topology-zone=eu-west-1a",
+				"--service-topology-zone=eu-west-1b",
+				"--from-owner=old-owner",
+				"--to-owner=new-owner",
 				"--registry=noop",
 				"--txt-owner-id=owner-1",
 				"--txt-prefix=associated-txt-record",
 				"--txt-cache-interval=12h",
 				"--txt-new-format-only",
+				"--txt-migrate",
+				"--audit",
+				"--audit-output=json",
+				"--audit-known-owner=owner-2",
+				"--audit-known-owner=owner-3",
 				"--dynamodb-table=custom-table",
+				"--configmap-registry-namespace=external-dns",
+				"--configmap-registry-name=dns-owners",
+				"--configmap-registry-shards=4",
 				"--interval=10m",
 				"--min-event-sync-interval=50s",
 				"--once",
 				"--dry-run",
+				"--plan-export-file=/tmp/plan.yaml",
+				"--plan-export-format=yaml",
+				"--plan-offline",
+				"--plan-manifest=/manifests/app.yaml",
+				"--plan-manifest=/manifests/base",
+				"--plan-records=/tmp/records.json",
 				"--events",
+				"--emit-events",
+				"--enable-leader-election",
+				"--leader-election-lease-name=external-dns-test",
+				"--leader-election-namespace=kube-system",
+				"--leader-election-lease-duration=30s",
+				"--leader-election-renew-deadline=20s",
+				"--leader-election-retry-period=5s",
 				"--log-format=json",
 				"--metrics-address=127.0.0.1:9099",
+				"--health-check-max-sync-intervals=5",
+				"--health-check-max-soft-errors=3",
 				"--log-level=debug",
 				"--connector-source-server=localhost:8081",
 				"--exoscale-apienv=api1",
@@ -374,6 +480,12 @@ func TestParseFlags(t *testing.T) {
 				"--exoscale-apisecret=2",
 				"--crd-source-apiversion=test.k8s.io/v1alpha1",
 				"--crd-source-kind=Endpoint",
+				"--unstructured-source-resource=widgets.v1.example.com",
+				"--unstructured-source-resource=gadgets.v1.example.com",
+				"--unstructured-source-hostname-jsonpath={.spec.hostnames[*]}",
+				"--unstructured-source-target-jsonpath={.status.addresses[*].value}",
+				"--unstructured-source-ttl-jsonpath={.spec.ttl}",
+				"--unstructured-source-record-type-jsonpath=TXT",
 				"--ns1-endpoint=https://api.example.com/v1",
 				"--ns1-ignoressl",
 				"--transip-account=transip",
@@ -445,6 +557,8 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_REGEX_DOMAIN_EXCLUSION":                            "xapi\\.(example\\.org|company\\.com)$",
 				"EXTERNAL_DNS_TARGET_NET_FILTER":                                 "10.0.0.0/9\n10.1.0.0/9",
 				"EXTERNAL_DNS_EXCLUDE_TARGET_NET":                                "1.0.0.0/9\n1.1.0.0/9",
+				"EXTERNAL_DNS_REVERSE_ZONES":                                     "10.0.0.0/24\n10.1.0.0/26",
+				"EXTERNAL_DNS_BACKEND":                                           "name=private,provider=aws,access=private",
 				"EXTERNAL_DNS_PDNS_SERVER":                                       "http://ns.example.com:8081",
 				"EXTERNAL_DNS_PDNS_ID":                                           "localhost",
 				"EXTERNAL_DNS_PDNS_API_KEY":                                      "some-secret-key",
@@ -472,20 +586,57 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_AWS_SD_SERVICE_CLEANUP":                            "true",
 				"EXTERNAL_DNS_AWS_SD_CREATE_TAG":                                 "key1=value1\nkey2=value2",
 				"EXTERNAL_DNS_DYNAMODB_TABLE":                                    "custom-table",
+				"EXTERNAL_DNS_CONFIGMAP_REGISTRY_NAMESPACE":                      "external-dns",
+				"EXTERNAL_DNS_CONFIGMAP_REGISTRY_NAME":                           "dns-owners",
+				"EXTERNAL_DNS_CONFIGMAP_REGISTRY_SHARDS":                         "4",
 				"EXTERNAL_DNS_PIHOLE_API_VERSION":                                "6",
 				"EXTERNAL_DNS_POLICY":                                            "upsert-only",
+				"EXTERNAL_DNS_CONFLICT_RESOLUTION":                               "refuse",
+				"EXTERNAL_DNS_CONFLICT_NAMESPACE_ALLOWLIST":                      "example.com=team-a,team-b\nexample.org=team-c",
+				"EXTERNAL_DNS_MERGE_TARGETS_DOMAIN":                              "global.example.com\nglobal.example.org",
+				"EXTERNAL_DNS_ADOPT_DOMAIN":                                      "legacy.example.com",
+				"EXTERNAL_DNS_DELETION_GUARD_MAX_DELETIONS":                      "50",
+				"EXTERNAL_DNS_DELETION_GUARD_MAX_PERCENT":                        "20",
+				"EXTERNAL_DNS_DELETION_GUARD_CONFIRMATIONS":                      "3",
+				"EXTERNAL_DNS_DELETION_GUARD_CONFIGMAP":                          "external-dns/deletion-approval",
+				"EXTERNAL_DNS_DELETION_GRACE_PERIOD":                             "10m",
+				"EXTERNAL_DNS_FLAP_DAMPING_MAX_CHANGES":                          "3",
+				"EXTERNAL_DNS_FLAP_DAMPING_WINDOW":                               "10m",
+				"EXTERNAL_DNS_FLAP_DAMPING_MAX_BACKOFF":                          "2h",
+				"EXTERNAL_DNS_SERVICE_TOPOLOGY_ZONE":                             "eu-west-1a\neu-west-1b",
+				"EXTERNAL_DNS_CHANGE_WINDOW":                                     "domain-filter=example.com;schedule=0 2 * * 6;duration=2h\nschedule=0 22 * * 1,3;duration=1h;outside=none",
+				"EXTERNAL_DNS_FROM_OWNER":                                        "old-owner",
+				"EXTERNAL_DNS_TO_OWNER":                                          "new-owner",
 				"EXTERNAL_DNS_REGISTRY":                                          "noop",
 				"EXTERNAL_DNS_TXT_OWNER_ID":                                      "owner-1",
 				"EXTERNAL_DNS_TXT_PREFIX":                                        "associated-txt-record",
 				"EXTERNAL_DNS_TXT_CACHE_INTERVAL":                                "12h",
 				"EXTERNAL_DNS_TXT_NEW_FORMAT_ONLY":                               "1",
+				"EXTERNAL_DNS_TXT_MIGRATE":                                       "1",
+				"EXTERNAL_DNS_AUDIT":                                             "1",
+				"EXTERNAL_DNS_AUDIT_OUTPUT":                                      "json",
+				"EXTERNAL_DNS_AUDIT_KNOWN_OWNER":                                 "owner-2\nowner-3",
 				"EXTERNAL_DNS_INTERVAL":                                          "10m",
 				"EXTERNAL_DNS_MIN_EVENT_SYNC_INTERVAL":                           "50s",
 				"EXTERNAL_DNS_ONCE":                                              "1",
 				"EXTERNAL_DNS_DRY_RUN":                                           "1",
+				"EXTERNAL_DNS_PLAN_EXPORT_FILE":                                  "/tmp/plan.yaml",
+				"EXTERNAL_DNS_PLAN_EXPORT_FORMAT":                                "yaml",
+				"EXTERNAL_DNS_PLAN_OFFLINE":                                      "1",
+				"EXTERNAL_DNS_PLAN_MANIFEST":                                     "/manifests/app.yaml\n/manifests/base",
+				"EXTERNAL_DNS_PLAN_RECORDS":                                      "/tmp/records.json",
 				"EXTERNAL_DNS_EVENTS":                                            "1",
+				"EXTERNAL_DNS_EMIT_EVENTS":                                       "1",
+				"EXTERNAL_DNS_ENABLE_LEADER_ELECTION":                            "1",
+				"EXTERNAL_DNS_LEADER_ELECTION_LEASE_NAME":                        "external-dns-test",
+				"EXTERNAL_DNS_LEADER_ELECTION_NAMESPACE":                         "kube-system",
+				"EXTERNAL_DNS_LEADER_ELECTION_LEASE_DURATION":                    "30s",
+				"EXTERNAL_DNS_LEADER_ELECTION_RENEW_DEADLINE":                    "20s",
+				"EXTERNAL_DNS_LEADER_ELECTION_RETRY_PERIOD":                      "5s",
 				"EXTERNAL_DNS_LOG_FORMAT":                                        "json",
 				"EXTERNAL_DNS_METRICS_ADDRESS":                                   "127.0.0.1:9099",
+				"EXTERNAL_DNS_HEALTH_CHECK_MAX_SYNC_INTERVALS":                   "5",
+				"EXTERNAL_DNS_HEALTH_CHECK_MAX_SOFT_ERRORS":                      "3",
 				"EXTERNAL_DNS_LOG_LEVEL":                                         "debug",
 				"EXTERNAL_DNS_CONNECTOR_SOURCE_SERVER":                           "localhost:8081",
 				"EXTERNAL_DNS_EXOSCALE_APIENV":                                   "api1",
@@ -494,6 +645,11 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_EXOSCALE_APISECRET":                                "2",
 				"EXTERNAL_DNS_CRD_SOURCE_APIVERSION":                             "test.k8s.io/v1alpha1",
 				"EXTERNAL_DNS_CRD_SOURCE_KIND":                                   "Endpoint",
+				"EXTERNAL_DNS_UNSTRUCTURED_SOURCE_RESOURCE":                      "widgets.v1.example.com\ngadgets.v1.example.com",
+				"EXTERNAL_DNS_UNSTRUCTURED_SOURCE_HOSTNAME_JSONPATH":             "{.spec.hostnames[*]}",
+				"EXTERNAL_DNS_UNSTRUCTURED_SOURCE_TARGET_JSONPATH":               "{.status.addresses[*].value}",
+				"EXTERNAL_DNS_UNSTRUCTURED_SOURCE_TTL_JSONPATH":                  "{.spec.ttl}",
+				"EXTERNAL_DNS_UNSTRUCTURED_SOURCE_RECORD_TYPE_JSONPATH":          "TXT",
 				"EXTERNAL_DNS_NS1_ENDPOINT":                                      "https://api.example.com/v1",
 				"EXTERNAL_DNS_NS1_IGNORESSL":                                     "1",
 				"EXTERNAL_DNS_TRANSIP_ACCOUNT":                                   "transip",
@@ -19,10 +19,15 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/labels"
 
+	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/rfc2317"
+	"sigs.k8s.io/external-dns/plan"
 )
 
 // ValidateConfig performs validation on the Config object
@@ -49,6 +54,86 @@ func ValidateConfig(cfg *externaldns.Config) error {
 	if err != nil {
 		return errors.New("--label-filter does not specify a valid label selector")
 	}
+
+	if cfg.HealthCheckMaxSyncIntervals < 0 {
+		return errors.New("--health-check-max-sync-intervals cannot be negative")
+	}
+	if cfg.HealthCheckMaxSoftErrors < 0 {
+		return errors.New("--health-check-max-soft-errors cannot be negative")
+	}
+
+	if cfg.EnableLeaderElection {
+		if err := validateConfigForLeaderElection(cfg); err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.ReverseZones) > 0 {
+		if err := validateConfigForReverseZones(cfg); err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.Backends) > 0 {
+		if err := validateConfigForBackends(cfg); err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.ConflictNamespaceAllowList) > 0 {
+		if err := validateConfigForConflictNamespaceAllowList(cfg); err != nil {
+			return err
+		}
+	}
+
+	if err := validateConfigForDeletionGuard(cfg); err != nil {
+		return err
+	}
+
+	if cfg.FlapDampingMaxChanges < 0 {
+		return errors.New("--flap-damping-max-changes cannot be negative")
+	}
+	if cfg.FlapDampingMaxChanges > 0 && (cfg.FlapDampingWindow <= 0 || cfg.FlapDampingMaxBackoff < cfg.FlapDampingWindow) {
+		return errors.New("--flap-damping-window must be positive and at most --flap-damping-max-backoff")
+	}
+
+	for _, value := range cfg.ChangeWindows {
+		w, err := externaldns.ParseChangeWindow(value)
+		if err != nil {
+			return err
+		}
+		if _, err := plan.ParseSchedule(w.Schedule); err != nil {
+			return fmt.Errorf("invalid change window %q: %w", value, err)
+		}
+	}
+
+	if cfg.FromOwner != "" || cfg.ToOwner != "" {
+		if err := validateConfigForOwnershipHandover(cfg); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Registry == "configmap" && cfg.ConfigMapRegistryShards < 1 {
+		return errors.New("--configmap-registry-shards must be positive")
+	}
+
+	if cfg.TXTMigrate {
+		if err := validateConfigForTXTMigration(cfg); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Audit {
+		if err := validateConfigForAudit(cfg); err != nil {
+			return err
+		}
+	}
+
+	if cfg.PlanOffline {
+		if err := validateConfigForPlanOffline(cfg); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -118,3 +203,187 @@ func validateConfigForRfc2136(cfg *externaldns.Config) error {
 	}
 	return nil
 }
+
+func validateConfigForLeaderElection(cfg *externaldns.Config) error {
+	if cfg.LeaderElectionLeaseName == "" {
+		return errors.New("--leader-election-lease-name must be set when leader election is enabled")
+	}
+	if cfg.LeaderElectionNamespace == "" {
+		return errors.New("--leader-election-namespace must be set when leader election is enabled")
+	}
+	if cfg.LeaderElectionRetryPeriod <= 0 {
+		return errors.New("--leader-election-retry-period must be greater than zero")
+	}
+	if cfg.LeaderElectionRenewDeadline <= cfg.LeaderElectionRetryPeriod {
+		return errors.New("--leader-election-renew-deadline must be greater than --leader-election-retry-period")
+	}
+	if cfg.LeaderElectionLeaseDuration <= cfg.LeaderElectionRenewDeadline {
+		return errors.New("--leader-election-lease-duration must be greater than --leader-election-renew-deadline")
+	}
+	return nil
+}
+
+func validateConfigForReverseZones(cfg *externaldns.Config) error {
+	for _, cidr := range cfg.ReverseZones {
+		if _, err := rfc2317.CidrToInAddr(cidr); err != nil {
+			return fmt.Errorf("invalid --reverse-zones network %q: %w", cidr, err)
+		}
+	}
+	if !slices.Contains(cfg.ManagedDNSRecordTypes, endpoint.RecordTypePTR) {
+		return errors.New("--managed-record-types must include PTR when --reverse-zones is set")
+	}
+	if cfg.RFC2136CreatePTR {
+		return errors.New("--rfc2136-create-ptr and --reverse-zones are mutually exclusive")
+	}
+	// the PTR records of other providers are not read back, so that they would be created again by every synchronization
+	if !slices.Contains(reverseZoneProviders, cfg.Provider) {
+		return fmt.Errorf("--reverse-zones is not supported by provider %s", cfg.Provider)
+	}
+	for _, value := range cfg.Backends {
+		b, err := externaldns.ParseBackend(value)
+		if err != nil {
+			// reported by the validation of the backends
+			continue
+		}
+		if c := b.Config(cfg); !slices.Contains(reverseZoneProviders, c.Provider) {
+			return fmt.Errorf("--reverse-zones is not supported by provider %s of backend %q", c.Provider, b.Name)
+		}
+	}
+	return nil
+}
+
+// reverseZoneProviders are the providers which manage PTR records.
+var reverseZoneProviders = []string{"aws", "azure", "azure-dns", "google", "inmemory", "rfc2136", "webhook"}
+
+func validateConfigForDeletionGuard(cfg *externaldns.Config) error {
+	if cfg.DeletionGuardMaxDeletions < 0 {
+		return errors.New("--deletion-guard-max-deletions cannot be negative")
+	}
+	if cfg.DeletionGuardMaxPercent < 0 || cfg.DeletionGuardMaxPercent > 100 {
+		return errors.New("--deletion-guard-max-percent must be between 0 and 100")
+	}
+	if cfg.DeletionGuardConfirmations < 0 {
+		return errors.New("--deletion-guard-confirmations cannot be negative")
+	}
+	if cfg.DeletionGracePeriod < 0 {
+		return errors.New("--deletion-grace-period cannot be negative")
+	}
+	if cfg.DeletionGracePeriod > 0 && !slices.Contains([]string{"txt", "dynamodb", "configmap"}, cfg.Registry) {
+		return fmt.Errorf("--deletion-grace-period is not supported with the %s registry, it requires a registry storing the pending deletions: txt, dynamodb or configmap", cfg.Registry)
+	}
+	if cfg.DeletionGuardConfigMap != "" {
+		namespace, name, ok := strings.Cut(cfg.DeletionGuardConfigMap, "/")
+		if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("invalid --deletion-guard-configmap %s: expected namespace/name", cfg.DeletionGuardConfigMap)
+		}
+	}
+	return nil
+}
+
+func validateConfigForConflictNamespaceAllowList(cfg *externaldns.Config) error {
+	for domain, namespaces := range cfg.ConflictNamespaceAllowList {
+		if strings.Trim(domain, ". ") == "" {
+			return fmt.Errorf("invalid --conflict-namespace-allowlist %s=%s: domain is required", domain, namespaces)
+		}
+		for _, namespace := range strings.Split(namespaces, ",") {
+			if strings.TrimSpace(namespace) == "" {
+				return fmt.Errorf("invalid --conflict-namespace-allowlist %s=%s: expected comma separated namespaces", domain, namespaces)
+			}
+		}
+	}
+	return nil
+}
+
+func validateConfigForOwnershipHandover(cfg *externaldns.Config) error {
+	if cfg.FromOwner == "" || cfg.ToOwner == "" {
+		return errors.New("--from-owner and --to-owner must be used together")
+	}
+	if cfg.FromOwner == cfg.ToOwner {
+		return errors.New("--from-owner and --to-owner must be different")
+	}
+	if cfg.Registry == "noop" {
+		return errors.New("--from-owner is not supported with the noop registry, which does not track ownership")
+	}
+	return nil
+}
+
+func validateConfigForTXTMigration(cfg *externaldns.Config) error {
+	if cfg.Registry != "txt" {
+		return errors.New("--txt-migrate is only supported with the txt registry")
+	}
+	if !cfg.Once {
+		return errors.New("--txt-migrate requires --once")
+	}
+	if cfg.FromOwner != "" {
+		return errors.New("--txt-migrate and --from-owner are mutually exclusive")
+	}
+	return nil
+}
+
+func validateConfigForAudit(cfg *externaldns.Config) error {
+	if cfg.Registry == "noop" {
+		return errors.New("--audit is not supported with the noop registry, which does not track ownership")
+	}
+	if cfg.TXTMigrate || cfg.FromOwner != "" {
+		return errors.New("--audit is read-only and cannot be combined with --txt-migrate or --from-owner")
+	}
+	return nil
+}
+
+// offlinePlanSources are the sources which read their objects through the clients served from manifests.
+var offlinePlanSources = []string{
+	"service", "ingress", "node", "pod", "fake",
+	"gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute",
+	"istio-gateway", "istio-virtualservice", "openshift-route",
+}
+
+func validateConfigForPlanOffline(cfg *externaldns.Config) error {
+	if len(cfg.PlanManifests) == 0 || cfg.PlanRecords == "" {
+		return errors.New("--plan-offline requires --plan-manifest and --plan-records")
+	}
+	if cfg.Registry != "txt" && cfg.Registry != "noop" {
+		return fmt.Errorf("--plan-offline is not supported with the %s registry, only with the txt and noop registries", cfg.Registry)
+	}
+	if len(cfg.Backends) > 0 {
+		return errors.New("--plan-offline is not supported with --backend")
+	}
+	if cfg.Audit || cfg.TXTMigrate || cfg.FromOwner != "" {
+		return errors.New("--plan-offline cannot be combined with --audit, --txt-migrate or --from-owner")
+	}
+	for _, name := range cfg.Sources {
+		if !slices.Contains(offlinePlanSources, name) {
+			return fmt.Errorf("--plan-offline is not supported with the %s source", name)
+		}
+	}
+	return nil
+}
+
+func validateConfigForBackends(cfg *externaldns.Config) error {
+	if cfg.WebhookServer {
+		return errors.New("--backend is not supported with --webhook-server")
+	}
+	// backends of the same provider would delete each other's records if they had the same owner
+	owners := map[string]string{cfg.Provider + "/" + cfg.TXTOwnerID: externaldns.DefaultBackendName}
+	names := map[string]bool{}
+	for _, value := range cfg.Backends {
+		b, err := externaldns.ParseBackend(value)
+		if err != nil {
+			return err
+		}
+		if names[b.Name] {
+			return fmt.Errorf("duplicate backend name %q", b.Name)
+		}
+		names[b.Name] = true
+
+		c := b.Config(cfg)
+		if other, ok := owners[c.Provider+"/"+c.TXTOwnerID]; ok {
+			return fmt.Errorf("backends %q and %q of provider %s must have distinct txt-owner-id", other, b.Name, c.Provider)
+		}
+		owners[c.Provider+"/"+c.TXTOwnerID] = b.Name
+
+		if err := validateConfigForProvider(c); err != nil {
+			return fmt.Errorf("backend %q: %w", b.Name, err)
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 
@@ -72,6 +73,456 @@ func TestValidateFlags(t *testing.T) {
 	cfg = newValidConfig(t)
 	cfg.LabelFilter = "#invalid-selector"
 	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.HealthCheckMaxSyncIntervals = -1
+	require.Error(t, ValidateConfig(cfg))
+
+	cfg = newValidConfig(t)
+	cfg.HealthCheckMaxSoftErrors = -1
+	require.Error(t, ValidateConfig(cfg))
+}
+
+func TestValidateLeaderElectionConfig(t *testing.T) {
+	for _, tt := range []struct {
+		title   string
+		modify  func(cfg *externaldns.Config)
+		wantErr bool
+	}{
+		{
+			title:  "valid timings",
+			modify: func(cfg *externaldns.Config) {},
+		},
+		{
+			title:   "empty lease name",
+			modify:  func(cfg *externaldns.Config) { cfg.LeaderElectionLeaseName = "" },
+			wantErr: true,
+		},
+		{
+			title:   "empty namespace",
+			modify:  func(cfg *externaldns.Config) { cfg.LeaderElectionNamespace = "" },
+			wantErr: true,
+		},
+		{
+			title:   "zero retry period",
+			modify:  func(cfg *externaldns.Config) { cfg.LeaderElectionRetryPeriod = 0 },
+			wantErr: true,
+		},
+		{
+			title:   "renew deadline not greater than retry period",
+			modify:  func(cfg *externaldns.Config) { cfg.LeaderElectionRenewDeadline = 2 * time.Second },
+			wantErr: true,
+		},
+		{
+			title:   "lease duration not greater than renew deadline",
+			modify:  func(cfg *externaldns.Config) { cfg.LeaderElectionLeaseDuration = 10 * time.Second },
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.EnableLeaderElection = true
+			cfg.LeaderElectionLeaseName = "external-dns"
+			cfg.LeaderElectionNamespace = "default"
+			cfg.LeaderElectionLeaseDuration = 15 * time.Second
+			cfg.LeaderElectionRenewDeadline = 10 * time.Second
+			cfg.LeaderElectionRetryPeriod = 2 * time.Second
+			tt.modify(cfg)
+
+			if tt.wantErr {
+				assert.Error(t, ValidateConfig(cfg))
+			} else {
+				assert.NoError(t, ValidateConfig(cfg))
+			}
+		})
+	}
+}
+
+func TestValidateReverseZonesConfig(t *testing.T) {
+	for _, tt := range []struct {
+		title   string
+		modify  func(cfg *externaldns.Config)
+		wantErr bool
+	}{
+		{
+			title:  "valid reverse zones",
+			modify: func(cfg *externaldns.Config) {},
+		},
+		{
+			title:   "invalid network",
+			modify:  func(cfg *externaldns.Config) { cfg.ReverseZones = []string{"10.0.0.0/12"} },
+			wantErr: true,
+		},
+		{
+			title:   "PTR records not managed",
+			modify:  func(cfg *externaldns.Config) { cfg.ManagedDNSRecordTypes = []string{"A", "AAAA"} },
+			wantErr: true,
+		},
+		{
+			title:   "RFC2136 PTR management enabled",
+			modify:  func(cfg *externaldns.Config) { cfg.RFC2136CreatePTR = true },
+			wantErr: true,
+		},
+		{
+			title:  "provider with PTR records",
+			modify: func(cfg *externaldns.Config) { cfg.Provider = "google" },
+		},
+		{
+			title:   "provider without PTR records",
+			modify:  func(cfg *externaldns.Config) { cfg.Provider = "cloudflare" },
+			wantErr: true,
+		},
+		{
+			title:  "backend with PTR records",
+			modify: func(cfg *externaldns.Config) { cfg.Backends = []string{"name=private,provider=webhook"} },
+		},
+		{
+			title:   "backend without PTR records",
+			modify:  func(cfg *externaldns.Config) { cfg.Backends = []string{"name=public,provider=cloudflare"} },
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.Provider = "aws"
+			cfg.ReverseZones = []string{"10.0.0.0/24", "10.1.0.0/26", "2001:db8::/32"}
+			cfg.ManagedDNSRecordTypes = []string{"A", "AAAA", "PTR"}
+			tt.modify(cfg)
+
+			if tt.wantErr {
+				assert.Error(t, ValidateConfig(cfg))
+			} else {
+				assert.NoError(t, ValidateConfig(cfg))
+			}
+		})
+	}
+}
+
+func TestValidateConflictNamespaceAllowListConfig(t *testing.T) {
+	for _, tt := range []struct {
+		title     string
+		allowList map[string]string
+		wantErr   bool
+	}{
+		{
+			title:     "valid allow list",
+			allowList: map[string]string{"example.com": "team-a,team-b", "team.example.com": "team-c"},
+		},
+		{
+			title:     "missing domain",
+			allowList: map[string]string{"": "team-a"},
+			wantErr:   true,
+		},
+		{
+			title:     "missing namespace",
+			allowList: map[string]string{"example.com": "team-a,"},
+			wantErr:   true,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.ConflictNamespaceAllowList = tt.allowList
+
+			if tt.wantErr {
+				assert.Error(t, ValidateConfig(cfg))
+			} else {
+				assert.NoError(t, ValidateConfig(cfg))
+			}
+		})
+	}
+}
+
+func TestValidateDeletionGuardConfig(t *testing.T) {
+	for _, tt := range []struct {
+		title   string
+		modify  func(cfg *externaldns.Config)
+		wantErr string
+	}{
+		{title: "disabled", modify: func(*externaldns.Config) {}},
+		{title: "valid guard", modify: func(cfg *externaldns.Config) {
+			cfg.DeletionGuardMaxDeletions = 50
+			cfg.DeletionGuardMaxPercent = 20
+			cfg.DeletionGuardConfirmations = 3
+			cfg.DeletionGuardConfigMap = "external-dns/deletion-approval"
+		}},
+		{title: "negative maximum", modify: func(cfg *externaldns.Config) { cfg.DeletionGuardMaxDeletions = -1 }, wantErr: "--deletion-guard-max-deletions"},
+		{title: "percentage above 100", modify: func(cfg *externaldns.Config) { cfg.DeletionGuardMaxPercent = 101 }, wantErr: "between 0 and 100"},
+		{title: "negative confirmations", modify: func(cfg *externaldns.Config) { cfg.DeletionGuardConfirmations = -1 }, wantErr: "--deletion-guard-confirmations"},
+		{title: "configmap without namespace", modify: func(cfg *externaldns.Config) { cfg.DeletionGuardConfigMap = "deletion-approval" }, wantErr: "expected namespace/name"},
+		{title: "configmap with empty name", modify: func(cfg *externaldns.Config) { cfg.DeletionGuardConfigMap = "external-dns/" }, wantErr: "expected namespace/name"},
+		{title: "grace period", modify: func(cfg *externaldns.Config) { cfg.DeletionGracePeriod = time.Minute; cfg.Registry = "txt" }},
+		{title: "negative grace period", modify: func(cfg *externaldns.Config) { cfg.DeletionGracePeriod = -time.Minute }, wantErr: "--deletion-grace-period cannot be negative"},
+		{title: "grace period without labels", modify: func(cfg *externaldns.Config) { cfg.DeletionGracePeriod = time.Minute; cfg.Registry = "noop" }, wantErr: "noop registry"},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			tt.modify(cfg)
+
+			err := ValidateConfig(cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateChangeWindowConfig(t *testing.T) {
+	for _, tt := range []struct {
+		title   string
+		windows []string
+		wantErr string
+	}{
+		{title: "valid windows", windows: []string{"domain-filter=example.com;schedule=0 2 * * 6;duration=2h", "schedule=0 22 * * 1,3;duration=1h;outside=none"}},
+		{title: "invalid window", windows: []string{"schedule=0 2 * * 6"}, wantErr: "duration"},
+		{title: "invalid schedule", windows: []string{"schedule=0 25 * * 6;duration=2h"}, wantErr: "out of range"},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.ChangeWindows = tt.windows
+
+			err := ValidateConfig(cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateFlapDampingConfig(t *testing.T) {
+	for _, tt := range []struct {
+		title      string
+		maxChanges int
+		window     time.Duration
+		maxBackoff time.Duration
+		wantErr    string
+	}{
+		{title: "disabled", window: 0, maxBackoff: 0},
+		{title: "valid damping", maxChanges: 3, window: 5 * time.Minute, maxBackoff: time.Hour},
+		{title: "negative changes", maxChanges: -1, window: 5 * time.Minute, maxBackoff: time.Hour, wantErr: "cannot be negative"},
+		{title: "without window", maxChanges: 3, maxBackoff: time.Hour, wantErr: "--flap-damping-window must be positive"},
+		{title: "backoff below window", maxChanges: 3, window: time.Hour, maxBackoff: time.Minute, wantErr: "at most --flap-damping-max-backoff"},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.FlapDampingMaxChanges = tt.maxChanges
+			cfg.FlapDampingWindow = tt.window
+			cfg.FlapDampingMaxBackoff = tt.maxBackoff
+
+			err := ValidateConfig(cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateOwnershipHandoverConfig(t *testing.T) {
+	for _, tt := range []struct {
+		title     string
+		fromOwner string
+		toOwner   string
+		registry  string
+		wantErr   string
+	}{
+		{title: "valid handover", fromOwner: "old", toOwner: "new", registry: "txt"},
+		{title: "missing to-owner", fromOwner: "old", registry: "txt", wantErr: "must be used together"},
+		{title: "missing from-owner", toOwner: "new", registry: "txt", wantErr: "must be used together"},
+		{title: "same owner", fromOwner: "old", toOwner: "old", registry: "txt", wantErr: "must be different"},
+		{title: "noop registry", fromOwner: "old", toOwner: "new", registry: "noop", wantErr: "noop registry"},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.FromOwner = tt.fromOwner
+			cfg.ToOwner = tt.toOwner
+			cfg.Registry = tt.registry
+
+			err := ValidateConfig(cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateConfigMapRegistryConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Registry = "configmap"
+	cfg.ConfigMapRegistryShards = 4
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.ConfigMapRegistryShards = 0
+	assert.ErrorContains(t, ValidateConfig(cfg), "--configmap-registry-shards must be positive")
+}
+
+func TestValidateTXTMigrationConfig(t *testing.T) {
+	for _, tt := range []struct {
+		title     string
+		registry  string
+		once      bool
+		fromOwner string
+		wantErr   string
+	}{
+		{title: "valid migration", registry: "txt", once: true},
+		{title: "without once", registry: "txt", wantErr: "requires --once"},
+		{title: "dynamodb registry", registry: "dynamodb", once: true, wantErr: "only supported with the txt registry"},
+		{title: "with handover", registry: "txt", once: true, fromOwner: "old", wantErr: "mutually exclusive"},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.TXTMigrate = true
+			cfg.Registry = tt.registry
+			cfg.Once = tt.once
+			if tt.fromOwner != "" {
+				cfg.FromOwner = tt.fromOwner
+				cfg.ToOwner = "new"
+			}
+
+			err := ValidateConfig(cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateAuditConfig(t *testing.T) {
+	for _, tt := range []struct {
+		title   string
+		modify  func(cfg *externaldns.Config)
+		wantErr string
+	}{
+		{title: "valid audit", modify: func(*externaldns.Config) {}},
+		{title: "noop registry", modify: func(cfg *externaldns.Config) { cfg.Registry = "noop" }, wantErr: "noop registry"},
+		{
+			title: "with migration",
+			modify: func(cfg *externaldns.Config) {
+				cfg.Registry = "txt"
+				cfg.TXTMigrate = true
+				cfg.Once = true
+			},
+			wantErr: "read-only",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.Audit = true
+			tt.modify(cfg)
+
+			err := ValidateConfig(cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatePlanOfflineConfig(t *testing.T) {
+	for _, tt := range []struct {
+		title   string
+		modify  func(cfg *externaldns.Config)
+		wantErr string
+	}{
+		{title: "valid offline plan", modify: func(*externaldns.Config) {}},
+		{title: "noop registry", modify: func(cfg *externaldns.Config) { cfg.Registry = "noop" }},
+		{title: "without records", modify: func(cfg *externaldns.Config) { cfg.PlanRecords = "" }, wantErr: "requires --plan-manifest and --plan-records"},
+		{title: "without manifests", modify: func(cfg *externaldns.Config) { cfg.PlanManifests = nil }, wantErr: "requires --plan-manifest and --plan-records"},
+		{title: "dynamodb registry", modify: func(cfg *externaldns.Config) { cfg.Registry = "dynamodb" }, wantErr: "dynamodb registry"},
+		{title: "unsupported source", modify: func(cfg *externaldns.Config) { cfg.Sources = []string{"ingress", "crd"} }, wantErr: "crd source"},
+		{title: "with backends", modify: func(cfg *externaldns.Config) { cfg.Backends = []string{"name=private,provider=aws"} }, wantErr: "--backend"},
+		{title: "with audit", modify: func(cfg *externaldns.Config) { cfg.Audit = true }, wantErr: "cannot be combined"},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.Registry = "txt"
+			cfg.Sources = []string{"service", "ingress"}
+			cfg.PlanOffline = true
+			cfg.PlanManifests = []string{"manifests.yaml"}
+			cfg.PlanRecords = "records.json"
+			tt.modify(cfg)
+
+			err := ValidateConfig(cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateBackendsConfig(t *testing.T) {
+	for _, tt := range []struct {
+		title    string
+		backends []string
+		modify   func(cfg *externaldns.Config)
+		wantErr  string
+	}{
+		{
+			title:    "valid backends",
+			backends: []string{"name=private,provider=aws,access=private", "name=internal,provider=rfc2136,domain-filter=internal.example.com"},
+		},
+		{
+			title:    "invalid backend",
+			backends: []string{"name=private"},
+			wantErr:  "unknown provider",
+		},
+		{
+			title:    "duplicate name",
+			backends: []string{"name=private,provider=aws", "name=private,provider=google"},
+			wantErr:  "duplicate backend name",
+		},
+		{
+			title:    "same provider and owner as default backend",
+			backends: []string{"name=private,provider=cloudflare"},
+			wantErr:  "distinct txt-owner-id",
+		},
+		{
+			title:    "same provider with distinct owner",
+			backends: []string{"name=private,provider=cloudflare,txt-owner-id=private"},
+		},
+		{
+			title:    "invalid provider configuration",
+			backends: []string{"name=private,provider=azure"},
+			modify:   func(cfg *externaldns.Config) { cfg.AzureConfigFile = "" },
+			wantErr:  `backend "private": no Azure config file specified`,
+		},
+		{
+			title:    "webhook server",
+			backends: []string{"name=private,provider=aws"},
+			modify:   func(cfg *externaldns.Config) { cfg.WebhookServer = true },
+			wantErr:  "not supported with --webhook-server",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.Provider = "cloudflare"
+			cfg.TXTOwnerID = "default"
+			cfg.RFC2136BatchChangeSize = 50
+			cfg.Backends = tt.backends
+			if tt.modify != nil {
+				tt.modify(cfg)
+			}
+
+			err := ValidateConfig(cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
 }
 
 func newValidConfig(t *testing.T) *externaldns.Config {
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// Component is the source component of the emitted events.
+	Component = "external-dns"
+
+	ReasonRecordCreated    = "RecordCreated"
+	ReasonRecordUpdated    = "RecordUpdated"
+	ReasonRecordDeleted    = "RecordDeleted"
+	ReasonRecordConflicted = "RecordConflicted"
+	ReasonRecordRejected   = "RecordRejected"
+	ReasonProviderRejected = "ProviderRejected"
+)
+
+// Recorder emits Kubernetes Events on the objects endpoints were generated from.
+// It is shared by the controller and the sources.
+type Recorder interface {
+	// Eventf emits an event on the object identified by resource, the value of the
+	// endpoint.ResourceLabelKey label of an endpoint, e.g. "ingress/default/app".
+	Eventf(resource, eventType, reason, messageFmt string, args ...interface{})
+}
+
+// kind is the group version, kind and resource of the objects a resource label prefix refers to.
+type kind struct {
+	apiVersion string
+	kind       string
+	resource   string
+}
+
+// kinds maps the prefixes of endpoint.ResourceLabelKey values set by the sources to their object kind.
+var kinds = map[string]kind{
+	"service":            {"v1", "Service", "services"},
+	"node":               {"v1", "Node", "nodes"},
+	"pod":                {"v1", "Pod", "pods"},
+	"ingress":            {"networking.k8s.io/v1", "Ingress", "ingresses"},
+	"crd":                {"externaldns.k8s.io/v1alpha1", "DNSEndpoint", "dnsendpoints"},
+	"httproute":          {"gateway.networking.k8s.io/v1", "HTTPRoute", "httproutes"},
+	"grpcroute":          {"gateway.networking.k8s.io/v1", "GRPCRoute", "grpcroutes"},
+	"tlsroute":           {"gateway.networking.k8s.io/v1alpha2", "TLSRoute", "tlsroutes"},
+	"tcproute":           {"gateway.networking.k8s.io/v1alpha2", "TCPRoute", "tcproutes"},
+	"udproute":           {"gateway.networking.k8s.io/v1alpha2", "UDPRoute", "udproutes"},
+	"gateway":            {"networking.istio.io/v1alpha3", "Gateway", "gateways"},
+	"virtualservice":     {"networking.istio.io/v1alpha3", "VirtualService", "virtualservices"},
+	"route":              {"route.openshift.io/v1", "Route", "routes"},
+	"httpproxy":          {"projectcontour.io/v1", "HTTPProxy", "httpproxies"},
+	"ingressroute":       {"traefik.io/v1alpha1", "IngressRoute", "ingressroutes"},
+	"ingressroutetcp":    {"traefik.io/v1alpha1", "IngressRouteTCP", "ingressroutetcps"},
+	"ingressrouteudp":    {"traefik.io/v1alpha1", "IngressRouteUDP", "ingressrouteudps"},
+	"host":               {"getambassador.io/v2", "Host", "hosts"},
+	"tcpingress":         {"configuration.konghq.com/v1beta1", "TCPIngress", "tcpingresses"},
+	"routegroup":         {"zalando.org/v1", "RouteGroup", "routegroups"},
+	"f5-virtualserver":   {"cis.f5.com/v1", "VirtualServer", "virtualservers"},
+	"f5-transportserver": {"cis.f5.com/v1", "TransportServer", "transportservers"},
+	"proxy":              {"gloo.solo.io/v1", "Proxy", "proxies"},
+}
+
+// ObjectReference returns a reference to the object identified by a value of the
+// endpoint.ResourceLabelKey label, either "kind/namespace/name" or "kind/name" for
+// cluster scoped objects. It returns false if the resource cannot be mapped to an object.
+func ObjectReference(resource string) (*corev1.ObjectReference, bool) {
+	ref, _, ok := objectReference(resource)
+	return ref, ok
+}
+
+// objectReference returns the reference of ObjectReference and the resource of the object.
+func objectReference(resource string) (*corev1.ObjectReference, schema.GroupVersionResource, bool) {
+	parts := strings.Split(resource, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, schema.GroupVersionResource{}, false
+	}
+	k, ok := kinds[strings.ToLower(parts[0])]
+	if !ok {
+		return nil, schema.GroupVersionResource{}, false
+	}
+
+	ref := &corev1.ObjectReference{
+		APIVersion: k.apiVersion,
+		Kind:       k.kind,
+		Name:       parts[len(parts)-1],
+	}
+	if len(parts) == 3 {
+		ref.Namespace = parts[1]
+	}
+	if ref.Name == "" {
+		return nil, schema.GroupVersionResource{}, false
+	}
+	gv, err := schema.ParseGroupVersion(k.apiVersion)
+	if err != nil {
+		return nil, schema.GroupVersionResource{}, false
+	}
+	return ref, gv.WithResource(k.resource), true
+}
+
+type recorder struct {
+	ctx           context.Context
+	recorder      record.EventRecorder
+	dynamicClient dynamic.Interface
+}
+
+// NewRecorder returns a Recorder which sends events to the Kubernetes API until ctx is done.
+// The objects are looked up with the dynamic client, so that the events refer to their UID,
+// which kubectl describe selects the events of an object by.
+func NewRecorder(ctx context.Context, client kubernetes.Interface, dynamicClient dynamic.Interface) Recorder {
+	broadcaster := record.NewBroadcaster(record.WithContext(ctx))
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	go func() {
+		<-ctx.Done()
+		broadcaster.Shutdown()
+	}()
+	return &recorder{
+		ctx:           ctx,
+		recorder:      broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: Component}),
+		dynamicClient: dynamicClient,
+	}
+}
+
+func (r *recorder) Eventf(resource, eventType, reason, messageFmt string, args ...interface{}) {
+	ref, gvr, ok := objectReference(resource)
+	if !ok {
+		log.Debugf("Not emitting %s event, unable to map resource %q to an object", reason, resource)
+		return
+	}
+	// an object which no longer exists, e.g. the object of a deleted record, gets an event without UID
+	obj, err := r.dynamicClient.Resource(gvr).Namespace(ref.Namespace).Get(r.ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Debugf("Unable to get the UID of %s: %v", resource, err)
+	} else {
+		ref.UID = obj.GetUID()
+	}
+	r.recorder.Eventf(ref, eventType, reason, messageFmt, args...)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestObjectReference(t *testing.T) {
+	for _, tt := range []struct {
+		resource string
+		want     *corev1.ObjectReference
+	}{
+		{
+			resource: "ingress/default/app",
+			want:     &corev1.ObjectReference{APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Namespace: "default", Name: "app"},
+		},
+		{
+			resource: "service/kube-system/dns",
+			want:     &corev1.ObjectReference{APIVersion: "v1", Kind: "Service", Namespace: "kube-system", Name: "dns"},
+		},
+		{
+			resource: "node/worker-1",
+			want:     &corev1.ObjectReference{APIVersion: "v1", Kind: "Node", Name: "worker-1"},
+		},
+		{
+			resource: "crd/default/records",
+			want:     &corev1.ObjectReference{APIVersion: "externaldns.k8s.io/v1alpha1", Kind: "DNSEndpoint", Namespace: "default", Name: "records"},
+		},
+		{
+			resource: "HTTPProxy/default/app",
+			want:     &corev1.ObjectReference{APIVersion: "projectcontour.io/v1", Kind: "HTTPProxy", Namespace: "default", Name: "app"},
+		},
+		{resource: ""},
+		{resource: "ingress"},
+		{resource: "ingress/default/"},
+		{resource: "unknown/default/app"},
+		{resource: "ingress/a/b/c"},
+	} {
+		t.Run(tt.resource, func(t *testing.T) {
+			ref, ok := ObjectReference(tt.resource)
+			assert.Equal(t, tt.want != nil, ok)
+			assert.Equal(t, tt.want, ref)
+		})
+	}
+}
+
+func TestRecorderEventf(t *testing.T) {
+	client := fake.NewClientset()
+	scheme := runtime.NewScheme()
+	require.NoError(t, networkingv1.AddToScheme(scheme))
+	dynamicClient := fakedynamic.NewSimpleDynamicClient(scheme, &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app", UID: "6d3c1a9e"},
+	})
+	recorder := NewRecorder(t.Context(), client, dynamicClient)
+
+	recorder.Eventf("ingress/default/app", corev1.EventTypeNormal, ReasonRecordCreated, "Created A record %s → %s", "app.example.com", "1.2.3.4")
+	recorder.Eventf("unknown/default/app", corev1.EventTypeNormal, ReasonRecordCreated, "ignored")
+	// the object of a deleted record no longer exists
+	recorder.Eventf("ingress/other/deleted", corev1.EventTypeNormal, ReasonRecordDeleted, "Deleted A record %s", "deleted.example.com")
+
+	var events *corev1.EventList
+	require.Eventually(t, func() bool {
+		var err error
+		events, err = client.CoreV1().Events("default").List(t.Context(), metav1.ListOptions{})
+		return err == nil && len(events.Items) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Len(t, events.Items, 1)
+	event := events.Items[0]
+	assert.Equal(t, "Ingress", event.InvolvedObject.Kind)
+	assert.Equal(t, "app", event.InvolvedObject.Name)
+	assert.Equal(t, types.UID("6d3c1a9e"), event.InvolvedObject.UID)
+	assert.Equal(t, ReasonRecordCreated, event.Reason)
+	assert.Equal(t, "Created A record app.example.com → 1.2.3.4", event.Message)
+	assert.Equal(t, Component, event.Source.Component)
+
+	require.Eventually(t, func() bool {
+		var err error
+		events, err = client.CoreV1().Events("other").List(t.Context(), metav1.ListOptions{})
+		return err == nil && len(events.Items) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "deleted", events.Items[0].InvolvedObject.Name)
+	assert.Empty(t, events.Items[0].InvolvedObject.UID)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// adopt takes over the unowned current records of a row which are desired by a candidate, if the DNS name is
+// in one of the AdoptDomains or a candidate opted in with the adopt label. The current records are replaced
+// by copies owned by the plan's owner and labeled as adopted, so that they are updated and the registry
+// writes their ownership.
+func (p *Plan) adopt(row *planTableRow) {
+	if p.OwnerID == "" {
+		return
+	}
+	domainFilter := endpoint.NewDomainFilter(p.AdoptDomains)
+
+	for _, records := range row.records {
+		current := records.current
+		if current == nil || len(records.candidates) == 0 || current.Labels[endpoint.OwnerLabelKey] != "" {
+			continue
+		}
+		if !adoptable(records.candidates, len(p.AdoptDomains) > 0 && domainFilter.Match(current.DNSName)) {
+			continue
+		}
+
+		adopted := current.DeepCopy()
+		if adopted.Labels == nil {
+			adopted.Labels = endpoint.NewLabels()
+		}
+		adopted.Labels[endpoint.OwnerLabelKey] = p.OwnerID
+		adopted.Labels[endpoint.AdoptedLabelKey] = "true"
+		log.Debugf("Adopting unowned record %v", current)
+
+		records.current = adopted
+		for i, c := range row.current {
+			if c == current {
+				row.current[i] = adopted
+			}
+		}
+	}
+}
+
+// adoptable returns true if unowned records desired by the candidates may be adopted.
+func adoptable(candidates []*endpoint.Endpoint, inAdoptDomain bool) bool {
+	if inAdoptDomain {
+		return true
+	}
+	for _, c := range candidates {
+		if c.Labels[endpoint.AdoptLabelKey] == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// isAdopted returns true if the current record was adopted by the plan.
+func isAdopted(current *endpoint.Endpoint) bool {
+	return current.Labels[endpoint.AdoptedLabelKey] == "true"
+}
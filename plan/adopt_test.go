@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestPlanAdoptDomains(t *testing.T) {
+	unowned := newResultEndpoint("app.example.com", endpoint.RecordTypeA, "", "", "1.1.1.1")
+	changed := newResultEndpoint("www.example.com", endpoint.RecordTypeA, "", "", "2.2.2.2")
+	foreign := newResultEndpoint("api.example.com", endpoint.RecordTypeA, "", "other", "3.3.3.3")
+	outside := newResultEndpoint("app.example.org", endpoint.RecordTypeA, "", "", "4.4.4.4")
+	undesired := newResultEndpoint("old.example.com", endpoint.RecordTypeA, "", "", "5.5.5.5")
+
+	p := &Plan{
+		Policies: []Policy{&SyncPolicy{}},
+		Current:  []*endpoint.Endpoint{unowned, changed, foreign, outside, undesired},
+		Desired: []*endpoint.Endpoint{
+			newResultEndpoint("app.example.com", endpoint.RecordTypeA, "ingress/default/app", "", "1.1.1.1"),
+			newResultEndpoint("www.example.com", endpoint.RecordTypeA, "ingress/default/www", "", "6.6.6.6"),
+			newResultEndpoint("api.example.com", endpoint.RecordTypeA, "ingress/default/api", "", "3.3.3.3"),
+			newResultEndpoint("app.example.org", endpoint.RecordTypeA, "ingress/default/app", "", "4.4.4.4"),
+		},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "owner",
+		AdoptDomains:   []string{"example.com"},
+	}
+	calculated := p.Calculate()
+	changes := calculated.Changes
+
+	// unowned records in the adopt domains are updated, even without changes, to write their ownership
+	require.Len(t, changes.UpdateOld, 2)
+	require.Len(t, changes.UpdateNew, 2)
+	assert.Empty(t, changes.Create)
+	assert.Empty(t, changes.Delete)
+	assert.ElementsMatch(t, []string{"app.example.com", "www.example.com"}, []string{changes.UpdateNew[0].DNSName, changes.UpdateNew[1].DNSName})
+	for i, old := range changes.UpdateOld {
+		assert.Equal(t, "owner", old.Labels[endpoint.OwnerLabelKey])
+		assert.Equal(t, "true", old.Labels[endpoint.AdoptedLabelKey])
+		assert.Equal(t, "owner", changes.UpdateNew[i].Labels[endpoint.OwnerLabelKey])
+		assert.NotContains(t, changes.UpdateNew[i].Labels, endpoint.AdoptedLabelKey)
+	}
+	assert.ElementsMatch(t, changes.UpdateOld, calculated.Adopted)
+
+	// the current records are not modified
+	assert.NotContains(t, unowned.Labels, endpoint.OwnerLabelKey)
+	assert.NotContains(t, unowned.Labels, endpoint.AdoptedLabelKey)
+}
+
+func TestPlanAdoptLabel(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		newResultEndpoint("app.example.com", endpoint.RecordTypeA, "", "", "1.1.1.1"),
+		newResultEndpoint("www.example.com", endpoint.RecordTypeA, "", "", "2.2.2.2"),
+	}
+	app := newResultEndpoint("app.example.com", endpoint.RecordTypeA, "ingress/default/app", "", "1.1.1.1").
+		WithLabel(endpoint.AdoptLabelKey, "true")
+	www := newResultEndpoint("www.example.com", endpoint.RecordTypeA, "ingress/default/www", "", "2.2.2.2")
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        []*endpoint.Endpoint{app, www},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "owner",
+	}
+	calculated := p.Calculate()
+
+	require.Len(t, calculated.Adopted, 1)
+	assert.Equal(t, "app.example.com", calculated.Adopted[0].DNSName)
+	require.Len(t, calculated.Changes.UpdateNew, 1)
+	assert.NotContains(t, calculated.Changes.UpdateNew[0].Labels, endpoint.AdoptLabelKey)
+
+	// records are not adopted without an owner ID
+	p.OwnerID = ""
+	assert.Empty(t, p.Calculate().Adopted)
+}
@@ -17,7 +17,12 @@ limitations under the License.
 package plan
 
 import (
+	"fmt"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -32,44 +37,59 @@ type ConflictResolver interface {
 	ResolveRecordTypes(key planKey, row *planTableRow) map[string]*domainEndpoints
 }
 
+// ConflictResolvers is a registry of available conflict resolution strategies.
+var ConflictResolvers = map[string]ConflictResolver{
+	"targets":  PerResource{},
+	"oldest":   OldestResource{},
+	"priority": PriorityResource{},
+	"refuse":   RefuseConflicts{},
+}
+
+// Conflict is a DNS name which several resources claim, and which was left alone because the
+// ConflictResolver refused to decide which of them acquires it.
+type Conflict struct {
+	DNSName       string
+	SetIdentifier string
+	// Resources claiming the DNS name
+	Resources []string
+}
+
+// conflictRefuser is implemented by conflict resolvers which leave records claimed by several
+// resources alone instead of resolving the conflict.
+type conflictRefuser interface {
+	// refuse returns the resources claiming the records of the candidates if the resolver refuses to resolve the conflict.
+	refuse(candidates []*endpoint.Endpoint) ([]string, bool)
+}
+
+// candidateRejecter is implemented by conflict resolvers which reject candidates regardless of other candidates.
+// Rejected candidates are not considered by the plan.
+type candidateRejecter interface {
+	// reject returns the reason if the candidate is rejected.
+	reject(candidate *endpoint.Endpoint) (string, bool)
+}
+
 // PerResource allows only one resource to own a given dns name
 type PerResource struct{}
 
 // ResolveCreate is invoked when dns name is not owned by any resource
 // ResolveCreate takes "minimal" (string comparison of Target) endpoint to acquire the DNS record
 func (s PerResource) ResolveCreate(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
-	var minE *endpoint.Endpoint
-	for _, ep := range candidates {
-		if minE == nil || s.less(ep, minE) {
-			minE = ep
-		}
-	}
-	return minE
+	return resolveCreate(candidates, s.less)
 }
 
 // ResolveUpdate is invoked when dns name is already owned by "current" endpoint
 // ResolveUpdate uses "current" record as base and updates it accordingly with new version of same resource
 // if it doesn't exist then pick min
 func (s PerResource) ResolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
-	currentResource := current.Labels[endpoint.ResourceLabelKey] // resource which has already acquired the DNS
-	// TODO: sort candidates only needed because we can still have two endpoints from same resource here. We sort for consistency
-	// TODO: remove once single endpoint can have multiple targets
-	sort.SliceStable(candidates, func(i, j int) bool {
-		return s.less(candidates[i], candidates[j])
-	})
-	for _, ep := range candidates {
-		if ep.Labels[endpoint.ResourceLabelKey] == currentResource {
-			return ep
-		}
-	}
-	return s.ResolveCreate(candidates)
+	return resolveUpdate(current, candidates, s.less, nil)
 }
 
 // ResolveRecordTypes attempts to detect and resolve record type conflicts in desired
 // endpoints for a domain. For eample if the there is more than 1 candidate and at lease one
 // of them is a CNAME. Per [RFC 1034 3.6.2] domains that contain a CNAME can not contain any
 // other record types. The default policy will prefer A and AAAA record types when a conflict is
-// detected (consistent with [endpoint.Targets.Less]).
+// detected (consistent with [endpoint.Targets.Less]), unless the only other record types are the
+// service bindings HTTPS and SVCB, which complement the address records rather than replace them.
 //
 // [RFC 1034 3.6.2]: https://datatracker.ietf.org/doc/html/rfc1034#autoid-15
 func (s PerResource) ResolveRecordTypes(key planKey, row *planTableRow) map[string]*domainEndpoints {
@@ -80,25 +100,30 @@ func (s PerResource) ResolveRecordTypes(key planKey, row *planTableRow) map[stri
 
 	cname := false
 	other := false
+	serviceBindingOnly := true
 	for _, c := range row.candidates {
 		if c.RecordType == endpoint.RecordTypeCNAME {
 			cname = true
 		} else {
 			other = true
-		}
-
-		if cname && other {
-			break
+			serviceBindingOnly = serviceBindingOnly && endpoint.IsServiceBindingRecordType(c.RecordType)
 		}
 	}
 
 	// conflict was found, remove candiates of non-preferred record types
 	if cname && other {
-		log.Infof("Domain %s contains conflicting record type candidates; discarding CNAME record", key.dnsName)
+		discard := func(recordType string) bool { return recordType == endpoint.RecordTypeCNAME }
+		if serviceBindingOnly {
+			// a CNAME alone still resolves, while HTTPS or SVCB records alone leave the domain without addresses
+			log.Infof("Domain %s contains conflicting record type candidates; discarding HTTPS and SVCB records", key.dnsName)
+			discard = endpoint.IsServiceBindingRecordType
+		} else {
+			log.Infof("Domain %s contains conflicting record type candidates; discarding CNAME record", key.dnsName)
+		}
 		records := map[string]*domainEndpoints{}
 		for recordType, recs := range row.records {
 			// policy is to prefer the non-CNAME record types when a conflict is found
-			if recordType == endpoint.RecordTypeCNAME {
+			if discard(recordType) {
 				// discard candidates of conflicting records
 				// keep currect so they can be deleted
 				records[recordType] = &domainEndpoints{
@@ -122,4 +147,215 @@ func (s PerResource) less(x, y *endpoint.Endpoint) bool {
 	return x.Targets.IsLess(y.Targets)
 }
 
-// TODO: with cross-resource/cross-cluster setup alternative variations of ConflictResolver can be used
+// OldestResource allows only one resource to own a given dns name, the one which was created first.
+// Resources without a creation timestamp are considered the newest, ties are resolved like PerResource.
+type OldestResource struct {
+	PerResource
+}
+
+// ResolveCreate takes the endpoint of the oldest resource to acquire the DNS record
+func (s OldestResource) ResolveCreate(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return resolveCreate(candidates, s.less)
+}
+
+// ResolveUpdate takes the endpoint of the oldest resource, which is stable as creation timestamps do not change,
+// so that an older resource claiming the DNS name takes it over.
+func (s OldestResource) ResolveUpdate(_ *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return resolveCreate(candidates, s.less)
+}
+
+func (s OldestResource) less(x, y *endpoint.Endpoint) bool {
+	cx, okx := resourceCreated(x)
+	cy, oky := resourceCreated(y)
+	switch {
+	case okx && oky && !cx.Equal(cy):
+		return cx.Before(cy)
+	case okx != oky:
+		return okx
+	}
+	return s.PerResource.less(x, y)
+}
+
+// PriorityResource allows only one resource to own a given dns name, the one with the highest priority annotation.
+// Resources without the annotation have priority 0, ties are resolved like OldestResource, except that the
+// resource which already owns the DNS name keeps it.
+type PriorityResource struct {
+	PerResource
+}
+
+// ResolveCreate takes the endpoint of the resource with the highest priority to acquire the DNS record
+func (s PriorityResource) ResolveCreate(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return resolveCreate(candidates, s.less)
+}
+
+// ResolveUpdate takes the endpoint of the resource with the highest priority, or the endpoint of the current
+// resource if it has the same priority.
+func (s PriorityResource) ResolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return resolveUpdate(current, candidates, s.less, func(x, y *endpoint.Endpoint) bool {
+		return resourcePriority(x) == resourcePriority(y)
+	})
+}
+
+func (s PriorityResource) less(x, y *endpoint.Endpoint) bool {
+	if px, py := resourcePriority(x), resourcePriority(y); px != py {
+		return px > py
+	}
+	return OldestResource{}.less(x, y)
+}
+
+// RefuseConflicts leaves records which are claimed by several resources alone, so that the conflict can be
+// reported and resolved by hand. Endpoints of a single resource are resolved like PerResource.
+type RefuseConflicts struct {
+	PerResource
+}
+
+// refuse returns the resources claiming the records if several resources claim the same record type,
+// or a CNAME and records of other types.
+func (s RefuseConflicts) refuse(candidates []*endpoint.Endpoint) ([]string, bool) {
+	var resources []string
+	byType := map[string]string{}
+	conflict := false
+	for _, c := range candidates {
+		resource := c.Labels[endpoint.ResourceLabelKey]
+		if !slices.Contains(resources, resource) {
+			resources = append(resources, resource)
+		}
+		if other, ok := byType[c.RecordType]; ok && other != resource {
+			conflict = true
+		}
+		byType[c.RecordType] = resource
+	}
+	if _, ok := byType[endpoint.RecordTypeCNAME]; ok && len(resources) > 1 {
+		conflict = true
+	}
+	if !conflict {
+		return nil, false
+	}
+	sort.Strings(resources)
+	return resources, true
+}
+
+// NamespaceAllowList only allows resources in the given namespaces to claim the DNS names of a domain,
+// and resolves conflicts between the allowed resources with the Resolver. Candidates of other resources
+// are not considered by the plan.
+type NamespaceAllowList struct {
+	// Namespaces maps domains to the namespaces of the resources which may claim names in them.
+	// The most specific domain of a name applies, names of other domains may be claimed by any resource.
+	Namespaces map[string][]string
+	// Resolver resolves conflicts between the allowed resources
+	Resolver ConflictResolver
+}
+
+func (s NamespaceAllowList) ResolveCreate(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return s.Resolver.ResolveCreate(candidates)
+}
+
+func (s NamespaceAllowList) ResolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	return s.Resolver.ResolveUpdate(current, candidates)
+}
+
+func (s NamespaceAllowList) ResolveRecordTypes(key planKey, row *planTableRow) map[string]*domainEndpoints {
+	return s.Resolver.ResolveRecordTypes(key, row)
+}
+
+func (s NamespaceAllowList) refuse(candidates []*endpoint.Endpoint) ([]string, bool) {
+	if r, ok := s.Resolver.(conflictRefuser); ok {
+		return r.refuse(candidates)
+	}
+	return nil, false
+}
+
+// reject rejects candidates of resources in namespaces which may not claim names in the domain of the candidate.
+func (s NamespaceAllowList) reject(candidate *endpoint.Endpoint) (string, bool) {
+	domain, namespaces := s.namespaces(candidate.DNSName)
+	if namespaces == nil {
+		return "", false
+	}
+	namespace := resourceNamespace(candidate)
+	if slices.Contains(namespaces, namespace) {
+		return "", false
+	}
+	if namespace == "" {
+		return fmt.Sprintf("only resources in the namespaces %s may claim names in %s", strings.Join(namespaces, ", "), domain), true
+	}
+	return fmt.Sprintf("namespace %s may not claim names in %s", namespace, domain), true
+}
+
+// namespaces returns the most specific domain of the allow list containing the name and its namespaces.
+func (s NamespaceAllowList) namespaces(dnsName string) (string, []string) {
+	name := normalizeDNSName(dnsName)
+	var domain string
+	var namespaces []string
+	for d, ns := range s.Namespaces {
+		normalized := normalizeDNSName(d)
+		if (name == normalized || strings.HasSuffix(name, "."+normalized)) && len(normalized) > len(domain) {
+			domain = normalized
+			namespaces = ns
+		}
+	}
+	return strings.TrimSuffix(domain, "."), namespaces
+}
+
+// resolveCreate returns the least candidate.
+func resolveCreate(candidates []*endpoint.Endpoint, less func(x, y *endpoint.Endpoint) bool) *endpoint.Endpoint {
+	var minE *endpoint.Endpoint
+	for _, ep := range candidates {
+		if minE == nil || less(ep, minE) {
+			minE = ep
+		}
+	}
+	return minE
+}
+
+// resolveUpdate returns the candidate of the resource owning the current record, if it is equivalent to
+// the least candidate by same, otherwise the least candidate. A nil same considers all candidates equivalent.
+func resolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint, less, same func(x, y *endpoint.Endpoint) bool) *endpoint.Endpoint {
+	currentResource := current.Labels[endpoint.ResourceLabelKey] // resource which has already acquired the DNS
+	// TODO: sort candidates only needed because we can still have two endpoints from same resource here. We sort for consistency
+	// TODO: remove once single endpoint can have multiple targets
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return less(candidates[i], candidates[j])
+	})
+	for _, ep := range candidates {
+		if ep.Labels[endpoint.ResourceLabelKey] == currentResource && (same == nil || same(ep, candidates[0])) {
+			return ep
+		}
+	}
+	return resolveCreate(candidates, less)
+}
+
+// resourceCreated returns the creation timestamp of the resource of an endpoint.
+func resourceCreated(e *endpoint.Endpoint) (time.Time, bool) {
+	created, ok := e.Labels[endpoint.ResourceCreatedLabelKey]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, created)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// resourcePriority returns the conflict priority of the resource of an endpoint, 0 if it has none.
+func resourcePriority(e *endpoint.Endpoint) int {
+	priority, ok := e.Labels[endpoint.ResourcePriorityLabelKey]
+	if !ok {
+		return 0
+	}
+	p, err := strconv.Atoi(priority)
+	if err != nil {
+		log.Debugf("Ignoring invalid conflict priority %q of endpoint %v", priority, e)
+		return 0
+	}
+	return p
+}
+
+// resourceNamespace returns the namespace of the resource of an endpoint, empty for cluster scoped resources.
+func resourceNamespace(e *endpoint.Endpoint) string {
+	parts := strings.Split(e.Labels[endpoint.ResourceLabelKey], "/")
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[1]
+}
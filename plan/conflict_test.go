@@ -20,11 +20,18 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"sigs.k8s.io/external-dns/endpoint"
 )
 
-var _ ConflictResolver = PerResource{}
+var (
+	_ ConflictResolver = PerResource{}
+	_ ConflictResolver = OldestResource{}
+	_ ConflictResolver = PriorityResource{}
+	_ ConflictResolver = RefuseConflicts{}
+	_ ConflictResolver = NamespaceAllowList{}
+)
 
 type ResolverSuite struct {
 	// resolvers
@@ -143,6 +150,11 @@ func (suite *ResolverSuite) TestStrictResolver() {
 }
 
 func (suite *ResolverSuite) TestPerResource_ResolveRecordTypes() {
+	fooHTTPS := &endpoint.Endpoint{
+		DNSName:    "foo",
+		Targets:    endpoint.Targets{"1 . alpn=h2"},
+		RecordType: endpoint.RecordTypeHTTPS,
+	}
 	type args struct {
 		key planKey
 		row *planTableRow
@@ -282,6 +294,65 @@ func (suite *ResolverSuite) TestPerResource_ResolveRecordTypes() {
 				},
 			},
 		},
+		{
+			name: "conflict: cname and https records",
+			args: args{
+				key: planKey{dnsName: "foo"},
+				row: &planTableRow{
+					current:    []*endpoint.Endpoint{fooHTTPS},
+					candidates: []*endpoint.Endpoint{suite.fooV1Cname, fooHTTPS},
+					records: map[string]*domainEndpoints{
+						endpoint.RecordTypeCNAME: {
+							candidates: []*endpoint.Endpoint{suite.fooV1Cname},
+						},
+						endpoint.RecordTypeHTTPS: {
+							current:    fooHTTPS,
+							candidates: []*endpoint.Endpoint{fooHTTPS},
+						},
+					},
+				},
+			},
+			want: map[string]*domainEndpoints{
+				endpoint.RecordTypeCNAME: {
+					candidates: []*endpoint.Endpoint{suite.fooV1Cname},
+				},
+				endpoint.RecordTypeHTTPS: {
+					current:    fooHTTPS,
+					candidates: []*endpoint.Endpoint{},
+				},
+			},
+		},
+		{
+			name: "conflict: cname, a, and https records",
+			args: args{
+				key: planKey{dnsName: "foo"},
+				row: &planTableRow{
+					candidates: []*endpoint.Endpoint{suite.fooV1Cname, suite.fooA5, fooHTTPS},
+					records: map[string]*domainEndpoints{
+						endpoint.RecordTypeCNAME: {
+							candidates: []*endpoint.Endpoint{suite.fooV1Cname},
+						},
+						endpoint.RecordTypeA: {
+							candidates: []*endpoint.Endpoint{suite.fooA5},
+						},
+						endpoint.RecordTypeHTTPS: {
+							candidates: []*endpoint.Endpoint{fooHTTPS},
+						},
+					},
+				},
+			},
+			want: map[string]*domainEndpoints{
+				endpoint.RecordTypeCNAME: {
+					candidates: []*endpoint.Endpoint{},
+				},
+				endpoint.RecordTypeA: {
+					candidates: []*endpoint.Endpoint{suite.fooA5},
+				},
+				endpoint.RecordTypeHTTPS: {
+					candidates: []*endpoint.Endpoint{fooHTTPS},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
@@ -295,3 +366,127 @@ func (suite *ResolverSuite) TestPerResource_ResolveRecordTypes() {
 func TestConflictResolver(t *testing.T) {
 	suite.Run(t, new(ResolverSuite))
 }
+
+func newConflictEndpoint(resource, created, priority string, target string) *endpoint.Endpoint {
+	ep := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, target).
+		WithLabel(endpoint.ResourceLabelKey, resource)
+	if created != "" {
+		ep.WithLabel(endpoint.ResourceCreatedLabelKey, created)
+	}
+	if priority != "" {
+		ep.WithLabel(endpoint.ResourcePriorityLabelKey, priority)
+	}
+	return ep
+}
+
+func TestOldestResource(t *testing.T) {
+	oldest := newConflictEndpoint("ingress/default/oldest", "2024-01-01T00:00:00Z", "", "3.3.3.3")
+	newer := newConflictEndpoint("ingress/default/newer", "2025-01-01T00:00:00Z", "", "1.1.1.1")
+	sameAge := newConflictEndpoint("ingress/default/same-age", "2024-01-01T00:00:00Z", "", "2.2.2.2")
+	unknown := newConflictEndpoint("ingress/default/unknown", "", "", "0.0.0.0")
+	invalid := newConflictEndpoint("ingress/default/invalid", "yesterday", "", "0.0.0.1")
+
+	resolver := OldestResource{}
+	assert.Equal(t, oldest, resolver.ResolveCreate([]*endpoint.Endpoint{newer, oldest, unknown}))
+	assert.Equal(t, newer, resolver.ResolveCreate([]*endpoint.Endpoint{unknown, newer, invalid}), "resources without creation timestamp are the newest")
+	assert.Equal(t, sameAge, resolver.ResolveCreate([]*endpoint.Endpoint{oldest, sameAge}), "ties are resolved by targets")
+	assert.Equal(t, unknown, resolver.ResolveCreate([]*endpoint.Endpoint{invalid, unknown}))
+
+	// an older resource takes the DNS name over
+	assert.Equal(t, oldest, resolver.ResolveUpdate(newer, []*endpoint.Endpoint{newer, oldest}))
+}
+
+func TestPriorityResource(t *testing.T) {
+	high := newConflictEndpoint("ingress/default/high", "2025-01-01T00:00:00Z", "10", "3.3.3.3")
+	low := newConflictEndpoint("ingress/default/low", "2024-01-01T00:00:00Z", "-1", "1.1.1.1")
+	none := newConflictEndpoint("ingress/default/none", "2024-06-01T00:00:00Z", "", "2.2.2.2")
+	older := newConflictEndpoint("ingress/default/older", "2024-01-01T00:00:00Z", "invalid", "4.4.4.4")
+	current := newConflictEndpoint("ingress/default/none", "", "", "2.2.2.2")
+
+	resolver := PriorityResource{}
+	assert.Equal(t, high, resolver.ResolveCreate([]*endpoint.Endpoint{low, none, high}))
+	assert.Equal(t, none, resolver.ResolveCreate([]*endpoint.Endpoint{low, none}), "resources without priority have priority 0")
+	assert.Equal(t, older, resolver.ResolveCreate([]*endpoint.Endpoint{none, older}), "ties are resolved by age")
+
+	// the current resource keeps the DNS name, unless a resource with a higher priority claims it
+	assert.Equal(t, none, resolver.ResolveUpdate(current, []*endpoint.Endpoint{older, none, low}))
+	assert.Equal(t, high, resolver.ResolveUpdate(current, []*endpoint.Endpoint{older, none, high}))
+}
+
+func TestRefuseConflicts(t *testing.T) {
+	fooA := newConflictEndpoint("ingress/default/foo", "", "", "1.1.1.1")
+	fooAAAA := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeAAAA, "::1").
+		WithLabel(endpoint.ResourceLabelKey, "ingress/default/foo")
+	barA := newConflictEndpoint("ingress/default/bar", "", "", "2.2.2.2")
+	barAAAA := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeAAAA, "::2").
+		WithLabel(endpoint.ResourceLabelKey, "ingress/default/bar")
+	barCNAME := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeCNAME, "bar.example.com").
+		WithLabel(endpoint.ResourceLabelKey, "ingress/default/bar")
+	fooA2 := newConflictEndpoint("ingress/default/foo", "", "", "3.3.3.3")
+
+	tests := []struct {
+		name       string
+		candidates []*endpoint.Endpoint
+		want       []string
+	}{
+		{name: "single resource", candidates: []*endpoint.Endpoint{fooA, fooAAAA, fooA2}},
+		{name: "record types of different resources", candidates: []*endpoint.Endpoint{fooA, barAAAA}},
+		{name: "same record type", candidates: []*endpoint.Endpoint{fooA, fooAAAA, barA}, want: []string{"ingress/default/bar", "ingress/default/foo"}},
+		{name: "cname and other record types", candidates: []*endpoint.Endpoint{fooA, barCNAME}, want: []string{"ingress/default/bar", "ingress/default/foo"}},
+		{name: "cname of the same resource", candidates: []*endpoint.Endpoint{barCNAME, barA}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources, refused := RefuseConflicts{}.refuse(tt.candidates)
+			assert.Equal(t, tt.want != nil, refused)
+			assert.Equal(t, tt.want, resources)
+		})
+	}
+}
+
+func TestNamespaceAllowList(t *testing.T) {
+	resolver := NamespaceAllowList{
+		Namespaces: map[string][]string{
+			"example.com":      {"team-a", "team-b"},
+			"team.example.com": {"team-c"},
+		},
+		Resolver: RefuseConflicts{},
+	}
+
+	tests := []struct {
+		name    string
+		dnsName string
+		labels  endpoint.Labels
+		want    string
+	}{
+		{name: "allowed", dnsName: "app.example.com", labels: endpoint.Labels{endpoint.ResourceLabelKey: "ingress/team-b/app"}},
+		{name: "apex", dnsName: "example.com.", labels: endpoint.Labels{endpoint.ResourceLabelKey: "ingress/team-a/app"}},
+		{name: "denied", dnsName: "app.example.com", labels: endpoint.Labels{endpoint.ResourceLabelKey: "ingress/team-c/app"}, want: "namespace team-c may not claim names in example.com"},
+		{name: "most specific domain", dnsName: "app.team.example.com", labels: endpoint.Labels{endpoint.ResourceLabelKey: "ingress/team-c/app"}},
+		{name: "most specific domain denied", dnsName: "app.team.example.com", labels: endpoint.Labels{endpoint.ResourceLabelKey: "ingress/team-a/app"}, want: "namespace team-a may not claim names in team.example.com"},
+		{name: "other domain", dnsName: "app.example.org", labels: endpoint.Labels{endpoint.ResourceLabelKey: "ingress/team-c/app"}},
+		{name: "suffix is not a domain", dnsName: "app.myexample.com", labels: endpoint.Labels{endpoint.ResourceLabelKey: "ingress/team-c/app"}},
+		{name: "no resource", dnsName: "app.example.com", want: "only resources in the namespaces team-a, team-b may claim names in example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := endpoint.NewEndpoint(tt.dnsName, endpoint.RecordTypeA, "1.1.1.1")
+			ep.Labels = tt.labels
+			reason, rejected := resolver.reject(ep)
+			assert.Equal(t, tt.want != "", rejected)
+			assert.Equal(t, tt.want, reason)
+		})
+	}
+
+	// conflicts are refused by the wrapped resolver
+	_, refused := resolver.refuse([]*endpoint.Endpoint{
+		newConflictEndpoint("ingress/team-a/foo", "", "", "1.1.1.1"),
+		newConflictEndpoint("ingress/team-b/bar", "", "", "2.2.2.2"),
+	})
+	assert.True(t, refused)
+	_, refused = NamespaceAllowList{Resolver: PerResource{}}.refuse([]*endpoint.Endpoint{
+		newConflictEndpoint("ingress/team-a/foo", "", "", "1.1.1.1"),
+		newConflictEndpoint("ingress/team-b/bar", "", "", "2.2.2.2"),
+	})
+	assert.False(t, refused)
+}
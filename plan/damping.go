@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// FlapDampingPolicy damps the records whose targets flap, e.g. the records of the pod and node sources
+// with pods and nodes which come and go. A record whose targets changed more than MaxChanges times within
+// Window is frozen at its current targets: its target changes are suppressed for a backoff, which starts
+// at Window and doubles up to MaxBackoff whenever the record is frozen again. A record is forgotten once
+// the source settles and no target change was planned for Window, the next time it flaps it is frozen
+// for Window again.
+//
+// A FlapDampingPolicy remembers the changes of the previous plans, it must only be used by the plans of
+// a single provider.
+type FlapDampingPolicy struct {
+	// MaxChanges is the number of target changes of a record within Window above which it is frozen.
+	MaxChanges int
+	// Window is the time the target changes of a record are counted in, and the first backoff.
+	Window time.Duration
+	// MaxBackoff is the maximum time a record is frozen.
+	MaxBackoff time.Duration
+
+	now        func() time.Time
+	records    map[endpoint.EndpointKey]*dampedRecord
+	suppressed int
+}
+
+// dampedRecord is the history of the target changes of a record.
+type dampedRecord struct {
+	changes     []time.Time
+	planned     time.Time
+	backoff     time.Duration
+	frozenUntil time.Time
+}
+
+// Apply suppresses the target changes of the frozen records.
+func (d *FlapDampingPolicy) Apply(changes *Changes) *Changes {
+	now := d.clock()
+	if d.records == nil {
+		d.records = map[endpoint.EndpointKey]*dampedRecord{}
+	}
+	d.suppressed = 0
+
+	damped := &Changes{
+		Create: changes.Create,
+		Delete: changes.Delete,
+	}
+	for i, update := range changes.UpdateNew {
+		current := changes.UpdateOld[i]
+		if !targetChanged(update, current) || d.allow(current.Key(), now) {
+			damped.UpdateOld = append(damped.UpdateOld, current)
+			damped.UpdateNew = append(damped.UpdateNew, update)
+			continue
+		}
+		d.suppressed++
+		log.Debugf("Suppressing the change of the targets of %s record %s from %v to %v, it is frozen", current.RecordType, current.DNSName, current.Targets, update.Targets)
+	}
+
+	// records which settled are forgotten
+	for key, r := range d.records {
+		if now.Sub(r.planned) >= d.Window && !now.Before(r.frozenUntil) {
+			delete(d.records, key)
+		}
+	}
+	return damped
+}
+
+// allow records a target change of the record, and tells whether it is applied.
+func (d *FlapDampingPolicy) allow(key endpoint.EndpointKey, now time.Time) bool {
+	r, ok := d.records[key]
+	if !ok {
+		r = &dampedRecord{}
+		d.records[key] = r
+	}
+	r.planned = now
+	if now.Before(r.frozenUntil) {
+		return false
+	}
+	r.prune(now.Add(-d.Window))
+	if len(r.changes) < d.MaxChanges {
+		r.changes = append(r.changes, now)
+		return true
+	}
+
+	if r.backoff == 0 {
+		r.backoff = d.Window
+	} else {
+		r.backoff *= 2
+	}
+	if d.MaxBackoff > 0 && r.backoff > d.MaxBackoff {
+		r.backoff = d.MaxBackoff
+	}
+	r.frozenUntil = now.Add(r.backoff)
+	log.Warnf("Freezing %s record %s until %s, its targets changed %d times within %s", key.RecordType, key.DNSName, r.frozenUntil.Format(time.RFC3339), len(r.changes)+1, d.Window)
+	return false
+}
+
+// prune forgets the changes before the time.
+func (r *dampedRecord) prune(before time.Time) {
+	i := 0
+	for i < len(r.changes) && !r.changes[i].After(before) {
+		i++
+	}
+	r.changes = r.changes[i:]
+}
+
+// Frozen returns the number of records which are frozen.
+func (d *FlapDampingPolicy) Frozen() int {
+	now := d.clock()
+	frozen := 0
+	for _, r := range d.records {
+		if now.Before(r.frozenUntil) {
+			frozen++
+		}
+	}
+	return frozen
+}
+
+// Suppressed returns the number of target changes suppressed from the last plan.
+func (d *FlapDampingPolicy) Suppressed() int {
+	return d.suppressed
+}
+
+func (d *FlapDampingPolicy) clock() time.Time {
+	if d.now != nil {
+		return d.now()
+	}
+	return time.Now()
+}
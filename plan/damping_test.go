@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestFlapDampingPolicy(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	d := &FlapDampingPolicy{MaxChanges: 2, Window: time.Minute, MaxBackoff: 3 * time.Minute, now: func() time.Time { return now }}
+
+	target := "1.2.3.4"
+	flap := func() *Changes {
+		old := endpoint.NewEndpoint("pod.example.com", endpoint.RecordTypeA, target)
+		if target == "1.2.3.4" {
+			target = "1.2.3.5"
+		} else {
+			target = "1.2.3.4"
+		}
+		return d.Apply(&Changes{
+			Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.6")},
+			UpdateOld: []*endpoint.Endpoint{old, endpoint.NewEndpoint("ttl.example.com", endpoint.RecordTypeA, "1.2.3.7")},
+			UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("pod.example.com", endpoint.RecordTypeA, target), endpoint.NewEndpointWithTTL("ttl.example.com", endpoint.RecordTypeA, 60, "1.2.3.7")},
+		})
+	}
+	applied := func(changes *Changes) bool {
+		for _, ep := range changes.UpdateNew {
+			if ep.DNSName == "pod.example.com" {
+				return true
+			}
+		}
+		return false
+	}
+
+	// two changes within the window are applied, the third freezes the record for the window
+	for range 2 {
+		assert.True(t, applied(flap()))
+		now = now.Add(10 * time.Second)
+	}
+	changes := flap()
+	assert.False(t, applied(changes))
+	assert.Equal(t, 1, d.Suppressed())
+	assert.Equal(t, 1, d.Frozen())
+	// other changes are applied
+	assert.Len(t, changes.Create, 1)
+	assert.Len(t, changes.UpdateNew, 1)
+
+	now = now.Add(59 * time.Second)
+	assert.False(t, applied(flap()))
+
+	// the record flaps again after the backoff, it is frozen for twice the window
+	now = now.Add(time.Second)
+	assert.True(t, applied(flap()))
+	assert.Zero(t, d.Frozen())
+	assert.True(t, applied(flap()))
+	assert.False(t, applied(flap()))
+	now = now.Add(119 * time.Second)
+	assert.Equal(t, 1, d.Frozen())
+	now = now.Add(time.Second)
+	assert.Zero(t, d.Frozen())
+
+	// the backoff is limited
+	assert.True(t, applied(flap()))
+	assert.True(t, applied(flap()))
+	assert.False(t, applied(flap()))
+	now = now.Add(3 * time.Minute)
+	assert.True(t, applied(flap()))
+
+	// once the source settled for the window, the record is forgotten
+	now = now.Add(time.Minute)
+	d.Apply(&Changes{})
+	assert.Empty(t, d.records)
+	assert.True(t, applied(flap()))
+	assert.True(t, applied(flap()))
+	assert.False(t, applied(flap()))
+	now = now.Add(time.Minute)
+	assert.True(t, applied(flap()))
+}
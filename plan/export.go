@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Action is the kind of a change to a record.
+type Action string
+
+const (
+	// ActionCreate creates a record which does not exist.
+	ActionCreate Action = "create"
+	// ActionUpdate replaces an existing record.
+	ActionUpdate Action = "update"
+	// ActionDelete deletes an existing record.
+	ActionDelete Action = "delete"
+)
+
+// ExportedChange describes a single change of Changes for review outside of ExternalDNS.
+type ExportedChange struct {
+	Action        Action `json:"action"`
+	DNSName       string `json:"dnsName"`
+	RecordType    string `json:"recordType"`
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// Targets are the targets of the record after the change, empty for deletions.
+	Targets endpoint.Targets `json:"targets,omitempty"`
+	// PreviousTargets are the targets of the record before the change, empty for creations.
+	PreviousTargets endpoint.Targets `json:"previousTargets,omitempty"`
+	TTL             endpoint.TTL     `json:"ttl,omitempty"`
+	// Reason is a human readable explanation of the change.
+	Reason string `json:"reason"`
+	// Owner is the owner ID of the record.
+	Owner string `json:"owner,omitempty"`
+	// Resource is the resource the record was generated from, e.g. ingress/default/app.
+	Resource string `json:"resource,omitempty"`
+}
+
+// Export describes every change of changes, sorted by name, type, set identifier and action.
+// It must be called on the Plan the changes were calculated from: created records without
+// an owner are reported with the owner ID of the plan, since the registry assigns it to them.
+func (p *Plan) Export(changes *Changes) []*ExportedChange {
+	if changes == nil {
+		return nil
+	}
+	exported := make([]*ExportedChange, 0, len(changes.Create)+len(changes.UpdateNew)+len(changes.Delete))
+	for _, ep := range changes.Create {
+		c := newExportedChange(ActionCreate, ep, p.OwnerID)
+		c.Targets = ep.Targets
+		c.TTL = ep.RecordTTL
+		c.Reason = "record does not exist"
+		exported = append(exported, c)
+	}
+	for i, ep := range changes.UpdateNew {
+		c := newExportedChange(ActionUpdate, ep, p.OwnerID)
+		c.Targets = ep.Targets
+		c.TTL = ep.RecordTTL
+		c.Reason = "record changed"
+		// the old and new records of an update are at the same position
+		if i < len(changes.UpdateOld) {
+			old := changes.UpdateOld[i]
+			c.PreviousTargets = old.Targets
+			c.Reason = p.updateReason(ep, old)
+			if c.Resource == "" {
+				c.Resource = old.Labels[endpoint.ResourceLabelKey]
+			}
+		}
+		exported = append(exported, c)
+	}
+	for _, ep := range changes.Delete {
+		c := newExportedChange(ActionDelete, ep, "")
+		c.PreviousTargets = ep.Targets
+		c.Reason = "record is no longer desired by any resource"
+		exported = append(exported, c)
+	}
+
+	sort.SliceStable(exported, func(i, j int) bool {
+		a, b := exported[i], exported[j]
+		if a.DNSName != b.DNSName {
+			return a.DNSName < b.DNSName
+		}
+		if a.RecordType != b.RecordType {
+			return a.RecordType < b.RecordType
+		}
+		if a.SetIdentifier != b.SetIdentifier {
+			return a.SetIdentifier < b.SetIdentifier
+		}
+		return a.Action < b.Action
+	})
+	return exported
+}
+
+func newExportedChange(action Action, ep *endpoint.Endpoint, defaultOwner string) *ExportedChange {
+	owner := ep.Labels[endpoint.OwnerLabelKey]
+	if owner == "" {
+		owner = defaultOwner
+	}
+	return &ExportedChange{
+		Action:        action,
+		DNSName:       ep.DNSName,
+		RecordType:    ep.RecordType,
+		SetIdentifier: ep.SetIdentifier,
+		Owner:         owner,
+		Resource:      ep.Labels[endpoint.ResourceLabelKey],
+	}
+}
+
+// updateReason explains why the plan replaces the current record with the desired one.
+func (p *Plan) updateReason(desired, current *endpoint.Endpoint) string {
+	var reasons []string
+	if isAdopted(current) {
+		reasons = append(reasons, "unowned record is adopted")
+	}
+	if targetChanged(desired, current) {
+		reasons = append(reasons, "targets changed")
+	}
+	if shouldUpdateTTL(desired, current) {
+		reasons = append(reasons, fmt.Sprintf("TTL changed from %d to %d", current.RecordTTL, desired.RecordTTL))
+	}
+	if p.shouldUpdateProviderSpecific(desired, current) {
+		reasons = append(reasons, "provider specific properties changed")
+	}
+	_, wasPending := current.Labels[endpoint.DeletionPendingLabelKey]
+	_, isPending := desired.Labels[endpoint.DeletionPendingLabelKey]
+	if isPending && !wasPending {
+		reasons = append(reasons, "deletion is delayed by the grace period")
+	} else if wasPending && !isPending {
+		reasons = append(reasons, "pending deletion is canceled")
+	}
+	if len(reasons) == 0 {
+		return "record changed"
+	}
+	return strings.Join(reasons, ", ")
+}
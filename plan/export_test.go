@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestPlanExport(t *testing.T) {
+	ttl := newResultEndpoint("ttl.example.com", endpoint.RecordTypeA, "", "owner", "1.1.1.1")
+	ttl.RecordTTL = 300
+
+	p := &Plan{
+		Policies: []Policy{&SyncPolicy{}},
+		Current: []*endpoint.Endpoint{
+			newResultEndpoint("app.example.com", endpoint.RecordTypeA, "ingress/default/app", "owner", "1.1.1.1"),
+			newResultEndpoint("old.example.com", endpoint.RecordTypeA, "ingress/default/old", "owner", "2.2.2.2"),
+			newResultEndpoint("unowned.example.com", endpoint.RecordTypeA, "", "", "3.3.3.3"),
+			ttl,
+		},
+		Desired: []*endpoint.Endpoint{
+			newResultEndpoint("app.example.com", endpoint.RecordTypeA, "ingress/default/app", "", "1.1.1.2"),
+			newResultEndpoint("new.example.com", endpoint.RecordTypeA, "service/default/new", "", "4.4.4.4"),
+			newResultEndpoint("unowned.example.com", endpoint.RecordTypeA, "ingress/default/unowned", "", "3.3.3.3"),
+			newResultEndpoint("ttl.example.com", endpoint.RecordTypeA, "ingress/default/ttl", "", "1.1.1.1").WithProviderSpecific("weight", "10"),
+		},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "owner",
+		AdoptDomains:   []string{"unowned.example.com"},
+	}
+	p.Desired[3].RecordTTL = 60
+
+	exported := p.Export(p.Calculate().Changes)
+	assert.Equal(t, []*ExportedChange{
+		{
+			Action:          ActionUpdate,
+			DNSName:         "app.example.com",
+			RecordType:      endpoint.RecordTypeA,
+			Targets:         endpoint.Targets{"1.1.1.2"},
+			PreviousTargets: endpoint.Targets{"1.1.1.1"},
+			Reason:          "targets changed",
+			Owner:           "owner",
+			Resource:        "ingress/default/app",
+		},
+		{
+			Action:     ActionCreate,
+			DNSName:    "new.example.com",
+			RecordType: endpoint.RecordTypeA,
+			Targets:    endpoint.Targets{"4.4.4.4"},
+			Reason:     "record does not exist",
+			Owner:      "owner",
+			Resource:   "service/default/new",
+		},
+		{
+			Action:          ActionDelete,
+			DNSName:         "old.example.com",
+			RecordType:      endpoint.RecordTypeA,
+			PreviousTargets: endpoint.Targets{"2.2.2.2"},
+			Reason:          "record is no longer desired by any resource",
+			Owner:           "owner",
+			Resource:        "ingress/default/old",
+		},
+		{
+			Action:          ActionUpdate,
+			DNSName:         "ttl.example.com",
+			RecordType:      endpoint.RecordTypeA,
+			Targets:         endpoint.Targets{"1.1.1.1"},
+			PreviousTargets: endpoint.Targets{"1.1.1.1"},
+			TTL:             60,
+			Reason:          "TTL changed from 300 to 60, provider specific properties changed",
+			Owner:           "owner",
+			Resource:        "ingress/default/ttl",
+		},
+		{
+			Action:          ActionUpdate,
+			DNSName:         "unowned.example.com",
+			RecordType:      endpoint.RecordTypeA,
+			Targets:         endpoint.Targets{"3.3.3.3"},
+			PreviousTargets: endpoint.Targets{"3.3.3.3"},
+			Reason:          "unowned record is adopted",
+			Owner:           "owner",
+			Resource:        "ingress/default/unowned",
+		},
+	}, exported)
+
+	// the pending label of the deletion grace period
+	p = &Plan{
+		Policies: []Policy{&SyncPolicy{}, &DeletionGracePolicy{GracePeriod: time.Hour}},
+		Current: []*endpoint.Endpoint{
+			newResultEndpoint("old.example.com", endpoint.RecordTypeA, "ingress/default/old", "owner", "2.2.2.2"),
+		},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "owner",
+	}
+	exported = p.Export(p.Calculate().Changes)
+	require.Len(t, exported, 1)
+	assert.Equal(t, ActionUpdate, exported[0].Action)
+	assert.Equal(t, "deletion is delayed by the grace period", exported[0].Reason)
+
+	assert.Empty(t, p.Export(nil))
+	assert.Empty(t, p.Export(&Changes{}))
+}
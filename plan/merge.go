@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// mergeKey identifies the candidates of a record set which may be merged.
+type mergeKey struct {
+	planKey
+	recordType string
+}
+
+// mergeTargets replaces the candidates of several resources for the same record set by a single candidate
+// with the targets of all of them, if the DNS name is in one of the MergeTargetsDomains or all resources
+// opted in with the merge-targets label. The order of the candidates is preserved otherwise.
+func (p *Plan) mergeTargets(candidates []*endpoint.Endpoint) []*endpoint.Endpoint {
+	domainFilter := endpoint.NewDomainFilter(p.MergeTargetsDomains)
+
+	groups := map[mergeKey][]*endpoint.Endpoint{}
+	var keys []mergeKey
+	for _, c := range candidates {
+		key := mergeKey{planKey{normalizeDNSName(c.DNSName), c.SetIdentifier}, c.RecordType}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], c)
+	}
+
+	merged := make([]*endpoint.Endpoint, 0, len(candidates))
+	for _, key := range keys {
+		group := groups[key]
+		if !mergeable(group, len(p.MergeTargetsDomains) > 0 && domainFilter.Match(group[0].DNSName)) {
+			merged = append(merged, group...)
+			continue
+		}
+		merged = append(merged, mergeEndpoints(group))
+	}
+	return merged
+}
+
+// mergeable returns true if the candidates of a record set are claimed by several resources which may be merged.
+// A CNAME can only have a single target.
+func mergeable(candidates []*endpoint.Endpoint, inMergeDomain bool) bool {
+	if len(candidates) < 2 || candidates[0].RecordType == endpoint.RecordTypeCNAME {
+		return false
+	}
+	if len(resourcesOf(candidates)) < 2 {
+		return false
+	}
+	if inMergeDomain {
+		return true
+	}
+	for _, c := range candidates {
+		if c.Labels[endpoint.MergeTargetsLabelKey] != "true" {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeEndpoints returns a record set with the targets of all candidates, which is owned by all of their resources.
+// The TTL is the lowest configured TTL, the other properties are those of the first resource.
+func mergeEndpoints(candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	sorted := slices.Clone(candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Labels[endpoint.ResourceLabelKey] < sorted[j].Labels[endpoint.ResourceLabelKey]
+	})
+
+	merged := sorted[0].DeepCopy()
+	merged.Targets = nil
+	for _, c := range sorted {
+		for _, target := range c.Targets {
+			if !slices.Contains(merged.Targets, target) {
+				merged.Targets = append(merged.Targets, target)
+			}
+		}
+		if c.RecordTTL.IsConfigured() && (!merged.RecordTTL.IsConfigured() || c.RecordTTL < merged.RecordTTL) {
+			merged.RecordTTL = c.RecordTTL
+		}
+	}
+	sort.Strings(merged.Targets)
+
+	resources := resourcesOf(sorted)
+	if merged.Labels == nil {
+		merged.Labels = endpoint.NewLabels()
+	}
+	merged.Labels[endpoint.ResourceLabelKey] = strings.Join(resources, endpoint.ResourceLabelSeparator)
+	log.Debugf("Merging the targets of %s for %s record %s", strings.Join(resources, ", "), merged.RecordType, merged.DNSName)
+	return merged
+}
+
+// resourcesOf returns the distinct resources of the endpoints in order, including the resources of merged endpoints.
+func resourcesOf(endpoints []*endpoint.Endpoint) []string {
+	var resources []string
+	for _, e := range endpoints {
+		for _, resource := range splitResources(e) {
+			if !slices.Contains(resources, resource) {
+				resources = append(resources, resource)
+			}
+		}
+	}
+	return resources
+}
+
+// splitResources returns the resources of the resource label of an endpoint, several for a merged record set.
+func splitResources(e *endpoint.Endpoint) []string {
+	resource := e.Labels[endpoint.ResourceLabelKey]
+	if resource == "" {
+		return nil
+	}
+	return strings.Split(resource, endpoint.ResourceLabelSeparator)
+}
+
+// mergedFrom returns true if the record set was merged from the targets of the desired endpoint and other resources.
+func mergedFrom(merged, desired *endpoint.Endpoint) bool {
+	resources := splitResources(merged)
+	if len(resources) < 2 || !slices.Contains(resources, desired.Labels[endpoint.ResourceLabelKey]) {
+		return false
+	}
+	for _, target := range desired.Targets {
+		if !slices.Contains(merged.Targets, target) {
+			return false
+		}
+	}
+	return true
+}
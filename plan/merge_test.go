@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestMergeTargets(t *testing.T) {
+	east := newResultEndpoint("app.example.com", endpoint.RecordTypeA, "service/east/app", "", "1.1.1.1", "2.2.2.2")
+	east.RecordTTL = 300
+	west := newResultEndpoint("app.example.com", endpoint.RecordTypeA, "service/west/app", "", "3.3.3.3", "1.1.1.1")
+	west.RecordTTL = 60
+	westAAAA := newResultEndpoint("app.example.com", endpoint.RecordTypeAAAA, "service/west/app", "", "::1")
+	cnameA := newResultEndpoint("www.example.com", endpoint.RecordTypeCNAME, "ingress/east/www", "", "a.example.com")
+	cnameB := newResultEndpoint("www.example.com", endpoint.RecordTypeCNAME, "ingress/west/www", "", "b.example.com")
+	other := newResultEndpoint("app.example.org", endpoint.RecordTypeA, "service/east/app", "", "1.1.1.1")
+	otherWest := newResultEndpoint("app.example.org", endpoint.RecordTypeA, "service/west/app", "", "3.3.3.3")
+
+	p := &Plan{MergeTargetsDomains: []string{"example.com"}}
+	candidates := p.mergeTargets([]*endpoint.Endpoint{west, cnameA, east, westAAAA, cnameB, other, otherWest})
+	require.Len(t, candidates, 6)
+
+	merged := candidates[0]
+	assert.Equal(t, "app.example.com", merged.DNSName)
+	assert.Equal(t, endpoint.Targets{"1.1.1.1", "2.2.2.2", "3.3.3.3"}, merged.Targets)
+	assert.Equal(t, endpoint.TTL(60), merged.RecordTTL)
+	assert.Equal(t, "service/east/app+service/west/app", merged.Labels[endpoint.ResourceLabelKey])
+	assert.Equal(t, endpoint.Targets{"1.1.1.1", "2.2.2.2"}, east.Targets, "candidates are not modified")
+
+	// CNAMEs, single resources and names outside the merge domains are not merged
+	assert.Equal(t, []*endpoint.Endpoint{cnameA, cnameB}, candidates[1:3])
+	assert.Equal(t, westAAAA, candidates[3])
+	assert.Equal(t, []*endpoint.Endpoint{other, otherWest}, candidates[4:])
+
+	// resources can opt in to merging
+	other.WithLabel(endpoint.MergeTargetsLabelKey, "true")
+	assert.Len(t, p.mergeTargets([]*endpoint.Endpoint{other, otherWest}), 2, "all resources must opt in")
+	otherWest.WithLabel(endpoint.MergeTargetsLabelKey, "true")
+	candidates = p.mergeTargets([]*endpoint.Endpoint{other, otherWest})
+	require.Len(t, candidates, 1)
+	assert.Equal(t, endpoint.Targets{"1.1.1.1", "3.3.3.3"}, candidates[0].Targets)
+}
+
+func TestPlanMergeTargets(t *testing.T) {
+	east := newResultEndpoint("app.example.com", endpoint.RecordTypeA, "service/east/app", "", "1.1.1.1")
+	west := newResultEndpoint("app.example.com", endpoint.RecordTypeA, "service/west/app", "", "2.2.2.2")
+
+	p := &Plan{
+		Policies:            []Policy{&SyncPolicy{}},
+		Desired:             []*endpoint.Endpoint{east, west},
+		ManagedRecords:      []string{endpoint.RecordTypeA},
+		OwnerID:             "owner",
+		MergeTargetsDomains: []string{"example.com"},
+		Resolver:            RefuseConflicts{},
+	}
+	changes := p.Calculate().Changes
+	require.Len(t, changes.Create, 1)
+	assert.Equal(t, endpoint.Targets{"1.1.1.1", "2.2.2.2"}, changes.Create[0].Targets)
+
+	results := resultsByResource(p.Results(changes, nil))
+	for _, key := range []string{"service/east/app|app.example.com", "service/west/app|app.example.com"} {
+		assert.Equal(t, OutcomeCreated, results[key].Outcome, key)
+		assert.Equal(t, changes.Create[0], results[key].Live, key)
+	}
+
+	// the merged record set is unchanged
+	current := changes.Create[0].DeepCopy()
+	current.WithLabel(endpoint.OwnerLabelKey, "owner")
+	p.Current = []*endpoint.Endpoint{current}
+	changes = p.Calculate().Changes
+	assert.False(t, changes.HasChanges())
+	for _, result := range p.Results(changes, nil) {
+		assert.Equal(t, OutcomeUnchanged, result.Outcome, result.Endpoint.Labels[endpoint.ResourceLabelKey])
+	}
+
+	// removing a resource removes only its targets
+	p.Desired = []*endpoint.Endpoint{west}
+	changes = p.Calculate().Changes
+	require.Len(t, changes.UpdateNew, 1)
+	assert.Equal(t, endpoint.Targets{"2.2.2.2"}, changes.UpdateNew[0].Targets)
+	assert.Equal(t, "service/west/app", changes.UpdateNew[0].Labels[endpoint.ResourceLabelKey])
+}
@@ -50,6 +50,20 @@ type Plan struct {
 	ExcludeRecords []string
 	// OwnerID of records to manage
 	OwnerID string
+	// MergeTargetsDomains are domains in which the targets of all resources claiming a DNS name are merged
+	// into one record set instead of resolving the conflict. Resources can also opt in with the merge-targets label.
+	MergeTargetsDomains []string
+	// AdoptDomains are domains in which unowned records desired by a resource are adopted: the registry writes
+	// their ownership, and they are managed like records created by the plan. Resources can also opt in with the adopt label.
+	AdoptDomains []string
+	// Resolver decides which resource acquires a DNS name claimed by several resources, PerResource if nil
+	Resolver ConflictResolver
+	// List of DNS names which were left alone, because several resources claim them and the Resolver refused to decide
+	// Populated after calling Calculate()
+	Conflicts []*Conflict
+	// List of unowned current records which were adopted by the changes
+	// Populated after calling Calculate()
+	Adopted []*endpoint.Endpoint
 }
 
 // Changes holds lists of actions to be executed by dns providers
@@ -93,8 +107,8 @@ type planTable struct {
 	resolver ConflictResolver
 }
 
-func newPlanTable() planTable { // TODO: make resolver configurable
-	return planTable{map[planKey]*planTableRow{}, PerResource{}}
+func newPlanTable(resolver ConflictResolver) planTable {
+	return planTable{map[planKey]*planTableRow{}, resolver}
 }
 
 // planTableRow represents a set of current and desired domain resource records.
@@ -166,7 +180,7 @@ func (c *Changes) HasChanges() bool {
 // state. It then passes those changes to the current policy for further
 // processing. It returns a copy of Plan with the changes populated.
 func (p *Plan) Calculate() *Plan {
-	t := newPlanTable()
+	t := newPlanTable(p.resolver())
 
 	if p.DomainFilter == nil {
 		p.DomainFilter = endpoint.MatchAllDomainFilters(nil)
@@ -175,13 +189,26 @@ func (p *Plan) Calculate() *Plan {
 	for _, current := range filterRecordsForPlan(p.Current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
 		t.addCurrent(current)
 	}
-	for _, desired := range filterRecordsForPlan(p.Desired, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
+	for _, desired := range p.candidates(p.DomainFilter) {
 		t.addCandidate(desired)
 	}
 
 	changes := &Changes{}
+	var conflicts []*Conflict
 
 	for key, row := range t.rows {
+		// dns name claimed by several resources, leave it alone
+		if refuser, ok := t.resolver.(conflictRefuser); ok {
+			if resources, refused := refuser.refuse(row.candidates); refused {
+				dnsName := row.candidates[0].DNSName
+				log.Warnf("Skipping %s, it is claimed by several resources: %s", dnsName, strings.Join(resources, ", "))
+				conflicts = append(conflicts, &Conflict{DNSName: dnsName, SetIdentifier: key.setIdentifier, Resources: resources})
+				continue
+			}
+		}
+
+		p.adopt(row)
+
 		// dns name not taken
 		if len(row.current) == 0 {
 			recordsByType := t.resolver.ResolveRecordTypes(key, row)
@@ -204,8 +231,8 @@ func (p *Plan) Calculate() *Plan {
 			// apply changes for each record type
 			recordsByType := t.resolver.ResolveRecordTypes(key, row)
 			for _, records := range recordsByType {
-				// record type not desired
-				if records.current != nil && len(records.candidates) == 0 {
+				// record type not desired, records adopted for discarded candidates stay unowned
+				if records.current != nil && len(records.candidates) == 0 && !isAdopted(records.current) {
 					changes.Delete = append(changes.Delete, records.current)
 				}
 
@@ -222,7 +249,7 @@ func (p *Plan) Calculate() *Plan {
 				if records.current != nil && len(records.candidates) > 0 {
 					update := t.resolver.ResolveUpdate(records.current, records.candidates)
 
-					if shouldUpdateTTL(update, records.current) || targetChanged(update, records.current) || p.shouldUpdateProviderSpecific(update, records.current) {
+					if isAdopted(records.current) || shouldUpdateTTL(update, records.current) || targetChanged(update, records.current) || p.shouldUpdateProviderSpecific(update, records.current) {
 						inheritOwner(records.current, update)
 						changes.UpdateNew = append(changes.UpdateNew, update)
 						changes.UpdateOld = append(changes.UpdateOld, records.current)
@@ -250,10 +277,6 @@ func (p *Plan) Calculate() *Plan {
 		}
 	}
 
-	for _, pol := range p.Policies {
-		changes = pol.Apply(changes)
-	}
-
 	// filter out updates this external dns does not have ownership claim over
 	if p.OwnerID != "" {
 		changes.Delete = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.Delete)
@@ -262,10 +285,36 @@ func (p *Plan) Calculate() *Plan {
 		changes.UpdateNew = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateNew)
 	}
 
+	// the policies are applied to the changes of the owner only, so that they see the records which are changed
+	for _, pol := range p.Policies {
+		if pp, ok := pol.(planPolicy); ok {
+			changes = pp.applyToPlan(p, changes)
+		} else {
+			changes = pol.Apply(changes)
+		}
+	}
+
+	// the resource metadata is only needed to resolve conflicts, it must not be stored with the records
+	for _, ep := range append(changes.Create, changes.UpdateNew...) {
+		delete(ep.Labels, endpoint.ResourceCreatedLabelKey)
+		delete(ep.Labels, endpoint.ResourcePriorityLabelKey)
+		delete(ep.Labels, endpoint.MergeTargetsLabelKey)
+		delete(ep.Labels, endpoint.AdoptLabelKey)
+	}
+
+	var adopted []*endpoint.Endpoint
+	for _, ep := range changes.UpdateOld {
+		if isAdopted(ep) {
+			adopted = append(adopted, ep)
+		}
+	}
+
 	plan := &Plan{
-		Current: p.Current,
-		Desired: p.Desired,
-		Changes: changes,
+		Current:   p.Current,
+		Desired:   p.Desired,
+		Changes:   changes,
+		Conflicts: conflicts,
+		Adopted:   adopted,
 		// The default for ExternalDNS is to always only consider A/AAAA and CNAMEs.
 		// Everything else is an add on or something to be considered.
 		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
@@ -274,6 +323,47 @@ func (p *Plan) Calculate() *Plan {
 	return plan
 }
 
+// ownedCurrent returns the current records considered by the plan which are owned by its owner.
+func (p *Plan) ownedCurrent() []*endpoint.Endpoint {
+	var owned []*endpoint.Endpoint
+	for _, current := range filterRecordsForPlan(p.Current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
+		if p.OwnerID == "" || current.IsOwnedBy(p.OwnerID) {
+			owned = append(owned, current)
+		}
+	}
+	return owned
+}
+
+// resolver returns the conflict resolver of the plan.
+func (p *Plan) resolver() ConflictResolver {
+	if p.Resolver == nil {
+		return PerResource{}
+	}
+	return p.Resolver
+}
+
+// candidates returns the desired records considered by the plan, with the targets of record sets merged.
+func (p *Plan) candidates(domainFilter endpoint.MatchAllDomainFilters) []*endpoint.Endpoint {
+	return p.mergeTargets(p.filterRejected(filterRecordsForPlan(p.Desired, domainFilter, p.ManagedRecords, p.ExcludeRecords)))
+}
+
+// filterRejected removes the candidates rejected by the conflict resolver.
+func (p *Plan) filterRejected(candidates []*endpoint.Endpoint) []*endpoint.Endpoint {
+	rejecter, ok := p.resolver().(candidateRejecter)
+	if !ok {
+		return candidates
+	}
+	filtered := make([]*endpoint.Endpoint, 0, len(candidates))
+	for _, c := range candidates {
+		if reason, rejected := rejecter.reject(c); rejected {
+			log.Debugf("Skipping endpoint %v: %s", c, reason)
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+	return filtered
+}
+
 func inheritOwner(from, to *endpoint.Endpoint) {
 	if to.Labels == nil {
 		to.Labels = map[string]string{}
@@ -285,7 +375,7 @@ func inheritOwner(from, to *endpoint.Endpoint) {
 }
 
 func targetChanged(desired, current *endpoint.Endpoint) bool {
-	return !desired.Targets.Same(current.Targets)
+	return !desired.Targets.SameForRecordType(desired.RecordType, current.Targets)
 }
 
 func shouldUpdateTTL(desired, current *endpoint.Endpoint) bool {
@@ -1017,6 +1017,67 @@ func (suite *PlanTestSuite) TestDualStackToSingleStack() {
 	validateEntries(suite.T(), changes.UpdateNew, expectNoChanges)
 }
 
+func (suite *PlanTestSuite) TestCAARecords() {
+	caa := &endpoint.Endpoint{
+		DNSName:    "foo",
+		Targets:    endpoint.Targets{`0 issue "letsencrypt.org"`},
+		RecordType: endpoint.RecordTypeCAA,
+		Labels: map[string]string{
+			endpoint.ResourceLabelKey: "crd/default/example",
+		},
+	}
+	current := []*endpoint.Endpoint{suite.fooA5}
+	desired := []*endpoint.Endpoint{suite.fooA5, caa}
+	expectedCreate := []*endpoint.Endpoint{caa}
+	expectNoChanges := []*endpoint.Endpoint{}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCAA},
+	}
+
+	changes := p.Calculate().Changes
+	validateEntries(suite.T(), changes.Create, expectedCreate)
+	validateEntries(suite.T(), changes.Delete, expectNoChanges)
+	validateEntries(suite.T(), changes.UpdateOld, expectNoChanges)
+	validateEntries(suite.T(), changes.UpdateNew, expectNoChanges)
+}
+
+func (suite *PlanTestSuite) TestCAARecordsIgnoreFormatting() {
+	current := []*endpoint.Endpoint{{
+		DNSName:    "example.com",
+		Targets:    endpoint.Targets{`0 ISSUE "letsencrypt.org"`, `0 iodef "mailto:security@example.com"`},
+		RecordType: endpoint.RecordTypeCAA,
+	}}
+	desired := []*endpoint.Endpoint{{
+		DNSName:    "example.com",
+		Targets:    endpoint.Targets{`0   iodef  "mailto:security@example.com"`, `0 issue "letsencrypt.org"`},
+		RecordType: endpoint.RecordTypeCAA,
+	}}
+	expectNoChanges := []*endpoint.Endpoint{}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeCAA},
+	}
+
+	changes := p.Calculate().Changes
+	validateEntries(suite.T(), changes.Create, expectNoChanges)
+	validateEntries(suite.T(), changes.Delete, expectNoChanges)
+	validateEntries(suite.T(), changes.UpdateOld, expectNoChanges)
+	validateEntries(suite.T(), changes.UpdateNew, expectNoChanges)
+
+	// a different issuer must be updated
+	desired[0].Targets = endpoint.Targets{`0 issue "pki.goog"`}
+	changes = p.Calculate().Changes
+	validateEntries(suite.T(), changes.UpdateNew, desired)
+	validateEntries(suite.T(), changes.UpdateOld, current)
+}
+
 func TestPlan(t *testing.T) {
 	suite.Run(t, new(PlanTestSuite))
 }
@@ -1181,3 +1242,64 @@ func TestShouldUpdateProviderSpecific(tt *testing.T) {
 		})
 	}
 }
+
+func (suite *PlanTestSuite) TestHTTPSRecordsIgnoreFormatting() {
+	current := []*endpoint.Endpoint{{
+		DNSName:    "example.com",
+		Targets:    endpoint.Targets{`1 . alpn="h3,h2" port="443" ipv4hint="5.6.7.8,1.2.3.4"`},
+		RecordType: endpoint.RecordTypeHTTPS,
+	}}
+	desired := []*endpoint.Endpoint{{
+		DNSName:    "example.com",
+		Targets:    endpoint.Targets{"1 . ipv4hint=1.2.3.4,5.6.7.8 port=443 alpn=h3,h2"},
+		RecordType: endpoint.RecordTypeHTTPS,
+	}}
+	expectNoChanges := []*endpoint.Endpoint{}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeHTTPS},
+	}
+
+	changes := p.Calculate().Changes
+	validateEntries(suite.T(), changes.Create, expectNoChanges)
+	validateEntries(suite.T(), changes.Delete, expectNoChanges)
+	validateEntries(suite.T(), changes.UpdateOld, expectNoChanges)
+	validateEntries(suite.T(), changes.UpdateNew, expectNoChanges)
+
+	// a change of the protocol preference must be updated
+	desired[0].Targets = endpoint.Targets{"1 . ipv4hint=1.2.3.4,5.6.7.8 port=443 alpn=h2,h3"}
+	changes = p.Calculate().Changes
+	validateEntries(suite.T(), changes.UpdateNew, desired)
+	validateEntries(suite.T(), changes.UpdateOld, current)
+}
+
+// TestHTTPSRecordsBesideAddresses checks that HTTPS records are created beside A records, while a conflicting
+// CNAME is preferred over the HTTPS record, since the HTTPS record alone would leave the domain without addresses.
+func (suite *PlanTestSuite) TestHTTPSRecordsBesideAddresses() {
+	https := &endpoint.Endpoint{
+		DNSName:    "foo",
+		Targets:    endpoint.Targets{"1 . alpn=h2,h3"},
+		RecordType: endpoint.RecordTypeHTTPS,
+		Labels: map[string]string{
+			endpoint.ResourceLabelKey: "httproute/default/foo",
+		},
+	}
+	expectNoChanges := []*endpoint.Endpoint{}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{},
+		Desired:        []*endpoint.Endpoint{suite.fooA5, https},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME, endpoint.RecordTypeHTTPS},
+	}
+	changes := p.Calculate().Changes
+	validateEntries(suite.T(), changes.Create, []*endpoint.Endpoint{suite.fooA5, https})
+
+	p.Desired = []*endpoint.Endpoint{suite.fooV1Cname, https}
+	changes = p.Calculate().Changes
+	validateEntries(suite.T(), changes.Create, []*endpoint.Endpoint{suite.fooV1Cname})
+	validateEntries(suite.T(), changes.Delete, expectNoChanges)
+}
@@ -16,11 +16,28 @@ limitations under the License.
 
 package plan
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
 // Policy allows to apply different rules to a set of changes.
 type Policy interface {
 	Apply(changes *Changes) *Changes
 }
 
+// planPolicy is implemented by the policies which need the plan the changes are calculated for.
+type planPolicy interface {
+	applyToPlan(p *Plan, changes *Changes) *Changes
+}
+
 // Policies is a registry of available policies.
 var Policies = map[string]Policy{
 	"sync":        &SyncPolicy{},
@@ -57,3 +74,183 @@ func (p *CreateOnlyPolicy) Apply(changes *Changes) *Changes {
 		Create: changes.Create,
 	}
 }
+
+// DeletionGracePolicy delays the deletion of records which are no longer desired by a grace period, so that
+// the records of resources which are deleted and recreated, e.g. during a blue/green rollout, are not deleted
+// and recreated with them. Instead of being deleted, a record is updated with the DeletionPendingLabelKey
+// label, which the registry stores, and it is deleted once the grace period has passed since. A record which
+// is desired again is updated without the label.
+type DeletionGracePolicy struct {
+	// GracePeriod is the time a record is kept after it is no longer desired.
+	GracePeriod time.Duration
+
+	now     func() time.Time
+	pending int
+}
+
+// Apply applies the grace period to the deletions. Records desired again keep their label, since the
+// current records are not known without the plan.
+func (g *DeletionGracePolicy) Apply(changes *Changes) *Changes {
+	return g.delay(changes, nil)
+}
+
+func (g *DeletionGracePolicy) applyToPlan(p *Plan, changes *Changes) *Changes {
+	return g.delay(changes, p.ownedCurrent())
+}
+
+// Pending returns the number of records of the last plan whose deletion is delayed.
+func (g *DeletionGracePolicy) Pending() int {
+	return g.pending
+}
+
+func (g *DeletionGracePolicy) delay(changes *Changes, owned []*endpoint.Endpoint) *Changes {
+	now := time.Now()
+	if g.now != nil {
+		now = g.now()
+	}
+	delayed := &Changes{
+		Create:    changes.Create,
+		UpdateOld: slices.Clone(changes.UpdateOld),
+		UpdateNew: slices.Clone(changes.UpdateNew),
+	}
+	g.pending = 0
+
+	deleted := map[endpoint.EndpointKey]bool{}
+	for _, ep := range changes.Delete {
+		deleted[ep.Key()] = true
+		since, err := time.Parse(time.RFC3339, ep.Labels[endpoint.DeletionPendingLabelKey])
+		switch {
+		case err != nil:
+			pending := ep.DeepCopy()
+			if pending.Labels == nil {
+				pending.Labels = endpoint.NewLabels()
+			}
+			pending.Labels[endpoint.DeletionPendingLabelKey] = now.UTC().Format(time.RFC3339)
+			delayed.UpdateOld = append(delayed.UpdateOld, ep)
+			delayed.UpdateNew = append(delayed.UpdateNew, pending)
+			g.pending++
+			log.Infof("Delaying the deletion of %s record %s by %s", ep.RecordType, ep.DNSName, g.GracePeriod)
+		case now.Sub(since) < g.GracePeriod:
+			g.pending++
+			log.Debugf("Delaying the deletion of %s record %s until %s", ep.RecordType, ep.DNSName, since.Add(g.GracePeriod).Format(time.RFC3339))
+		default:
+			delayed.Delete = append(delayed.Delete, ep)
+		}
+	}
+
+	updated := map[endpoint.EndpointKey]bool{}
+	for _, ep := range changes.UpdateOld {
+		updated[ep.Key()] = true
+	}
+	for _, current := range owned {
+		if _, ok := current.Labels[endpoint.DeletionPendingLabelKey]; !ok || deleted[current.Key()] || updated[current.Key()] {
+			continue
+		}
+		desired := current.DeepCopy()
+		delete(desired.Labels, endpoint.DeletionPendingLabelKey)
+		delayed.UpdateOld = append(delayed.UpdateOld, current)
+		delayed.UpdateNew = append(delayed.UpdateNew, desired)
+		log.Infof("Canceling the pending deletion of %s record %s, it is desired again", current.RecordType, current.DNSName)
+	}
+	return delayed
+}
+
+// DeletionGuard is a policy which holds back the deletions of a plan when there are more of them than
+// a maximum number, or than a maximum percentage of the records currently owned by the plan's owner,
+// e.g. when a broken source returns no endpoints. Held back deletions are applied once the same
+// deletions have been planned by a number of consecutive plans, or once they are approved.
+// The creations and updates of the plan are not held back.
+//
+// A DeletionGuard remembers the deletions of the previous plan, it must only be used by the plans of
+// a single owner and provider.
+type DeletionGuard struct {
+	// MaxDeletions is the maximum number of deletions of a plan, zero for no maximum.
+	MaxDeletions int
+	// MaxDeletionsPercent is the maximum percentage of the owned records deleted by a plan, zero for no maximum.
+	MaxDeletionsPercent int
+	// Confirmations is the number of consecutive plans with the same deletions after which they are applied,
+	// zero to apply them only once approved.
+	Confirmations int
+	// Approved tells whether the deletions with the given fingerprint are approved, if set.
+	Approved func(fingerprint string) bool
+
+	fingerprint string
+	plans       int
+	held        int
+}
+
+// Apply applies the deletion guard to the changes. Only the maximum number of deletions is
+// considered, since the owned records are not known without the plan.
+func (g *DeletionGuard) Apply(changes *Changes) *Changes {
+	return g.guard(changes, nil)
+}
+
+func (g *DeletionGuard) applyToPlan(p *Plan, changes *Changes) *Changes {
+	return g.guard(changes, func() int { return len(p.ownedCurrent()) })
+}
+
+// Held returns the number of deletions held back from the last plan.
+func (g *DeletionGuard) Held() int {
+	return g.held
+}
+
+func (g *DeletionGuard) guard(changes *Changes, owned func() int) *Changes {
+	g.held = 0
+	deletions := len(changes.Delete)
+	if deletions == 0 || !g.exceeded(deletions, owned) {
+		g.fingerprint, g.plans = "", 0
+		return changes
+	}
+
+	fingerprint := deletionsFingerprint(changes.Delete)
+	if fingerprint == g.fingerprint {
+		g.plans++
+	} else {
+		g.fingerprint, g.plans = fingerprint, 1
+	}
+
+	if g.Confirmations > 0 && g.plans >= g.Confirmations {
+		log.Warnf("Applying %d deletions %s, they have been planned by %d consecutive plans", deletions, fingerprint, g.plans)
+		g.fingerprint, g.plans = "", 0
+		return changes
+	}
+	if g.Approved != nil && g.Approved(fingerprint) {
+		log.Warnf("Applying %d deletions %s, they have been approved", deletions, fingerprint)
+		g.fingerprint, g.plans = "", 0
+		return changes
+	}
+
+	g.held = deletions
+	if g.Confirmations > 0 {
+		log.Warnf("Holding back %d deletions %s exceeding the deletion limits, they are applied once approved or planned by %d consecutive plans (%d so far)", deletions, fingerprint, g.Confirmations, g.plans)
+	} else {
+		log.Warnf("Holding back %d deletions %s exceeding the deletion limits, they are applied once approved", deletions, fingerprint)
+	}
+	for _, ep := range changes.Delete {
+		log.Debugf("Holding back deletion of %v", ep)
+	}
+	return &Changes{
+		Create:    changes.Create,
+		UpdateOld: changes.UpdateOld,
+		UpdateNew: changes.UpdateNew,
+	}
+}
+
+// exceeded tells whether the number of deletions exceeds the limits of the guard.
+func (g *DeletionGuard) exceeded(deletions int, owned func() int) bool {
+	if g.MaxDeletions > 0 && deletions > g.MaxDeletions {
+		return true
+	}
+	return g.MaxDeletionsPercent > 0 && owned != nil && deletions*100 > g.MaxDeletionsPercent*owned()
+}
+
+// deletionsFingerprint identifies a set of deleted records, whatever their order.
+func deletionsFingerprint(deletions []*endpoint.Endpoint) string {
+	keys := make([]string, 0, len(deletions))
+	for _, ep := range deletions {
+		keys = append(keys, ep.DNSName+" "+ep.RecordType+" "+ep.SetIdentifier)
+	}
+	slices.Sort(keys)
+	sum := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+	return hex.EncodeToString(sum[:6])
+}
@@ -17,8 +17,13 @@ limitations under the License.
 package plan
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/endpoint"
 )
@@ -86,3 +91,156 @@ func validatePolicy(t *testing.T, policy, expected Policy) {
 		t.Errorf("expected %q to match %q", policyType, expectedType)
 	}
 }
+
+// TestDeletionGracePolicy tests that deletions are delayed by the grace period through the pending label.
+func TestDeletionGracePolicy(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	policy := &DeletionGracePolicy{GracePeriod: 10 * time.Minute, now: func() time.Time { return now }}
+	calculate := func(current, desired []*endpoint.Endpoint) *Changes {
+		p := &Plan{
+			Current:        current,
+			Desired:        desired,
+			Policies:       []Policy{&SyncPolicy{}, policy},
+			ManagedRecords: []string{endpoint.RecordTypeA},
+			OwnerID:        "owner",
+		}
+		return p.Calculate().Changes
+	}
+	record := func(dnsName string, labels map[string]string) *endpoint.Endpoint {
+		ep := endpoint.NewEndpoint(dnsName, endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "owner")
+		for k, v := range labels {
+			ep.Labels[k] = v
+		}
+		return ep
+	}
+	pendingSince := func(d time.Duration) map[string]string {
+		return map[string]string{endpoint.DeletionPendingLabelKey: now.Add(-d).Format(time.RFC3339)}
+	}
+
+	// a record no longer desired is labeled instead of deleted
+	changes := calculate([]*endpoint.Endpoint{record("app.example.com", nil)}, nil)
+	assert.Empty(t, changes.Delete)
+	require.Len(t, changes.UpdateNew, 1)
+	assert.Equal(t, "2025-06-01T12:00:00Z", changes.UpdateNew[0].Labels[endpoint.DeletionPendingLabelKey])
+	assert.Empty(t, changes.UpdateOld[0].Labels[endpoint.DeletionPendingLabelKey])
+	assert.Equal(t, 1, policy.Pending())
+
+	// a pending record is kept during the grace period, and deleted after it
+	changes = calculate([]*endpoint.Endpoint{record("app.example.com", pendingSince(9*time.Minute))}, nil)
+	assert.False(t, changes.HasChanges())
+	assert.Equal(t, 1, policy.Pending())
+	changes = calculate([]*endpoint.Endpoint{record("app.example.com", pendingSince(10*time.Minute))}, nil)
+	assert.Len(t, changes.Delete, 1)
+	assert.Empty(t, changes.UpdateNew)
+	assert.Zero(t, policy.Pending())
+
+	// a pending record desired again is updated without the label
+	desired := []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")}
+	changes = calculate([]*endpoint.Endpoint{record("app.example.com", pendingSince(time.Minute))}, desired)
+	assert.Empty(t, changes.Delete)
+	require.Len(t, changes.UpdateNew, 1)
+	assert.NotContains(t, changes.UpdateNew[0].Labels, endpoint.DeletionPendingLabelKey)
+	assert.Contains(t, changes.UpdateOld[0].Labels, endpoint.DeletionPendingLabelKey)
+	assert.Zero(t, policy.Pending())
+
+	// with other targets, the update of the targets removes the label
+	desired = []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.5")}
+	changes = calculate([]*endpoint.Endpoint{record("app.example.com", pendingSince(time.Minute))}, desired)
+	require.Len(t, changes.UpdateNew, 1)
+	assert.Equal(t, endpoint.Targets{"1.2.3.5"}, changes.UpdateNew[0].Targets)
+	assert.NotContains(t, changes.UpdateNew[0].Labels, endpoint.DeletionPendingLabelKey)
+
+	// an invalid label starts the grace period again
+	changes = calculate([]*endpoint.Endpoint{record("app.example.com", map[string]string{endpoint.DeletionPendingLabelKey: "yesterday"})}, nil)
+	assert.Empty(t, changes.Delete)
+	require.Len(t, changes.UpdateNew, 1)
+	assert.Equal(t, "2025-06-01T12:00:00Z", changes.UpdateNew[0].Labels[endpoint.DeletionPendingLabelKey])
+}
+
+// TestDeletionGuard tests that deletions exceeding the limits are held back until confirmed or approved.
+func TestDeletionGuard(t *testing.T) {
+	var current []*endpoint.Endpoint
+	for i := range 10 {
+		current = append(current, endpoint.NewEndpoint(fmt.Sprintf("app-%d.example.com", i), endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.OwnerLabelKey, "owner"))
+	}
+	// records of other owners are not counted
+	for i := range 10 {
+		current = append(current, endpoint.NewEndpoint(fmt.Sprintf("other-%d.example.com", i), endpoint.RecordTypeA, "1.2.3.4").
+			WithLabel(endpoint.OwnerLabelKey, "other"))
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app-0.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+		endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.6"),
+	}
+	calculate := func(guard *DeletionGuard, desired []*endpoint.Endpoint) *Changes {
+		p := &Plan{
+			Current:        current,
+			Desired:        desired,
+			Policies:       []Policy{&SyncPolicy{}, guard},
+			ManagedRecords: []string{endpoint.RecordTypeA},
+			OwnerID:        "owner",
+		}
+		return p.Calculate().Changes
+	}
+
+	// 9 of 10 owned records are deleted
+	guard := &DeletionGuard{MaxDeletionsPercent: 50, Confirmations: 3}
+	for range 2 {
+		changes := calculate(guard, desired)
+		assert.Len(t, changes.Create, 1)
+		assert.Len(t, changes.UpdateNew, 1)
+		assert.Empty(t, changes.Delete)
+		assert.Equal(t, 9, guard.Held())
+	}
+	// other deletions start counting again
+	changes := calculate(guard, append(desired, endpoint.NewEndpoint("app-1.example.com", endpoint.RecordTypeA, "1.2.3.4")))
+	assert.Empty(t, changes.Delete)
+	assert.Equal(t, 8, guard.Held())
+	assert.Equal(t, 1, guard.plans)
+	// the third consecutive plan applies the deletions
+	calculate(guard, desired)
+	calculate(guard, desired)
+	changes = calculate(guard, desired)
+	assert.Len(t, changes.Delete, 9)
+	assert.Zero(t, guard.Held())
+
+	// deletions within the limits are applied
+	guard = &DeletionGuard{MaxDeletions: 9}
+	changes = calculate(guard, desired)
+	assert.Len(t, changes.Delete, 9)
+	guard = &DeletionGuard{MaxDeletionsPercent: 90}
+	changes = calculate(guard, desired)
+	assert.Len(t, changes.Delete, 9)
+
+	// approved deletions are applied
+	var approved []string
+	guard = &DeletionGuard{MaxDeletions: 5, Approved: func(fingerprint string) bool {
+		approved = append(approved, fingerprint)
+		return len(approved) > 1
+	}}
+	changes = calculate(guard, desired)
+	assert.Empty(t, changes.Delete)
+	changes = calculate(guard, desired)
+	assert.Len(t, changes.Delete, 9)
+	assert.Len(t, approved, 2)
+	assert.Equal(t, approved[0], approved[1])
+	assert.Equal(t, deletionsFingerprint(changes.Delete), approved[0])
+
+	// without the plan, only the maximum number of deletions is considered
+	guard = &DeletionGuard{MaxDeletions: 1, MaxDeletionsPercent: 1}
+	changes = guard.Apply(&Changes{Delete: current[:1]})
+	assert.Len(t, changes.Delete, 1)
+	changes = guard.Apply(&Changes{Delete: current[:2]})
+	assert.Empty(t, changes.Delete)
+}
+
+func TestDeletionsFingerprint(t *testing.T) {
+	foo := endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	bar := endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	barAAAA := endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeAAAA, "::1")
+
+	assert.Equal(t, deletionsFingerprint([]*endpoint.Endpoint{foo, bar}), deletionsFingerprint([]*endpoint.Endpoint{bar, foo}))
+	assert.NotEqual(t, deletionsFingerprint([]*endpoint.Endpoint{foo, bar}), deletionsFingerprint([]*endpoint.Endpoint{foo, barAAAA}))
+	assert.Len(t, deletionsFingerprint([]*endpoint.Endpoint{foo}), 12)
+}
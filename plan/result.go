@@ -0,0 +1,287 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Outcome describes what a reconciliation did to a single record.
+type Outcome string
+
+const (
+	// OutcomeCreated means the record was created in the provider.
+	OutcomeCreated Outcome = "Created"
+	// OutcomeUpdated means the record was updated in the provider.
+	OutcomeUpdated Outcome = "Updated"
+	// OutcomeDeleted means the record was deleted from the provider.
+	OutcomeDeleted Outcome = "Deleted"
+	// OutcomeUnchanged means the record already matched the desired state.
+	OutcomeUnchanged Outcome = "Unchanged"
+	// OutcomeConflicted means the record is owned by another owner or was claimed by another resource.
+	OutcomeConflicted Outcome = "Conflicted"
+	// OutcomeSkipped means the change was dropped by the policy.
+	OutcomeSkipped Outcome = "Skipped"
+	// OutcomeIgnored means the record is not managed by this instance, because of the
+	// domain filter or the managed record types.
+	OutcomeIgnored Outcome = "Ignored"
+	// OutcomeFailed means the provider rejected the changes.
+	OutcomeFailed Outcome = "Failed"
+)
+
+// Result is the outcome of a reconciliation for a single record.
+type Result struct {
+	// Endpoint is the desired record, or the current record for deletions.
+	Endpoint *endpoint.Endpoint
+	// Live is the record as it exists in the provider after the reconciliation, nil if none exists.
+	Live *endpoint.Endpoint
+	// Outcome is what happened to the record.
+	Outcome Outcome
+	// Message is a human readable explanation of the outcome.
+	Message string
+}
+
+// resultKey identifies a single record set independently of its targets.
+type resultKey struct {
+	dnsName       string
+	recordType    string
+	setIdentifier string
+}
+
+func newResultKey(e *endpoint.Endpoint) resultKey {
+	return resultKey{
+		dnsName:       normalizeDNSName(e.DNSName),
+		recordType:    e.RecordType,
+		setIdentifier: e.SetIdentifier,
+	}
+}
+
+// Results reports what the given changes did to every desired record and to every
+// deleted record. It must be called on the Plan the changes were calculated from,
+// with the error returned when the changes were submitted, nil if they were applied.
+func (p *Plan) Results(changes *Changes, applyErr error) []*Result {
+	if changes == nil {
+		changes = &Changes{}
+	}
+	domainFilter := p.DomainFilter
+	if domainFilter == nil {
+		domainFilter = endpoint.MatchAllDomainFilters(nil)
+	}
+
+	current := map[resultKey]*endpoint.Endpoint{}
+	for _, ep := range p.Current {
+		current[newResultKey(ep)] = ep
+	}
+	created := map[resultKey]*endpoint.Endpoint{}
+	for _, ep := range changes.Create {
+		created[newResultKey(ep)] = ep
+	}
+	updated := map[resultKey]*endpoint.Endpoint{}
+	for _, ep := range changes.UpdateNew {
+		updated[newResultKey(ep)] = ep
+	}
+
+	candidates := map[resultKey][]*endpoint.Endpoint{}
+	for _, ep := range p.Desired {
+		key := newResultKey(ep)
+		candidates[key] = append(candidates[key], ep)
+	}
+	refused := p.refused()
+
+	results := make([]*Result, 0, len(p.Desired)+len(changes.Delete))
+	for _, desired := range p.Desired {
+		if !domainFilter.Match(desired.DNSName) || !IsManagedRecord(desired.RecordType, p.ManagedRecords, p.ExcludeRecords) {
+			results = append(results, &Result{
+				Endpoint: desired,
+				Outcome:  OutcomeIgnored,
+				Message:  fmt.Sprintf("%s record %s is not managed by this instance", desired.RecordType, desired.DNSName),
+			})
+			continue
+		}
+
+		if reason, ok := p.rejected(desired); ok {
+			results = append(results, &Result{
+				Endpoint: desired,
+				Outcome:  OutcomeConflicted,
+				Message:  reason,
+			})
+			continue
+		}
+
+		key := newResultKey(desired)
+		cur := current[key]
+
+		if resources, ok := refused[planKey{dnsName: key.dnsName, setIdentifier: key.setIdentifier}]; ok {
+			results = append(results, &Result{
+				Endpoint: desired,
+				Live:     cur,
+				Outcome:  OutcomeConflicted,
+				Message:  fmt.Sprintf("record is claimed by several resources: %s", strings.Join(resources, ", ")),
+			})
+			continue
+		}
+
+		if ep, ok := created[key]; ok {
+			results = append(results, p.changedResult(desired, ep, nil, OutcomeCreated, applyErr))
+			continue
+		}
+		if ep, ok := updated[key]; ok {
+			results = append(results, p.changedResult(desired, ep, cur, OutcomeUpdated, applyErr))
+			continue
+		}
+
+		results = append(results, p.unchangedResult(desired, cur, candidates[key]))
+	}
+
+	for _, ep := range changes.Delete {
+		result := &Result{Endpoint: ep, Outcome: OutcomeDeleted}
+		if applyErr != nil {
+			result.Live = ep
+			result.Outcome = OutcomeFailed
+			result.Message = fmt.Sprintf("provider rejected change: %v", applyErr)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// changedResult returns the result of a desired record whose record set is part of the changes.
+// Another resource may have won the record set, in which case the desired record is conflicted,
+// unless the record set was merged from the targets of the desired record and other resources.
+func (p *Plan) changedResult(desired, change, cur *endpoint.Endpoint, outcome Outcome, applyErr error) *Result {
+	if change != desired && !mergedFrom(change, desired) {
+		return &Result{
+			Endpoint: desired,
+			Live:     cur,
+			Outcome:  OutcomeConflicted,
+			Message:  fmt.Sprintf("record is claimed by %s", resourceOf(change)),
+		}
+	}
+	if applyErr != nil {
+		return &Result{
+			Endpoint: desired,
+			Live:     cur,
+			Outcome:  OutcomeFailed,
+			Message:  fmt.Sprintf("provider rejected change: %v", applyErr),
+		}
+	}
+	return &Result{Endpoint: desired, Live: change, Outcome: outcome}
+}
+
+// unchangedResult returns the result of a desired record whose record set is not part of the changes.
+// candidates are all desired records for the same record set.
+func (p *Plan) unchangedResult(desired, cur *endpoint.Endpoint, candidates []*endpoint.Endpoint) *Result {
+	if cur == nil {
+		// the create was dropped because the name is owned by another owner, e.g. through a record of another type
+		for _, other := range p.Current {
+			if normalizeDNSName(other.DNSName) == normalizeDNSName(desired.DNSName) && p.OwnerID != "" && !other.IsOwnedBy(p.OwnerID) {
+				return &Result{
+					Endpoint: desired,
+					Outcome:  OutcomeConflicted,
+					Message:  fmt.Sprintf("%s is owned by another owner %q", desired.DNSName, other.Labels[endpoint.OwnerLabelKey]),
+				}
+			}
+		}
+		return &Result{
+			Endpoint: desired,
+			Outcome:  OutcomeConflicted,
+			Message:  "record was not scheduled for creation because of a conflicting record",
+		}
+	}
+
+	if p.OwnerID != "" && !cur.IsOwnedBy(p.OwnerID) {
+		return &Result{
+			Endpoint: desired,
+			Live:     cur,
+			Outcome:  OutcomeConflicted,
+			Message:  fmt.Sprintf("owned by another owner %q", cur.Labels[endpoint.OwnerLabelKey]),
+		}
+	}
+
+	if !targetChanged(desired, cur) && !shouldUpdateTTL(desired, cur) {
+		return &Result{Endpoint: desired, Live: cur, Outcome: OutcomeUnchanged}
+	}
+	if mergedFrom(cur, desired) {
+		return &Result{Endpoint: desired, Live: cur, Outcome: OutcomeUnchanged}
+	}
+
+	// the record set may already be live for another resource competing for it
+	for _, other := range candidates {
+		if other == desired {
+			continue
+		}
+		resource := cur.Labels[endpoint.ResourceLabelKey]
+		if (resource != "" && other.Labels[endpoint.ResourceLabelKey] == resource) || !targetChanged(other, cur) {
+			return &Result{
+				Endpoint: desired,
+				Live:     cur,
+				Outcome:  OutcomeConflicted,
+				Message:  fmt.Sprintf("record is claimed by %s", resourceOf(other)),
+			}
+		}
+	}
+
+	return &Result{
+		Endpoint: desired,
+		Live:     cur,
+		Outcome:  OutcomeSkipped,
+		Message:  "update is not permitted by the policy",
+	}
+}
+
+// rejected returns the reason if the conflict resolver rejects the desired record.
+func (p *Plan) rejected(desired *endpoint.Endpoint) (string, bool) {
+	if rejecter, ok := p.resolver().(candidateRejecter); ok {
+		return rejecter.reject(desired)
+	}
+	return "", false
+}
+
+// refused returns the resources claiming the DNS names which the conflict resolver refused to decide on.
+func (p *Plan) refused() map[planKey][]string {
+	refuser, ok := p.resolver().(conflictRefuser)
+	if !ok {
+		return nil
+	}
+	domainFilter := p.DomainFilter
+	if domainFilter == nil {
+		domainFilter = endpoint.MatchAllDomainFilters(nil)
+	}
+	rows := map[planKey][]*endpoint.Endpoint{}
+	for _, ep := range p.candidates(domainFilter) {
+		key := planKey{dnsName: normalizeDNSName(ep.DNSName), setIdentifier: ep.SetIdentifier}
+		rows[key] = append(rows[key], ep)
+	}
+	refused := map[planKey][]string{}
+	for key, candidates := range rows {
+		if resources, ok := refuser.refuse(candidates); ok {
+			refused[key] = resources
+		}
+	}
+	return refused
+}
+
+func resourceOf(e *endpoint.Endpoint) string {
+	if resource := e.Labels[endpoint.ResourceLabelKey]; resource != "" {
+		return resource
+	}
+	return "another resource"
+}
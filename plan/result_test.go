@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func newResultEndpoint(dnsName, recordType, resource, owner string, targets ...string) *endpoint.Endpoint {
+	ep := endpoint.NewEndpoint(dnsName, recordType, targets...)
+	if resource != "" {
+		ep.WithLabel(endpoint.ResourceLabelKey, resource)
+	}
+	if owner != "" {
+		ep.WithLabel(endpoint.OwnerLabelKey, owner)
+	}
+	return ep
+}
+
+func resultsByResource(results []*Result) map[string]*Result {
+	byResource := map[string]*Result{}
+	for _, r := range results {
+		byResource[r.Endpoint.Labels[endpoint.ResourceLabelKey]+"|"+r.Endpoint.DNSName] = r
+	}
+	return byResource
+}
+
+func TestPlanResults(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		newResultEndpoint("unchanged.example.com", endpoint.RecordTypeA, "ingress/default/unchanged", "owner", "1.1.1.1"),
+		newResultEndpoint("update.example.com", endpoint.RecordTypeA, "ingress/default/update", "owner", "1.1.1.1"),
+		newResultEndpoint("foreign.example.com", endpoint.RecordTypeA, "ingress/other/foreign", "other", "1.1.1.1"),
+		newResultEndpoint("delete.example.com", endpoint.RecordTypeA, "ingress/default/delete", "owner", "1.1.1.1"),
+	}
+	desired := []*endpoint.Endpoint{
+		newResultEndpoint("unchanged.example.com", endpoint.RecordTypeA, "ingress/default/unchanged", "", "1.1.1.1"),
+		newResultEndpoint("update.example.com", endpoint.RecordTypeA, "ingress/default/update", "", "2.2.2.2"),
+		newResultEndpoint("foreign.example.com", endpoint.RecordTypeA, "ingress/default/foreign", "", "2.2.2.2"),
+		newResultEndpoint("create.example.com", endpoint.RecordTypeA, "ingress/default/a", "", "3.3.3.3"),
+		newResultEndpoint("create.example.com", endpoint.RecordTypeA, "ingress/default/b", "", "4.4.4.4"),
+		newResultEndpoint("text.example.com", endpoint.RecordTypeTXT, "ingress/default/text", "", "text"),
+		newResultEndpoint("filtered.example.org", endpoint.RecordTypeA, "ingress/default/filtered", "", "5.5.5.5"),
+	}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		DomainFilter:   endpoint.MatchAllDomainFilters{endpoint.NewDomainFilter([]string{"example.com"})},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		OwnerID:        "owner",
+	}
+	changes := p.Calculate().Changes
+
+	t.Run("applied", func(t *testing.T) {
+		results := resultsByResource(p.Results(changes, nil))
+		require.Len(t, results, 8)
+
+		assert.Equal(t, OutcomeUnchanged, results["ingress/default/unchanged|unchanged.example.com"].Outcome)
+		assert.Equal(t, OutcomeUpdated, results["ingress/default/update|update.example.com"].Outcome)
+		assert.Equal(t, endpoint.Targets{"2.2.2.2"}, results["ingress/default/update|update.example.com"].Live.Targets)
+
+		foreign := results["ingress/default/foreign|foreign.example.com"]
+		assert.Equal(t, OutcomeConflicted, foreign.Outcome)
+		assert.Contains(t, foreign.Message, `"other"`)
+
+		assert.Equal(t, OutcomeCreated, results["ingress/default/a|create.example.com"].Outcome)
+		loser := results["ingress/default/b|create.example.com"]
+		assert.Equal(t, OutcomeConflicted, loser.Outcome)
+		assert.Contains(t, loser.Message, "ingress/default/a")
+
+		assert.Equal(t, OutcomeIgnored, results["ingress/default/text|text.example.com"].Outcome)
+		assert.Equal(t, OutcomeIgnored, results["ingress/default/filtered|filtered.example.org"].Outcome)
+
+		deleted := results["ingress/default/delete|delete.example.com"]
+		assert.Equal(t, OutcomeDeleted, deleted.Outcome)
+		assert.Nil(t, deleted.Live)
+	})
+
+	t.Run("rejected by provider", func(t *testing.T) {
+		results := resultsByResource(p.Results(changes, errors.New("throttled")))
+
+		assert.Equal(t, OutcomeUnchanged, results["ingress/default/unchanged|unchanged.example.com"].Outcome)
+		for _, key := range []string{
+			"ingress/default/update|update.example.com",
+			"ingress/default/a|create.example.com",
+			"ingress/default/delete|delete.example.com",
+		} {
+			assert.Equal(t, OutcomeFailed, results[key].Outcome, key)
+			assert.Contains(t, results[key].Message, "throttled", key)
+		}
+		assert.Equal(t, endpoint.Targets{"1.1.1.1"}, results["ingress/default/update|update.example.com"].Live.Targets)
+		assert.Nil(t, results["ingress/default/a|create.example.com"].Live)
+	})
+
+	t.Run("update dropped by policy", func(t *testing.T) {
+		createOnly := *p
+		createOnly.Policies = []Policy{&CreateOnlyPolicy{}}
+		results := resultsByResource(createOnly.Results(createOnly.Calculate().Changes, nil))
+
+		assert.Equal(t, OutcomeSkipped, results["ingress/default/update|update.example.com"].Outcome)
+		assert.Equal(t, OutcomeCreated, results["ingress/default/a|create.example.com"].Outcome)
+	})
+}
+
+func TestPlanResultsWithConflictResolver(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		newResultEndpoint("shared.example.com", endpoint.RecordTypeA, "ingress/team-a/a", "owner", "1.1.1.1"),
+	}
+	desired := []*endpoint.Endpoint{
+		newResultEndpoint("shared.example.com", endpoint.RecordTypeA, "ingress/team-a/a", "", "2.2.2.2"),
+		newResultEndpoint("shared.example.com", endpoint.RecordTypeA, "ingress/team-a/b", "", "3.3.3.3"),
+		newResultEndpoint("app.example.com", endpoint.RecordTypeA, "ingress/team-a/app", "", "4.4.4.4").
+			WithLabel(endpoint.ResourceCreatedLabelKey, "2024-01-01T00:00:00Z").
+			WithLabel(endpoint.ResourcePriorityLabelKey, "1"),
+		newResultEndpoint("other.example.com", endpoint.RecordTypeA, "ingress/team-z/other", "", "5.5.5.5"),
+	}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "owner",
+		Resolver: NamespaceAllowList{
+			Namespaces: map[string][]string{"example.com": {"team-a"}},
+			Resolver:   RefuseConflicts{},
+		},
+	}
+	calculated := p.Calculate()
+
+	// the conflicting record is left alone
+	assert.Empty(t, calculated.Changes.UpdateNew)
+	assert.Empty(t, calculated.Changes.Delete)
+	require.Len(t, calculated.Conflicts, 1)
+	assert.Equal(t, &Conflict{DNSName: "shared.example.com", Resources: []string{"ingress/team-a/a", "ingress/team-a/b"}}, calculated.Conflicts[0])
+
+	// the resource metadata is not stored with the records
+	require.Len(t, calculated.Changes.Create, 1)
+	assert.Equal(t, "app.example.com", calculated.Changes.Create[0].DNSName)
+	assert.NotContains(t, calculated.Changes.Create[0].Labels, endpoint.ResourceCreatedLabelKey)
+	assert.NotContains(t, calculated.Changes.Create[0].Labels, endpoint.ResourcePriorityLabelKey)
+
+	results := resultsByResource(p.Results(calculated.Changes, nil))
+	require.Len(t, results, 4)
+	for _, key := range []string{"ingress/team-a/a|shared.example.com", "ingress/team-a/b|shared.example.com"} {
+		assert.Equal(t, OutcomeConflicted, results[key].Outcome, key)
+		assert.Equal(t, "record is claimed by several resources: ingress/team-a/a, ingress/team-a/b", results[key].Message, key)
+	}
+	assert.Equal(t, OutcomeCreated, results["ingress/team-a/app|app.example.com"].Outcome)
+	other := results["ingress/team-z/other|other.example.com"]
+	assert.Equal(t, OutcomeConflicted, other.Outcome)
+	assert.Equal(t, "namespace team-z may not claim names in example.com", other.Message)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleHorizon is how far ahead the next time of a schedule is searched.
+const scheduleHorizon = 366 * 24 * time.Hour
+
+// Schedule is a cron schedule of five fields: minute, hour, day of month, month and day of week.
+// Every field is *, a value, a range a-b, a step */n or a-b/n, or a comma separated list of them.
+// Days of week are 0 to 7, both 0 and 7 are Sunday. Like with cron, when both the day of month and
+// the day of week are restricted, a time matches either of them.
+type Schedule struct {
+	spec                                   string
+	minutes, hours, days, months, weekdays uint64
+	anyDay, anyWeekday                     bool
+}
+
+// ParseSchedule parses a cron schedule of five fields, e.g. "0 2 * * 6" for Saturdays at 02:00.
+func ParseSchedule(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+	s := &Schedule{
+		spec:       strings.Join(fields, " "),
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+	for i, field := range []struct {
+		bits     *uint64
+		min, max int
+	}{
+		{&s.minutes, 0, 59},
+		{&s.hours, 0, 23},
+		{&s.days, 1, 31},
+		{&s.months, 1, 12},
+		{&s.weekdays, 0, 7},
+	} {
+		bits, err := parseScheduleField(fields[i], field.min, field.max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		*field.bits = bits
+	}
+	// Sunday is 0 and 7
+	if s.weekdays&(1<<7) != 0 {
+		s.weekdays |= 1
+	}
+	return s, nil
+}
+
+// parseScheduleField returns the bits of the values of a field.
+func parseScheduleField(field string, minValue, maxValue int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		values, step, hasStep := strings.Cut(part, "/")
+		first, last := minValue, maxValue
+		if values != "*" {
+			from, to, isRange := strings.Cut(values, "-")
+			var err error
+			if first, err = strconv.Atoi(from); err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			last = first
+			if isRange {
+				if last, err = strconv.Atoi(to); err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			} else if hasStep {
+				last = maxValue
+			}
+		}
+		if first < minValue || last > maxValue || first > last {
+			return 0, fmt.Errorf("value %q out of range %d-%d", part, minValue, maxValue)
+		}
+		increment := 1
+		if hasStep {
+			var err error
+			if increment, err = strconv.Atoi(step); err != nil || increment < 1 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+		}
+		for v := first; v <= last; v += increment {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// String returns the schedule as parsed.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Matches tells whether the minute of t is a time of the schedule.
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.minutes&(1<<t.Minute()) != 0 && s.hours&(1<<t.Hour()) != 0 && s.months&(1<<int(t.Month())) != 0 && s.matchesDay(t)
+}
+
+// matchesDay tells whether the day of t is a day of the schedule, by its day of month or day of week.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	day := s.days&(1<<t.Day()) != 0
+	weekday := s.weekdays&(1<<int(t.Weekday())) != 0
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// Next returns the first time of the schedule after t, at the start of its minute, or the zero time
+// if there is none within a year, e.g. for February 30. Months, days and hours which do not match are
+// skipped as a whole, so that a time far ahead is found in a few hundred steps.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	end := t.Add(scheduleHorizon)
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	for next.Before(end) {
+		switch {
+		case s.months&(1<<int(next.Month())) == 0:
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, loc)
+		case s.hours&(1<<next.Hour()) == 0:
+			// added rather than set, so that an hour repeated at the end of daylight saving time is not skipped
+			next = next.Add(time.Duration(60-next.Minute()) * time.Minute)
+		case s.minutes&(1<<next.Minute()) == 0:
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	for _, tt := range []struct {
+		spec    string
+		wantErr string
+	}{
+		{spec: "* * * * *"},
+		{spec: "0 2 * * 6"},
+		{spec: "*/15 1-5 1,15 */2 1-5/2"},
+		{spec: "30 22 * * 7"},
+		{spec: "0 2 * *", wantErr: "expected 5 fields"},
+		{spec: "60 2 * * *", wantErr: "out of range"},
+		{spec: "0 2 0 * *", wantErr: "out of range"},
+		{spec: "0 5-2 * * *", wantErr: "out of range"},
+		{spec: "0 2 * * mon", wantErr: "invalid value"},
+		{spec: "*/0 2 * * *", wantErr: "invalid step"},
+	} {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := ParseSchedule(tt.spec)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestScheduleMatches(t *testing.T) {
+	// Saturday
+	saturday := time.Date(2025, 6, 7, 2, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		spec    string
+		time    time.Time
+		matches bool
+	}{
+		{"0 2 * * 6", saturday, true},
+		{"0 2 * * 6", saturday.Add(time.Minute), false},
+		{"0 2 * * 6", saturday.Add(24 * time.Hour), false},
+		{"*/15 * * * *", saturday.Add(45 * time.Minute), true},
+		{"*/15 * * * *", saturday.Add(50 * time.Minute), false},
+		// Sunday is 0 and 7
+		{"0 2 * * 7", saturday.Add(24 * time.Hour), true},
+		{"0 2 * * 0", saturday.Add(24 * time.Hour), true},
+		// either the day of month or the day of week
+		{"0 2 1 * 6", saturday, true},
+		{"0 2 7 * 1", saturday, true},
+		{"0 2 1 * 1", saturday, false},
+		{"0 2 * 7 *", saturday, false},
+	} {
+		s, err := ParseSchedule(tt.spec)
+		require.NoError(t, err)
+		assert.Equal(t, tt.matches, s.Matches(tt.time), "%s at %s", tt.spec, tt.time)
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	s, err := ParseSchedule("0 2 * * 6")
+	require.NoError(t, err)
+	saturday := time.Date(2025, 6, 7, 2, 0, 0, 0, time.UTC)
+	assert.Equal(t, saturday, s.Next(saturday.Add(-30*time.Second)))
+	assert.Equal(t, saturday.Add(7*24*time.Hour), s.Next(saturday))
+	assert.Equal(t, "0 2 * * 6", s.String())
+
+	s, err = ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(saturday).IsZero())
+}
+
+func TestScheduleNextMatchesMinuteByMinute(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// the first match minute by minute, as a reference
+	reference := func(s *Schedule, from time.Time) time.Time {
+		for next := from.Truncate(time.Minute).Add(time.Minute); next.Before(from.Add(scheduleHorizon)); next = next.Add(time.Minute) {
+			if s.Matches(next) {
+				return next
+			}
+		}
+		return time.Time{}
+	}
+
+	for _, spec := range []string{
+		"0 2 * * 6",
+		"*/15 * * * *",
+		"30 2 * * *",
+		"0 0 29 2 *",
+		"0 22 1,15 * 1-5",
+		"5 4 * 1,7 0",
+		"59 23 31 12 *",
+		"0 0 30 2 *",
+	} {
+		s, err := ParseSchedule(spec)
+		require.NoError(t, err)
+		for _, from := range []time.Time{
+			time.Date(2025, 6, 7, 2, 0, 0, 0, time.UTC),
+			time.Date(2025, 12, 31, 23, 59, 30, 0, time.UTC),
+			time.Date(2025, 3, 30, 1, 45, 0, 0, berlin),
+			time.Date(2025, 10, 26, 1, 45, 0, 0, berlin),
+			time.Date(2025, 10, 26, 2, 30, 0, 0, berlin).Add(time.Hour),
+		} {
+			expected := reference(s, from)
+			assert.True(t, expected.Equal(s.Next(from)), "%s after %s: expected %s, got %s", spec, from, expected, s.Next(from))
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// DeferredChanges counts the changes deferred by a ChangeWindowPolicy.
+type DeferredChanges struct {
+	Create int
+	Update int
+	Delete int
+}
+
+// Total returns the number of deferred changes.
+func (d DeferredChanges) Total() int {
+	return d.Create + d.Update + d.Delete
+}
+
+// ChangeWindowPolicy restricts the changes of the records in its domains to change windows, which open at
+// the times of a schedule and stay open for a duration. Outside of the windows, only the creations are
+// applied, or no changes at all, and the other changes of the records are deferred until a window opens.
+type ChangeWindowPolicy struct {
+	// DomainFilter selects the records restricted by the policy, all records if nil.
+	DomainFilter endpoint.DomainFilterInterface
+	// Schedule is the schedule at which the windows open.
+	Schedule *Schedule
+	// Duration is the time the windows stay open.
+	Duration time.Duration
+	// Location is the time zone of the schedule, UTC if nil.
+	Location *time.Location
+	// AllowCreate applies the creations outside of the windows too.
+	AllowCreate bool
+
+	now      func() time.Time
+	deferred DeferredChanges
+}
+
+// Apply defers the changes of the records of the policy when no window is open.
+func (w *ChangeWindowPolicy) Apply(changes *Changes) *Changes {
+	w.deferred = DeferredChanges{}
+	now := w.clock()
+	if w.Open(now) {
+		return changes
+	}
+
+	applied := &Changes{}
+	for _, ep := range changes.Create {
+		if w.AllowCreate || !w.restricts(ep) {
+			applied.Create = append(applied.Create, ep)
+		} else {
+			w.deferred.Create++
+		}
+	}
+	for i, ep := range changes.UpdateNew {
+		if w.restricts(ep) || w.restricts(changes.UpdateOld[i]) {
+			w.deferred.Update++
+			continue
+		}
+		applied.UpdateOld = append(applied.UpdateOld, changes.UpdateOld[i])
+		applied.UpdateNew = append(applied.UpdateNew, ep)
+	}
+	for _, ep := range changes.Delete {
+		if w.restricts(ep) {
+			w.deferred.Delete++
+		} else {
+			applied.Delete = append(applied.Delete, ep)
+		}
+	}
+
+	if total := w.deferred.Total(); total > 0 {
+		log.Infof("Deferring %d changes outside of the change window %q, the next window opens at %s", total, w.Schedule, w.Next(now).Format(time.RFC3339))
+	}
+	return applied
+}
+
+// Deferred returns the changes deferred from the last plan.
+func (w *ChangeWindowPolicy) Deferred() DeferredChanges {
+	return w.deferred
+}
+
+// Open tells whether a window is open at t: a time of the schedule is at most Duration before t.
+func (w *ChangeWindowPolicy) Open(t time.Time) bool {
+	t = t.In(w.location()).Truncate(time.Minute)
+	for start := t; t.Sub(start) < w.Duration; start = start.Add(-time.Minute) {
+		if w.Schedule.Matches(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the time the next window opens after t, or the zero time if none opens within a year.
+func (w *ChangeWindowPolicy) Next(t time.Time) time.Time {
+	return w.Schedule.Next(t.In(w.location()))
+}
+
+// restricts tells whether the changes of the record are restricted to the windows.
+func (w *ChangeWindowPolicy) restricts(ep *endpoint.Endpoint) bool {
+	return w.DomainFilter == nil || w.DomainFilter.Match(ep.DNSName)
+}
+
+func (w *ChangeWindowPolicy) location() *time.Location {
+	if w.Location == nil {
+		return time.UTC
+	}
+	return w.Location
+}
+
+func (w *ChangeWindowPolicy) clock() time.Time {
+	if w.now != nil {
+		return w.now()
+	}
+	return time.Now()
+}
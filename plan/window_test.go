@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestChangeWindowPolicy(t *testing.T) {
+	schedule, err := ParseSchedule("0 2 * * 6")
+	require.NoError(t, err)
+	// Saturday 02:00 UTC
+	opening := time.Date(2025, 6, 7, 2, 0, 0, 0, time.UTC)
+	now := opening.Add(-time.Hour)
+	w := &ChangeWindowPolicy{
+		DomainFilter: endpoint.NewDomainFilter([]string{"prod.example.com"}),
+		Schedule:     schedule,
+		Duration:     2 * time.Hour,
+		AllowCreate:  true,
+		now:          func() time.Time { return now },
+	}
+
+	prodOld := endpoint.NewEndpoint("app.prod.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	prodNew := endpoint.NewEndpoint("app.prod.example.com", endpoint.RecordTypeA, "1.2.3.5")
+	devOld := endpoint.NewEndpoint("app.dev.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	devNew := endpoint.NewEndpoint("app.dev.example.com", endpoint.RecordTypeA, "1.2.3.5")
+	changes := func() *Changes {
+		return &Changes{
+			Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("new.prod.example.com", endpoint.RecordTypeA, "1.2.3.6")},
+			UpdateOld: []*endpoint.Endpoint{prodOld, devOld},
+			UpdateNew: []*endpoint.Endpoint{prodNew, devNew},
+			Delete: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("old.prod.example.com", endpoint.RecordTypeA, "1.2.3.7"),
+				endpoint.NewEndpoint("old.dev.example.com", endpoint.RecordTypeA, "1.2.3.7"),
+			},
+		}
+	}
+
+	// outside of the window, the creations and the changes of other domains are applied
+	applied := w.Apply(changes())
+	assert.Len(t, applied.Create, 1)
+	assert.Equal(t, []*endpoint.Endpoint{devOld}, applied.UpdateOld)
+	assert.Equal(t, []*endpoint.Endpoint{devNew}, applied.UpdateNew)
+	require.Len(t, applied.Delete, 1)
+	assert.Equal(t, "old.dev.example.com", applied.Delete[0].DNSName)
+	assert.Equal(t, DeferredChanges{Update: 1, Delete: 1}, w.Deferred())
+	assert.Equal(t, opening, w.Next(now))
+
+	// without creations outside of the window
+	w.AllowCreate = false
+	applied = w.Apply(changes())
+	assert.Empty(t, applied.Create)
+	assert.Equal(t, 3, w.Deferred().Total())
+
+	// within the window, all changes are applied
+	for _, at := range []time.Time{opening, opening.Add(119 * time.Minute)} {
+		now = at
+		applied = w.Apply(changes())
+		assert.Len(t, applied.Create, 1)
+		assert.Len(t, applied.UpdateNew, 2)
+		assert.Len(t, applied.Delete, 2)
+		assert.Zero(t, w.Deferred().Total())
+	}
+	now = opening.Add(2 * time.Hour)
+	w.Apply(changes())
+	assert.Equal(t, 3, w.Deferred().Total())
+}
+
+func TestChangeWindowPolicyLocation(t *testing.T) {
+	schedule, err := ParseSchedule("0 2 * * *")
+	require.NoError(t, err)
+	w := &ChangeWindowPolicy{Schedule: schedule, Duration: time.Hour, Location: time.FixedZone("UTC+2", 2*60*60)}
+	assert.True(t, w.Open(time.Date(2025, 6, 7, 0, 30, 0, 0, time.UTC)))
+	assert.False(t, w.Open(time.Date(2025, 6, 7, 2, 30, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC), w.Next(time.Date(2025, 6, 7, 0, 30, 0, 0, time.UTC)).UTC())
+}
@@ -1234,7 +1234,7 @@ func cleanZoneID(id string) string {
 
 func (p *AWSProvider) SupportedRecordType(recordType route53types.RRType) bool {
 	switch recordType {
-	case route53types.RRTypeMx:
+	case route53types.RRTypeMx, route53types.RRTypeCaa, route53types.RRTypeHttps, route53types.RRTypeSvcb, route53types.RRTypePtr:
 		return true
 	default:
 		return provider.SupportedRecordType(string(recordType))
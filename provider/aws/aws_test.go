@@ -607,6 +607,24 @@ func TestAWSRecords(t *testing.T) {
 			TTL:             aws.Int64(defaultTTL),
 			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("10 mailhost1.example.com")}, {Value: aws.String("20 mailhost2.example.com")}},
 		},
+		{
+			Name:            aws.String("caa.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeCaa,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String(`0 issue "letsencrypt.org"`)}, {Value: aws.String(`0 iodef "mailto:security@example.com"`)}},
+		},
+		{
+			Name:            aws.String("https.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeHttps,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String(`1 . alpn="h2,h3" port=443`)}},
+		},
+		{
+			Name:            aws.String("ptr.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypePtr,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("foo.example.com")}},
+		},
 	})
 
 	records, err := provider.Records(context.Background())
@@ -639,6 +657,9 @@ func TestAWSRecords(t *testing.T) {
 		endpoint.NewEndpointWithTTL("healthcheck-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCNAME, endpoint.TTL(defaultTTL), "foo.example.com").WithSetIdentifier("test-set-1").WithProviderSpecific(providerSpecificWeight, "10").WithProviderSpecific(providerSpecificHealthCheckID, "foo-bar-healthcheck-id").WithProviderSpecific(providerSpecificAlias, "false"),
 		endpoint.NewEndpointWithTTL("healthcheck-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "4.3.2.1").WithSetIdentifier("test-set-2").WithProviderSpecific(providerSpecificWeight, "20").WithProviderSpecific(providerSpecificHealthCheckID, "abc-def-healthcheck-id"),
 		endpoint.NewEndpointWithTTL("mail.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeMX, endpoint.TTL(defaultTTL), "10 mailhost1.example.com", "20 mailhost2.example.com"),
+		endpoint.NewEndpointWithTTL("caa.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCAA, endpoint.TTL(defaultTTL), `0 issue "letsencrypt.org"`, `0 iodef "mailto:security@example.com"`),
+		endpoint.NewEndpointWithTTL("https.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeHTTPS, endpoint.TTL(defaultTTL), `1 . alpn="h2,h3" port=443`),
+		endpoint.NewEndpointWithTTL("ptr.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypePTR, endpoint.TTL(defaultTTL), "foo.example.com"),
 	})
 }
 
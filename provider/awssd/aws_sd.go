@@ -299,8 +299,9 @@ func (p *AWSSDProvider) submitCreates(ctx context.Context, namespaces []*sdtypes
 				}
 				// update a local list of services
 				services[*srv.Name] = srv
-			} else if ch.RecordTTL.IsConfigured() && *srv.DnsConfig.DnsRecords[0].TTL != int64(ch.RecordTTL) {
-				// update service when TTL differ
+			} else if (ch.RecordTTL.IsConfigured() && *srv.DnsConfig.DnsRecords[0].TTL != int64(ch.RecordTTL)) ||
+				aws.ToString(srv.Description) != ch.Labels[endpoint.AWSSDDescriptionLabel] {
+				// update service when TTL or ownership differ
 				err = p.UpdateService(ctx, srv, ch)
 				if err != nil {
 					return err
@@ -455,6 +456,9 @@ func (p *AWSSDProvider) UpdateService(ctx context.Context, service *sdtypes.Serv
 	ttl := int64(defaultTTL)
 	if ep.RecordTTL.IsConfigured() {
 		ttl = int64(ep.RecordTTL)
+	} else if service.DnsConfig != nil && len(service.DnsConfig.DnsRecords) > 0 && service.DnsConfig.DnsRecords[0].TTL != nil {
+		// keep the TTL of the service when only its description changes
+		ttl = *service.DnsConfig.DnsRecords[0].TTL
 	}
 
 	if p.dryRun {
@@ -476,6 +480,47 @@ func (p *AWSSDProvider) UpdateService(ctx context.Context, service *sdtypes.Serv
 	return err
 }
 
+// UpdateServiceDescriptions sets the descriptions of the services of the endpoints, which hold their ownership
+// labels, to the AWSSDDescriptionLabel of the endpoints. The DNS configuration and instances of the services are kept.
+func (p *AWSSDProvider) UpdateServiceDescriptions(ctx context.Context, endpoints []*endpoint.Endpoint) error {
+	namespaces, err := p.ListNamespaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	for nsID, changeList := range p.changesByNamespaceID(namespaces, endpoints) {
+		if len(changeList) == 0 {
+			continue
+		}
+		services, err := p.ListServicesByNamespaceID(ctx, aws.String(nsID))
+		if err != nil {
+			return err
+		}
+
+		for _, ep := range changeList {
+			_, srvName := p.parseHostname(ep.DNSName)
+			srv := services[srvName]
+			if srv == nil {
+				log.Warnf("Skipping description of %s, service \"%s\" does not exist", ep.DNSName, srvName)
+				continue
+			}
+			log.Infof("Updating description of service \"%s\"", *srv.Name)
+
+			if p.dryRun {
+				continue
+			}
+			change := &sdtypes.ServiceChange{Description: aws.String(ep.Labels[endpoint.AWSSDDescriptionLabel])}
+			if srv.DnsConfig != nil {
+				change.DnsConfig = &sdtypes.DnsConfigChange{DnsRecords: srv.DnsConfig.DnsRecords}
+			}
+			if _, err := p.client.UpdateService(ctx, &sd.UpdateServiceInput{Id: srv.Id, Service: change}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // DeleteService deletes empty Service from AWS API if its owner id match
 func (p *AWSSDProvider) DeleteService(ctx context.Context, service *sdtypes.Service) error {
 	log.Debugf("Check if service \"%s\" owner id match and it can be deleted", *service.Name)
@@ -280,6 +280,45 @@ func TestAWSSDProvider_ApplyChanges_Update(t *testing.T) {
 	assert.Equal(t, "1.2.3.5", api.deregistered[0], "wrong target de-registered")
 }
 
+func TestAWSSDProvider_ApplyChanges_UpdateDescription(t *testing.T) {
+	namespaces := map[string]*sdtypes.Namespace{
+		"private": {
+			Id:   aws.String("private"),
+			Name: aws.String("private.com"),
+			Type: sdtypes.NamespaceTypeDnsPrivate,
+		},
+	}
+
+	api := &AWSSDClientStub{
+		namespaces: namespaces,
+		services:   make(map[string]map[string]*sdtypes.Service),
+		instances:  make(map[string]map[string]*sdtypes.Instance),
+	}
+
+	provider := newTestAWSSDProvider(api, endpoint.NewDomainFilter([]string{}), "", "owner")
+	ctx := context.Background()
+
+	oldEndpoints := []*endpoint.Endpoint{
+		{DNSName: "service1.private.com", Targets: endpoint.Targets{"1.2.3.4"}, RecordType: endpoint.RecordTypeA, RecordTTL: 60},
+	}
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{Create: oldEndpoints}))
+
+	// only the ownership of the service changes, e.g. when it is adopted
+	newEndpoints := []*endpoint.Endpoint{
+		{DNSName: "service1.private.com", Targets: endpoint.Targets{"1.2.3.4"}, RecordType: endpoint.RecordTypeA, Labels: map[string]string{
+			endpoint.AWSSDDescriptionLabel: "heritage=external-dns,external-dns/owner=owner",
+		}},
+	}
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{UpdateOld: oldEndpoints, UpdateNew: newEndpoints}))
+
+	services, err := provider.ListServicesByNamespaceID(ctx, namespaces["private"].Id)
+	require.NoError(t, err)
+	require.NotNil(t, services["service1"])
+	assert.Equal(t, "heritage=external-dns,external-dns/owner=owner", *services["service1"].Description)
+	assert.Equal(t, int64(60), *services["service1"].DnsConfig.DnsRecords[0].TTL, "the TTL of the service is kept")
+	assert.Empty(t, api.deregistered)
+}
+
 func TestAWSSDProvider_ListNamespaces(t *testing.T) {
 	namespaces := map[string]*sdtypes.Namespace{
 		"private": {
@@ -649,6 +688,57 @@ func TestAWSSDProvider_UpdateService_DryRun(t *testing.T) {
 	assert.NotEqual(t, endpoint.RecordTypeAAAA, api.services["private"]["srv1"].DnsConfig.DnsRecords[0].Type)
 }
 
+func TestAWSSDProvider_UpdateServiceDescriptions(t *testing.T) {
+	namespaces := map[string]*sdtypes.Namespace{
+		"private": {
+			Id:   aws.String("private"),
+			Name: aws.String("private.com"),
+			Type: sdtypes.NamespaceTypeDnsPrivate,
+		},
+	}
+
+	services := map[string]map[string]*sdtypes.Service{
+		"private": {
+			"srv1": {
+				Id:          aws.String("srv1"),
+				Name:        aws.String("service1"),
+				NamespaceId: aws.String("private"),
+				Description: aws.String("heritage=external-dns,external-dns/owner=old"),
+				DnsConfig: &sdtypes.DnsConfig{
+					RoutingPolicy: sdtypes.RoutingPolicyMultivalue,
+					DnsRecords: []sdtypes.DnsRecord{{
+						Type: sdtypes.RecordTypeA,
+						TTL:  aws.Int64(60),
+					}},
+				},
+			},
+		},
+	}
+
+	api := &AWSSDClientStub{
+		namespaces: namespaces,
+		services:   services,
+		instances: map[string]map[string]*sdtypes.Instance{
+			"srv1": {"1.2.3.4": {Id: aws.String("1.2.3.4")}},
+		},
+	}
+
+	provider := newTestAWSSDProvider(api, endpoint.NewDomainFilter([]string{}), "", "old")
+
+	ep := endpoint.NewEndpoint("service1.private.com", endpoint.RecordTypeA, "1.2.3.4")
+	ep.Labels[endpoint.AWSSDDescriptionLabel] = "heritage=external-dns,external-dns/owner=new"
+	missing := endpoint.NewEndpoint("service2.private.com", endpoint.RecordTypeA, "1.2.3.5")
+
+	require.NoError(t, provider.UpdateServiceDescriptions(context.Background(), []*endpoint.Endpoint{ep, missing}))
+
+	srv := api.services["private"]["srv1"]
+	assert.Equal(t, "heritage=external-dns,external-dns/owner=new", *srv.Description)
+	// the DNS configuration and instances of the service are kept
+	assert.Equal(t, int64(60), *srv.DnsConfig.DnsRecords[0].TTL)
+	assert.Len(t, api.instances["srv1"], 1)
+	assert.Len(t, api.services["private"], 1)
+}
+
 func TestAWSSDProvider_DeleteService(t *testing.T) {
 	namespaces := map[string]*sdtypes.Namespace{
 		"private": {
@@ -200,7 +200,7 @@ func (p *AzureProvider) zones(ctx context.Context) ([]dns.Zone, error) {
 
 func (p *AzureProvider) SupportedRecordType(recordType string) bool {
 	switch recordType {
-	case "MX":
+	case "MX", "CAA", "PTR":
 		return true
 	default:
 		return provider.SupportedRecordType(recordType)
@@ -395,6 +395,21 @@ func (p *AzureProvider) newRecordSet(endpoint *endpoint.Endpoint) (dns.RecordSet
 				MxRecords: mxRecords,
 			},
 		}, nil
+	case dns.RecordTypeCAA:
+		caaRecords := make([]*dns.CaaRecord, len(endpoint.Targets))
+		for i, target := range endpoint.Targets {
+			caaRecord, err := parseCaaTarget(target)
+			if err != nil {
+				return dns.RecordSet{}, err
+			}
+			caaRecords[i] = &caaRecord
+		}
+		return dns.RecordSet{
+			Properties: &dns.RecordSetProperties{
+				TTL:        to.Ptr(ttl),
+				CaaRecords: caaRecords,
+			},
+		}, nil
 	case dns.RecordTypeNS:
 		nsRecords := make([]*dns.NsRecord, len(endpoint.Targets))
 		for i, target := range endpoint.Targets {
@@ -408,6 +423,19 @@ func (p *AzureProvider) newRecordSet(endpoint *endpoint.Endpoint) (dns.RecordSet
 				NsRecords: nsRecords,
 			},
 		}, nil
+	case dns.RecordTypePTR:
+		ptrRecords := make([]*dns.PtrRecord, len(endpoint.Targets))
+		for i, target := range endpoint.Targets {
+			ptrRecords[i] = &dns.PtrRecord{
+				Ptrdname: to.Ptr(target),
+			}
+		}
+		return dns.RecordSet{
+			Properties: &dns.RecordSetProperties{
+				TTL:        to.Ptr(ttl),
+				PtrRecords: ptrRecords,
+			},
+		}, nil
 	case dns.RecordTypeTXT:
 		return dns.RecordSet{
 			Properties: &dns.RecordSetProperties{
@@ -476,6 +504,16 @@ func extractAzureTargets(recordSet *dns.RecordSet) []string {
 		return targets
 	}
 
+	// Check for CAA records
+	caaRecords := properties.CaaRecords
+	if len(caaRecords) > 0 && (caaRecords)[0].Tag != nil {
+		targets := make([]string, len(caaRecords))
+		for i, caaRecord := range caaRecords {
+			targets[i] = endpoint.NewCAATarget(uint8(*caaRecord.Flags), *caaRecord.Tag, *caaRecord.Value)
+		}
+		return targets
+	}
+
 	// Check for NS records
 	nsRecords := properties.NsRecords
 	if len(nsRecords) > 0 && (nsRecords)[0].Nsdname != nil {
@@ -486,6 +524,16 @@ func extractAzureTargets(recordSet *dns.RecordSet) []string {
 		return targets
 	}
 
+	// Check for PTR records
+	ptrRecords := properties.PtrRecords
+	if len(ptrRecords) > 0 && (ptrRecords)[0].Ptrdname != nil {
+		targets := make([]string, len(ptrRecords))
+		for i, ptrRecord := range ptrRecords {
+			targets[i] = *ptrRecord.Ptrdname
+		}
+		return targets
+	}
+
 	// Check for TXT records
 	txtRecords := properties.TxtRecords
 	if len(txtRecords) > 0 && (txtRecords)[0].Value != nil {
@@ -172,6 +172,18 @@ func mxRecordSetPropertiesGetter(values []string, ttl int64) *dns.RecordSetPrope
 	}
 }
 
+func caaRecordSetPropertiesGetter(values []string, ttl int64) *dns.RecordSetProperties {
+	caaRecords := make([]*dns.CaaRecord, len(values))
+	for i, target := range values {
+		caaRecord, _ := parseCaaTarget(target)
+		caaRecords[i] = &caaRecord
+	}
+	return &dns.RecordSetProperties{
+		TTL:        to.Ptr(ttl),
+		CaaRecords: caaRecords,
+	}
+}
+
 func nsRecordSetPropertiesGetter(values []string, ttl int64) *dns.RecordSetProperties {
 	nsRecords := make([]*dns.NsRecord, len(values))
 	for i, value := range values {
@@ -185,6 +197,19 @@ func nsRecordSetPropertiesGetter(values []string, ttl int64) *dns.RecordSetPrope
 	}
 }
 
+func ptrRecordSetPropertiesGetter(values []string, ttl int64) *dns.RecordSetProperties {
+	ptrRecords := make([]*dns.PtrRecord, len(values))
+	for i, value := range values {
+		ptrRecords[i] = &dns.PtrRecord{
+			Ptrdname: to.Ptr(value),
+		}
+	}
+	return &dns.RecordSetProperties{
+		TTL:        to.Ptr(ttl),
+		PtrRecords: ptrRecords,
+	}
+}
+
 func txtRecordSetPropertiesGetter(values []string, ttl int64) *dns.RecordSetProperties {
 	return &dns.RecordSetProperties{
 		TTL: to.Ptr(ttl),
@@ -222,8 +247,12 @@ func createMockRecordSetMultiWithTTL(name, recordType string, ttl int64, values
 		getterFunc = cNameRecordSetPropertiesGetter
 	case endpoint.RecordTypeMX:
 		getterFunc = mxRecordSetPropertiesGetter
+	case endpoint.RecordTypeCAA:
+		getterFunc = caaRecordSetPropertiesGetter
 	case endpoint.RecordTypeNS:
 		getterFunc = nsRecordSetPropertiesGetter
+	case endpoint.RecordTypePTR:
+		getterFunc = ptrRecordSetPropertiesGetter
 	case endpoint.RecordTypeTXT:
 		getterFunc = txtRecordSetPropertiesGetter
 	default:
@@ -281,6 +310,7 @@ func TestAzureRecord(t *testing.T) {
 			createMockRecordSetWithTTL("nginx", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default", recordTTL),
 			createMockRecordSetWithTTL("hack", endpoint.RecordTypeCNAME, "hack.azurewebsites.net", 10),
 			createMockRecordSetMultiWithTTL("mail", endpoint.RecordTypeMX, 4000, "10 example.com"),
+			createMockRecordSetMultiWithTTL("@", endpoint.RecordTypeCAA, 3600, `0 issue "letsencrypt.org"`, `0 iodef "mailto:security@example.com"`),
 		}, 3)
 	if err != nil {
 		t.Fatal(err)
@@ -303,11 +333,39 @@ func TestAzureRecord(t *testing.T) {
 		endpoint.NewEndpointWithTTL("nginx.example.com", endpoint.RecordTypeTXT, recordTTL, "heritage=external-dns,external-dns/owner=default"),
 		endpoint.NewEndpointWithTTL("hack.example.com", endpoint.RecordTypeCNAME, 10, "hack.azurewebsites.net"),
 		endpoint.NewEndpointWithTTL("mail.example.com", endpoint.RecordTypeMX, 4000, "10 example.com"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeCAA, 3600, `0 issue "letsencrypt.org"`, `0 iodef "mailto:security@example.com"`),
 	}
 
 	validateAzureEndpoints(t, actual, expected)
 }
 
+func TestAzureRecordPTR(t *testing.T) {
+	provider, err := newMockedAzureProvider(endpoint.NewDomainFilter([]string{"2.0.192.in-addr.arpa"}), endpoint.NewDomainFilter([]string{}), provider.NewZoneIDFilter([]string{""}), true, "k8s", "", "",
+		[]*dns.Zone{
+			createMockZone("2.0.192.in-addr.arpa", "/dnszones/2.0.192.in-addr.arpa"),
+		},
+		[]*dns.RecordSet{
+			createMockRecordSetMultiWithTTL("10", endpoint.RecordTypePTR, 300, "nginx.example.com"),
+		}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := provider.Records(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateAzureEndpoints(t, actual, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("10.2.0.192.in-addr.arpa", endpoint.RecordTypePTR, 300, "nginx.example.com"),
+	})
+
+	recordSet, err := provider.newRecordSet(endpoint.NewEndpointWithTTL("10.2.0.192.in-addr.arpa", endpoint.RecordTypePTR, 300, "nginx.example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"nginx.example.com"}, extractAzureTargets(&recordSet))
+}
+
 func TestAzureMultiRecord(t *testing.T) {
 	provider, err := newMockedAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{}), provider.NewZoneIDFilter([]string{""}), true, "k8s", "", "",
 		[]*dns.Zone{
@@ -384,6 +442,7 @@ func TestAzureApplyChanges(t *testing.T) {
 		endpoint.NewEndpointWithTTL("newcname.example.com", endpoint.RecordTypeCNAME, 10, "other.com"),
 		endpoint.NewEndpointWithTTL("newns.example.com", endpoint.RecordTypeNS, 10, "ns1.example.com."),
 		endpoint.NewEndpointWithTTL("newmail.example.com", endpoint.RecordTypeMX, 7200, "40 bar.other.com"),
+		endpoint.NewEndpointWithTTL("newcaa.example.com", endpoint.RecordTypeCAA, 7200, `0 issue "letsencrypt.org"`),
 		endpoint.NewEndpointWithTTL("mail.example.com", endpoint.RecordTypeMX, endpoint.TTL(recordTTL), "10 other.com"),
 		endpoint.NewEndpointWithTTL("mail.example.com", endpoint.RecordTypeTXT, endpoint.TTL(recordTTL), "tag"),
 	})
@@ -457,6 +516,7 @@ func testAzureApplyChangesInternal(t *testing.T, dryRun bool, client RecordSetsC
 		endpoint.NewEndpoint("new.nope.com", endpoint.RecordTypeAAAA, "2001::222:111:222:111"),
 		endpoint.NewEndpoint("newns.nope.com", endpoint.RecordTypeNS, "ns1.example.com"),
 		endpoint.NewEndpointWithTTL("newmail.example.com", endpoint.RecordTypeMX, 7200, "40 bar.other.com"),
+		endpoint.NewEndpointWithTTL("newcaa.example.com", endpoint.RecordTypeCAA, 7200, `0 issue "letsencrypt.org"`),
 	}
 
 	deleteRecords := []*endpoint.Endpoint{
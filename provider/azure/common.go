@@ -25,6 +25,8 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
 	privatedns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
 // Helper function (shared with test code)
@@ -45,3 +47,17 @@ func parseMxTarget[T dns.MxRecord | privatedns.MxRecord](mxTarget string) (T, er
 		Exchange:   to.Ptr(exchange),
 	}, nil
 }
+
+// Helper function (shared with test code)
+func parseCaaTarget(caaTarget string) (dns.CaaRecord, error) {
+	flags, tag, value, err := endpoint.ParseCAATarget(caaTarget)
+	if err != nil {
+		return dns.CaaRecord{}, fmt.Errorf("caa target needs to be of form '0 issue \"letsencrypt.org\"': %w", err)
+	}
+
+	return dns.CaaRecord{
+		Flags: to.Ptr(int32(flags)),
+		Tag:   to.Ptr(tag),
+		Value: to.Ptr(value),
+	}, nil
+}
@@ -85,3 +85,54 @@ func Test_parseMxTarget(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseCaaTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		want    dns.CaaRecord
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "valid caa target",
+			args: `0 issue "letsencrypt.org"`,
+			want: dns.CaaRecord{
+				Flags: to.Ptr(int32(0)),
+				Tag:   to.Ptr("issue"),
+				Value: to.Ptr("letsencrypt.org"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "valid critical iodef target",
+			args: `128 iodef "mailto:security@example.com"`,
+			want: dns.CaaRecord{
+				Flags: to.Ptr(int32(128)),
+				Tag:   to.Ptr("iodef"),
+				Value: to.Ptr("mailto:security@example.com"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid caa target with unquoted value",
+			args:    "0 issue letsencrypt.org",
+			want:    dns.CaaRecord{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "invalid caa target without flags",
+			args:    `issue "letsencrypt.org"`,
+			want:    dns.CaaRecord{},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCaaTarget(tt.args)
+			if !tt.wantErr(t, err, fmt.Sprintf("parseCaaTarget(%v)", tt.args)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "parseCaaTarget(%v)", tt.args)
+		})
+	}
+}
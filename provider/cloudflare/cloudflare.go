@@ -91,12 +91,15 @@ type CustomHostnameIndex struct {
 type CustomHostnamesMap map[CustomHostnameIndex]cloudflare.CustomHostname
 
 var recordTypeProxyNotSupported = map[string]bool{
-	"LOC": true,
-	"MX":  true,
-	"NS":  true,
-	"SPF": true,
-	"TXT": true,
-	"SRV": true,
+	"CAA":   true,
+	"HTTPS": true,
+	"LOC":   true,
+	"MX":    true,
+	"NS":    true,
+	"SPF":   true,
+	"SVCB":  true,
+	"TXT":   true,
+	"SRV":   true,
 }
 
 type CustomHostnamesConfig struct {
@@ -257,6 +260,7 @@ func updateDNSRecordParam(cfc cloudFlareChange) cloudflare.UpdateDNSRecordParams
 		Proxied: cfc.ResourceRecord.Proxied,
 		Type:    cfc.ResourceRecord.Type,
 		Content: cfc.ResourceRecord.Content,
+		Data:    cfc.ResourceRecord.Data,
 	}
 }
 
@@ -268,6 +272,7 @@ func getCreateDNSRecordParam(cfc cloudFlareChange) cloudflare.CreateDNSRecordPar
 		Proxied: cfc.ResourceRecord.Proxied,
 		Type:    cfc.ResourceRecord.Type,
 		Content: cfc.ResourceRecord.Content,
+		Data:    cfc.ResourceRecord.Data,
 	}
 }
 
@@ -732,6 +737,7 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 			Proxied: &proxied,
 			Type:    ep.RecordType,
 			Content: target,
+			Data:    recordData(ep.RecordType, target),
 			Comment: comment,
 		},
 		RegionalHostname:    p.regionalHostname(ep),
@@ -740,6 +746,56 @@ func (p *CloudFlareProvider) newCloudFlareChange(action changeAction, ep *endpoi
 	}
 }
 
+// recordData returns the structured data Cloudflare requires for record types like CAA, nil for
+// the record types set through their content.
+func recordData(recordType, target string) interface{} {
+	switch recordType {
+	case endpoint.RecordTypeCAA:
+		flags, tag, value, err := endpoint.ParseCAATarget(target)
+		if err != nil {
+			log.Warnf("Invalid CAA record target %q: %v", target, err)
+			return nil
+		}
+		return map[string]interface{}{
+			"flags": flags,
+			"tag":   tag,
+			"value": value,
+		}
+	case endpoint.RecordTypeHTTPS, endpoint.RecordTypeSVCB:
+		priority, targetName, params, err := endpoint.ParseSVCBTarget(target)
+		if err != nil {
+			log.Warnf("Invalid %s record target %q: %v", recordType, target, err)
+			return nil
+		}
+		// the SvcParams are passed in presentation format, the remainder of the formatted target
+		parts := strings.SplitN(endpoint.NewSVCBTarget(priority, targetName, params), " ", 3)
+		value := ""
+		if len(parts) == 3 {
+			value = parts[2]
+		}
+		return map[string]interface{}{
+			"priority": priority,
+			"target":   targetName,
+			"value":    value,
+		}
+	}
+	return nil
+}
+
+// normalizeContent formats the content of records listed from Cloudflare like the targets of endpoints,
+// so that records can be matched by their content.
+func normalizeContent(r cloudflare.DNSRecord) string {
+	switch r.Type {
+	case endpoint.RecordTypeCAA:
+		if flags, tag, value, err := endpoint.ParseCAATarget(r.Content); err == nil {
+			return endpoint.NewCAATarget(flags, tag, value)
+		}
+	case endpoint.RecordTypeHTTPS, endpoint.RecordTypeSVCB:
+		return endpoint.NormalizeSVCBTarget(r.Content)
+	}
+	return r.Content
+}
+
 func newDNSRecordIndex(r cloudflare.DNSRecord) DNSRecordIndex {
 	return DNSRecordIndex{Name: r.Name, Type: r.Type, Content: r.Content}
 }
@@ -764,6 +820,7 @@ func (p *CloudFlareProvider) listDNSRecordsWithAutoPagination(ctx context.Contex
 		}
 
 		for _, r := range pageRecords {
+			r.Content = normalizeContent(r)
 			records[newDNSRecordIndex(r)] = r
 		}
 		params.ResultInfo = resultInfo.Next()
@@ -864,7 +921,7 @@ func groupByNameAndTypeWithCustomHostnames(records DNSRecordsMap, chs CustomHost
 	groups := map[string][]cloudflare.DNSRecord{}
 
 	for _, r := range records {
-		if !provider.SupportedRecordType(r.Type) {
+		if !provider.SupportedRecordType(r.Type) && r.Type != endpoint.RecordTypeCAA && !endpoint.IsServiceBindingRecordType(r.Type) {
 			continue
 		}
 
@@ -125,6 +125,7 @@ func getDNSRecordFromRecordParams(rp any) cloudflare.DNSRecord {
 			Proxied: params.Proxied,
 			Type:    params.Type,
 			Content: params.Content,
+			Data:    params.Data,
 		}
 	case cloudflare.UpdateDNSRecordParams:
 		return cloudflare.DNSRecord{
@@ -134,6 +135,7 @@ func getDNSRecordFromRecordParams(rp any) cloudflare.DNSRecord {
 			Proxied: params.Proxied,
 			Type:    params.Type,
 			Content: params.Content,
+			Data:    params.Data,
 		}
 	default:
 		return cloudflare.DNSRecord{}
@@ -526,6 +528,120 @@ func TestCloudflareA(t *testing.T) {
 	)
 }
 
+func TestCloudflareCAA(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		{
+			RecordType: endpoint.RecordTypeCAA,
+			DNSName:    "bar.com",
+			Targets:    endpoint.Targets{`0 issue "letsencrypt.org"`},
+		},
+	}
+
+	AssertActions(t, &CloudFlareProvider{proxiedByDefault: true}, endpoints, []MockAction{
+		{
+			Name:     "Create",
+			ZoneId:   "001",
+			RecordId: generateDNSRecordID("CAA", "bar.com", `0 issue "letsencrypt.org"`),
+			RecordData: cloudflare.DNSRecord{
+				ID:      generateDNSRecordID("CAA", "bar.com", `0 issue "letsencrypt.org"`),
+				Type:    "CAA",
+				Name:    "bar.com",
+				Content: `0 issue "letsencrypt.org"`,
+				Data: map[string]interface{}{
+					"flags": uint8(0),
+					"tag":   "issue",
+					"value": "letsencrypt.org",
+				},
+				TTL:     1,
+				Proxied: proxyDisabled,
+			},
+		},
+	},
+		[]string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	)
+}
+
+func TestCloudflareRecordsCAA(t *testing.T) {
+	client := NewMockCloudFlareClientWithRecords(map[string][]cloudflare.DNSRecord{
+		"001": {
+			{
+				ID:      "1234567890",
+				Name:    "bar.com",
+				Type:    endpoint.RecordTypeCAA,
+				TTL:     120,
+				Content: `0 ISSUE  "letsencrypt.org"`,
+				Proxied: proxyDisabled,
+			},
+		},
+	})
+	p := &CloudFlareProvider{Client: client}
+
+	records, err := p.Records(context.Background())
+	assert.NoError(t, err)
+	if !assert.Len(t, records, 1) {
+		return
+	}
+	assert.Equal(t, endpoint.RecordTypeCAA, records[0].RecordType)
+	assert.Equal(t, endpoint.Targets{`0 issue "letsencrypt.org"`}, records[0].Targets)
+}
+
+func TestCloudflareHTTPS(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		{
+			RecordType: endpoint.RecordTypeHTTPS,
+			DNSName:    "bar.com",
+			Targets:    endpoint.Targets{`1 . port=443 alpn="h3,h2"`},
+		},
+	}
+
+	AssertActions(t, &CloudFlareProvider{proxiedByDefault: true}, endpoints, []MockAction{
+		{
+			Name:     "Create",
+			ZoneId:   "001",
+			RecordId: generateDNSRecordID("HTTPS", "bar.com", `1 . port=443 alpn="h3,h2"`),
+			RecordData: cloudflare.DNSRecord{
+				ID:      generateDNSRecordID("HTTPS", "bar.com", `1 . port=443 alpn="h3,h2"`),
+				Type:    "HTTPS",
+				Name:    "bar.com",
+				Content: `1 . port=443 alpn="h3,h2"`,
+				Data: map[string]interface{}{
+					"priority": uint16(1),
+					"target":   ".",
+					"value":    "alpn=h3,h2 port=443",
+				},
+				TTL:     1,
+				Proxied: proxyDisabled,
+			},
+		},
+	},
+		[]string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	)
+}
+
+func TestCloudflareRecordsHTTPS(t *testing.T) {
+	client := NewMockCloudFlareClientWithRecords(map[string][]cloudflare.DNSRecord{
+		"001": {
+			{
+				ID:      "1234567890",
+				Name:    "bar.com",
+				Type:    endpoint.RecordTypeHTTPS,
+				TTL:     120,
+				Content: `1 . alpn="h3,h2" ipv4hint="5.6.7.8,1.2.3.4"`,
+				Proxied: proxyDisabled,
+			},
+		},
+	})
+	p := &CloudFlareProvider{Client: client}
+
+	records, err := p.Records(context.Background())
+	assert.NoError(t, err)
+	if !assert.Len(t, records, 1) {
+		return
+	}
+	assert.Equal(t, endpoint.RecordTypeHTTPS, records[0].RecordType)
+	assert.Equal(t, endpoint.Targets{"1 . alpn=h3,h2 ipv4hint=1.2.3.4,5.6.7.8"}, records[0].Targets)
+}
+
 func TestCloudflareCname(t *testing.T) {
 	endpoints := []*endpoint.Endpoint{
 		{
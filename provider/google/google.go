@@ -250,7 +250,7 @@ func (p *GoogleProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 // SupportedRecordType returns true if the record type is supported by the provider
 func (p *GoogleProvider) SupportedRecordType(recordType string) bool {
 	switch recordType {
-	case "MX":
+	case "MX", "CAA", "PTR":
 		return true
 	default:
 		return provider.SupportedRecordType(recordType)
@@ -449,6 +449,12 @@ func newRecord(ep *endpoint.Endpoint) *dns.ResourceRecordSet {
 		}
 	}
 
+	if ep.RecordType == endpoint.RecordTypePTR {
+		for i, ptrRecord := range ep.Targets {
+			targets[i] = provider.EnsureTrailingDot(ptrRecord)
+		}
+	}
+
 	// no annotation results in a Ttl of 0, default to 300 for backwards-compatibility
 	var ttl int64 = defaultTTL
 	if ep.RecordTTL.IsConfigured() {
@@ -185,7 +185,7 @@ func isValidRecordSet(recordSet *dns.ResourceRecordSet) bool {
 	}
 
 	switch recordSet.Type {
-	case endpoint.RecordTypeCNAME:
+	case endpoint.RecordTypeCNAME, endpoint.RecordTypePTR:
 		for _, rrd := range recordSet.Rrdatas {
 			if !hasTrailingDot(rrd) {
 				return false
@@ -197,6 +197,12 @@ func isValidRecordSet(recordSet *dns.ResourceRecordSet) bool {
 				return false
 			}
 		}
+	case endpoint.RecordTypeCAA:
+		for _, rrd := range recordSet.Rrdatas {
+			if _, _, _, err := endpoint.ParseCAATarget(rrd); err != nil {
+				return false
+			}
+		}
 	default:
 		panic("unhandled record type")
 	}
@@ -268,6 +274,8 @@ func TestGoogleRecords(t *testing.T) {
 		endpoint.NewEndpointWithTTL("list-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, endpoint.TTL(1), "1.2.3.4"),
 		endpoint.NewEndpointWithTTL("list-test.zone-2.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, endpoint.TTL(2), "8.8.8.8"),
 		endpoint.NewEndpointWithTTL("list-test-alias.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeCNAME, endpoint.TTL(3), "foo.elb.amazonaws.com"),
+		endpoint.NewEndpointWithTTL("list-test-caa.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeCAA, endpoint.TTL(4), `0 issue "letsencrypt.org"`),
+		endpoint.NewEndpointWithTTL("list-test-ptr.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypePTR, endpoint.TTL(5), "foo.example.org"),
 	}
 
 	provider := newGoogleProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.gcp.zalan.do."}), provider.NewZoneIDFilter([]string{""}), false, originalEndpoints, nil, nil)
@@ -470,6 +478,8 @@ func TestNewFilteredRecords(t *testing.T) {
 		// test fallback to Ttl:300 when Ttl==0 :
 		endpoint.NewEndpointWithTTL("update-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, 0, "8.8.8.8"),
 		endpoint.NewEndpointWithTTL("update-test-mx.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeMX, 6000, "10 mail.elb.amazonaws.com"),
+		endpoint.NewEndpointWithTTL("update-test-caa.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeCAA, 600, `0 issue "letsencrypt.org"`),
+		endpoint.NewEndpointWithTTL("update-test-ptr.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypePTR, 600, "foo.example.org"),
 		endpoint.NewEndpoint("delete-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, "8.8.8.8"),
 		endpoint.NewEndpoint("delete-test-cname.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeCNAME, "qux.elb.amazonaws.com"),
 		endpoint.NewEndpoint("delete-test-ns.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeNS, "foo.elb.amazonaws.com"),
@@ -482,6 +492,8 @@ func TestNewFilteredRecords(t *testing.T) {
 		{Name: "update-test-ns.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"foo.elb.amazonaws.com."}, Type: "NS", Ttl: 120},
 		{Name: "update-test.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"8.8.8.8"}, Type: "A", Ttl: 300},
 		{Name: "update-test-mx.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"10 mail.elb.amazonaws.com."}, Type: "MX", Ttl: 6000},
+		{Name: "update-test-caa.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{`0 issue "letsencrypt.org"`}, Type: "CAA", Ttl: 600},
+		{Name: "update-test-ptr.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"foo.example.org."}, Type: "PTR", Ttl: 600},
 		{Name: "delete-test.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"8.8.8.8"}, Type: "A", Ttl: 300},
 		{Name: "delete-test-cname.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"qux.elb.amazonaws.com."}, Type: "CNAME", Ttl: 300},
 		{Name: "delete-test-ns.zone-1.ext-dns-test-2.gcp.zalan.do.", Rrdatas: []string{"foo.elb.amazonaws.com."}, Type: "NS", Ttl: 300},
@@ -823,7 +835,7 @@ func clearGoogleRecords(t *testing.T, provider *GoogleProvider, zone string) {
 	provider.resourceRecordSetsClient.List(provider.project, zone).Pages(context.Background(), func(resp *dns.ResourceRecordSetsListResponse) error {
 		for _, r := range resp.Rrsets {
 			switch r.Type {
-			case endpoint.RecordTypeA, endpoint.RecordTypeCNAME:
+			case endpoint.RecordTypeA, endpoint.RecordTypeCNAME, endpoint.RecordTypeCAA, endpoint.RecordTypePTR:
 				recordSets = append(recordSets, r)
 			}
 		}
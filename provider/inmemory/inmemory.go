@@ -40,6 +40,8 @@ var (
 	ErrRecordNotFound = errors.New("record not found")
 	// ErrDuplicateRecordFound when record is repeated in create/update/delete
 	ErrDuplicateRecordFound = errors.New("invalid batch request")
+	// ErrInvalidRecord when a record in create/update request has malformed targets
+	ErrInvalidRecord = errors.New("invalid record targets")
 )
 
 // InMemoryProvider - dns provider only used for testing purposes
@@ -304,6 +306,17 @@ func (c *inMemoryClient) updateMesh(mesh sets.Set[endpoint.EndpointKey], record
 	return nil
 }
 
+// validateRecord validates the targets of record types with structured data, like a real DNS server would
+func validateRecord(record *endpoint.Endpoint) error {
+	switch record.RecordType {
+	case endpoint.RecordTypeCAA, endpoint.RecordTypeHTTPS, endpoint.RecordTypeSVCB:
+		if !record.CheckEndpoint() {
+			return ErrInvalidRecord
+		}
+	}
+	return nil
+}
+
 // validateChangeBatch validates that the changes passed to InMemory DNS provider is valid
 func (c *inMemoryClient) validateChangeBatch(zone string, changes *plan.Changes) error {
 	curZone, ok := c.zones[zone]
@@ -315,6 +328,9 @@ func (c *inMemoryClient) validateChangeBatch(zone string, changes *plan.Changes)
 		if _, ok := curZone[newEndpoint.Key()]; ok {
 			return ErrRecordAlreadyExists
 		}
+		if err := validateRecord(newEndpoint); err != nil {
+			return err
+		}
 		if err := c.updateMesh(mesh, newEndpoint); err != nil {
 			return err
 		}
@@ -323,6 +339,9 @@ func (c *inMemoryClient) validateChangeBatch(zone string, changes *plan.Changes)
 		if _, ok := curZone[updateEndpoint.Key()]; !ok {
 			return ErrRecordNotFound
 		}
+		if err := validateRecord(updateEndpoint); err != nil {
+			return err
+		}
 		if err := c.updateMesh(mesh, updateEndpoint); err != nil {
 			return err
 		}
@@ -366,6 +366,80 @@ func testInMemoryValidateChangeBatch(t *testing.T) {
 				},
 			},
 		},
+		{
+			title:       "zones, update, right zone, invalid batch - malformed CAA record",
+			expectError: true,
+			zone:        "org",
+			init:        init,
+			changes: &plan.Changes{
+				Create: []*endpoint.Endpoint{
+					{
+						DNSName:    "example.org",
+						Targets:    endpoint.Targets{"0 issue letsencrypt.org"},
+						RecordType: endpoint.RecordTypeCAA,
+					},
+				},
+				UpdateNew: []*endpoint.Endpoint{},
+				UpdateOld: []*endpoint.Endpoint{},
+				Delete:    []*endpoint.Endpoint{},
+			},
+			errorType: ErrInvalidRecord,
+		},
+		{
+			title:       "zones, update, right zone, valid batch - create CAA record",
+			expectError: false,
+			zone:        "org",
+			init:        init,
+			changes: &plan.Changes{
+				Create: []*endpoint.Endpoint{
+					{
+						DNSName:    "example.org",
+						Targets:    endpoint.Targets{`0 issue "letsencrypt.org"`, `0 iodef "mailto:security@example.org"`},
+						RecordType: endpoint.RecordTypeCAA,
+					},
+				},
+				UpdateNew: []*endpoint.Endpoint{},
+				UpdateOld: []*endpoint.Endpoint{},
+				Delete:    []*endpoint.Endpoint{},
+			},
+		},
+		{
+			title:       "zones, update, right zone, invalid batch - HTTPS AliasMode record with SvcParams",
+			expectError: true,
+			zone:        "org",
+			init:        init,
+			changes: &plan.Changes{
+				Create: []*endpoint.Endpoint{
+					{
+						DNSName:    "example.org",
+						Targets:    endpoint.Targets{"0 svc.example.org alpn=h2"},
+						RecordType: endpoint.RecordTypeHTTPS,
+					},
+				},
+				UpdateNew: []*endpoint.Endpoint{},
+				UpdateOld: []*endpoint.Endpoint{},
+				Delete:    []*endpoint.Endpoint{},
+			},
+			errorType: ErrInvalidRecord,
+		},
+		{
+			title:       "zones, update, right zone, valid batch - create HTTPS record",
+			expectError: false,
+			zone:        "org",
+			init:        init,
+			changes: &plan.Changes{
+				Create: []*endpoint.Endpoint{
+					{
+						DNSName:    "example.org",
+						Targets:    endpoint.Targets{`1 . alpn="h2,h3" port=443`},
+						RecordType: endpoint.RecordTypeHTTPS,
+					},
+				},
+				UpdateNew: []*endpoint.Endpoint{},
+				UpdateOld: []*endpoint.Endpoint{},
+				Delete:    []*endpoint.Endpoint{},
+			},
+		},
 		{
 			title:       "zones, update, right zone, valid batch - update and create",
 			expectError: false,
@@ -223,6 +223,14 @@ OuterLoop:
 		case dns.TypePTR:
 			rrValues = []string{rr.(*dns.PTR).Ptr}
 			rrType = "PTR"
+		case dns.TypeCAA:
+			caa := rr.(*dns.CAA)
+			rrValues = []string{endpoint.NewCAATarget(caa.Flag, caa.Tag, caa.Value)}
+			rrType = "CAA"
+		case dns.TypeHTTPS, dns.TypeSVCB:
+			// the presentation format of the record without its header, e.g. '1 . alpn="h2,h3"'
+			rrValues = []string{endpoint.NormalizeSVCBTarget(strings.TrimPrefix(rr.String(), rr.Header().String()))}
+			rrType = dns.TypeToString[rr.Header().Rrtype]
 		default:
 			continue // Unhandled record type
 		}
@@ -294,6 +294,84 @@ func TestRfc2136GetRecordsMultipleTargets(t *testing.T) {
 	assert.Empty(t, recs[0].ProviderSpecific, "expected no provider specific config")
 }
 
+func TestRfc2136GetRecordsCAA(t *testing.T) {
+	stub := newStub()
+	err := stub.setOutput([]string{
+		`foo.com 3600 IN CAA 0 issue "letsencrypt.org"`,
+		`foo.com 3600 IN CAA 128 iodef "mailto:security@foo.com"`,
+	})
+	assert.NoError(t, err)
+
+	provider, err := createRfc2136StubProvider(stub)
+	assert.NoError(t, err)
+
+	recs, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+
+	assert.Len(t, recs, 1, "expected single record")
+	assert.Equal(t, endpoint.RecordTypeCAA, recs[0].RecordType)
+	assert.ElementsMatch(t, endpoint.Targets{`0 issue "letsencrypt.org"`, `128 iodef "mailto:security@foo.com"`}, recs[0].Targets)
+}
+
+func TestRfc2136CAACreation(t *testing.T) {
+	stub := newStub()
+	provider, err := createRfc2136StubProvider(stub)
+	assert.NoError(t, err)
+
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			{
+				DNSName:    "foo.com",
+				RecordType: endpoint.RecordTypeCAA,
+				Targets:    []string{`0 issue "letsencrypt.org"`},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, stub.createMsgs, 1)
+	assert.Contains(t, strings.Join(strings.Fields(stub.createMsgs[0].String()), " "), `foo.com. 300 IN CAA 0 issue "letsencrypt.org"`)
+}
+
+func TestRfc2136GetRecordsHTTPS(t *testing.T) {
+	stub := newStub()
+	err := stub.setOutput([]string{
+		`foo.com 3600 IN HTTPS 1 . alpn="h3,h2" port="443" ipv4hint="5.6.7.8,1.2.3.4"`,
+		`_dns.foo.com 3600 IN SVCB 1 dns.foo.com. alpn="dot" port="853"`,
+	})
+	assert.NoError(t, err)
+
+	provider, err := createRfc2136StubProvider(stub)
+	assert.NoError(t, err)
+
+	recs, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+
+	require.Len(t, recs, 2)
+	assert.Equal(t, endpoint.RecordTypeHTTPS, recs[0].RecordType)
+	assert.Equal(t, endpoint.Targets{"1 . alpn=h3,h2 port=443 ipv4hint=1.2.3.4,5.6.7.8"}, recs[0].Targets)
+	assert.Equal(t, endpoint.RecordTypeSVCB, recs[1].RecordType)
+	assert.Equal(t, endpoint.Targets{"1 dns.foo.com. alpn=dot port=853"}, recs[1].Targets)
+}
+
+func TestRfc2136HTTPSCreation(t *testing.T) {
+	stub := newStub()
+	provider, err := createRfc2136StubProvider(stub)
+	assert.NoError(t, err)
+
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			{
+				DNSName:    "foo.com",
+				RecordType: endpoint.RecordTypeHTTPS,
+				Targets:    []string{"1 . alpn=h2,h3 port=443"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, stub.createMsgs, 1)
+	assert.Contains(t, strings.Join(strings.Fields(stub.createMsgs[0].String()), " "), `foo.com. 300 IN HTTPS 1 . alpn="h2,h3" port="443"`)
+}
+
 func TestRfc2136PTRCreation(t *testing.T) {
 	stub := newStub()
 	provider, err := createRfc2136StubProviderWithReverse(stub)
@@ -19,6 +19,7 @@ package registry
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
@@ -99,6 +100,34 @@ func (sdr *AWSSDRegistry) updateLabels(endpoints []*endpoint.Endpoint) {
 	}
 }
 
+// serviceDescriptionUpdater is implemented by the AWS SD provider to update the descriptions of services.
+type serviceDescriptionUpdater interface {
+	UpdateServiceDescriptions(ctx context.Context, endpoints []*endpoint.Endpoint) error
+}
+
+// TransferOwnership updates the descriptions of the services of the records owned by the registry to the
+// owner ID to. The instances of the services are not modified.
+func (sdr *AWSSDRegistry) TransferOwnership(ctx context.Context, records []*endpoint.Endpoint, to string) error {
+	updater, ok := sdr.provider.(serviceDescriptionUpdater)
+	if !ok {
+		return fmt.Errorf("provider %T does not support updating service descriptions", sdr.provider)
+	}
+
+	owned := endpoint.FilterEndpointsByOwnerID(sdr.ownerID, records)
+	transferred := make([]*endpoint.Endpoint, 0, len(owned))
+	for _, r := range owned {
+		ep := r.DeepCopy()
+		ep.Labels[endpoint.OwnerLabelKey] = to
+		delete(ep.Labels, endpoint.AWSSDDescriptionLabel)
+		ep.Labels[endpoint.AWSSDDescriptionLabel] = ep.Labels.SerializePlain(false)
+		transferred = append(transferred, ep)
+	}
+	if len(transferred) == 0 {
+		return nil
+	}
+	return updater.UpdateServiceDescriptions(ctx, transferred)
+}
+
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (sdr *AWSSDRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	return sdr.provider.AdjustEndpoints(endpoints)
@@ -159,6 +159,42 @@ func TestAWSSDRegistry_Records_ApplyChanges(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// describedProvider records the endpoints of the services whose descriptions are updated.
+type describedProvider struct {
+	*inMemoryProvider
+	described []*endpoint.Endpoint
+}
+
+func (p *describedProvider) UpdateServiceDescriptions(_ context.Context, endpoints []*endpoint.Endpoint) error {
+	p.described = append(p.described, endpoints...)
+	return nil
+}
+
+func TestAWSSDRegistry_TransferOwnership(t *testing.T) {
+	p := &describedProvider{inMemoryProvider: newInMemoryProvider([]*endpoint.Endpoint{
+		newEndpointWithOwnerAndDescription("foo1.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "", ""),
+		newEndpointWithOwnerAndDescription("foo2.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "old", "heritage=external-dns,external-dns/owner=old,external-dns/resource=service/default/foo"),
+		newEndpointWithOwnerAndDescription("foo3.test-zone.example.org", "my-domain.com", endpoint.RecordTypeCNAME, "other", "heritage=external-dns,external-dns/owner=other"),
+	}, func(*plan.Changes) {
+		t.Error("unexpected changes of the records")
+	})}
+	r, err := NewAWSSDRegistry(p, "old")
+	require.NoError(t, err)
+	records, err := r.Records(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, r.TransferOwnership(context.Background(), records, "new"))
+	require.Len(t, p.described, 1)
+	assert.Equal(t, "foo2.test-zone.example.org", p.described[0].DNSName)
+	assert.Equal(t, "heritage=external-dns,external-dns/owner=new,external-dns/resource=service/default/foo", p.described[0].Labels[endpoint.AWSSDDescriptionLabel])
+	// the records read from the registry are not modified
+	assert.Equal(t, "old", records[1].Labels[endpoint.OwnerLabelKey])
+
+	r, err = NewAWSSDRegistry(newInMemoryProvider(nil, nil), "old")
+	require.NoError(t, err)
+	assert.ErrorContains(t, r.TransferOwnership(context.Background(), records, "new"), "does not support")
+}
+
 func newEndpointWithOwnerAndDescription(dnsName, target, recordType, ownerID string, description string) *endpoint.Endpoint {
 	e := endpoint.NewEndpoint(dnsName, recordType, target)
 	e.Labels[endpoint.OwnerLabelKey] = ownerID
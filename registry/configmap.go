@@ -0,0 +1,477 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// configMapRegistryLabelKey is the label of the ConfigMaps of a registry, whose value is the registry name
+	configMapRegistryLabelKey = "externaldns.k8s.io/registry"
+	configMapManagedByLabel   = "app.kubernetes.io/managed-by"
+	// podNamespaceEnv is the environment variable the namespace of the pod is usually exposed in with the downward API
+	podNamespaceEnv = "POD_NAMESPACE"
+)
+
+// serviceAccountNamespaceFile holds the namespace of the pod in the service account volume.
+var serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// ConfigMapRegistry implements registry interface with ownership stored in a sharded set of Kubernetes ConfigMaps.
+// Every record has an entry in one of the ConfigMaps, which are updated with optimistic concurrency, so that
+// several instances with different owner IDs can share them.
+type ConfigMapRegistry struct {
+	provider provider.Provider
+	ownerID  string // refers to the owner id of the current instance
+
+	client    kubernetes.Interface
+	namespace string
+	name      string
+	shards    int
+
+	// the entries read by the last call to Records, by record
+	entries map[endpoint.EndpointKey]configMapEntry
+	// the entries owned by us whose record no longer exists, deleted by the next ApplyChanges
+	orphanedEntries []endpoint.EndpointKey
+}
+
+// configMapEntry is the ownership of a record, stored as JSON in a ConfigMap.
+type configMapEntry struct {
+	DNSName       string          `json:"dnsName"`
+	RecordType    string          `json:"recordType"`
+	SetIdentifier string          `json:"setIdentifier,omitempty"`
+	Owner         string          `json:"owner"`
+	Labels        endpoint.Labels `json:"labels,omitempty"`
+
+	// the ConfigMap the entry is stored in
+	shard string
+}
+
+// NewConfigMapRegistry returns a new ConfigMapRegistry object, which stores the ownership in the ConfigMaps
+// "<name>-0" to "<name>-<shards-1>" of the namespace. An empty namespace is the namespace of the pod.
+func NewConfigMapRegistry(provider provider.Provider, ownerID string, client kubernetes.Interface, namespace, name string, shards int) (*ConfigMapRegistry, error) {
+	if ownerID == "" {
+		return nil, errors.New("owner id cannot be empty")
+	}
+	if namespace == "" {
+		namespace = podNamespace()
+	}
+	if namespace == "" || name == "" {
+		return nil, errors.New("the namespace and name of the ConfigMaps cannot be empty")
+	}
+	if shards < 1 {
+		return nil, errors.New("the number of ConfigMap shards must be positive")
+	}
+
+	return &ConfigMapRegistry{
+		provider:  provider,
+		ownerID:   ownerID,
+		client:    client,
+		namespace: namespace,
+		name:      name,
+		shards:    shards,
+	}, nil
+}
+
+// podNamespace returns the namespace of the pod, from the downward API or from the service account,
+// or an empty string out of the cluster.
+func podNamespace() string {
+	if namespace := os.Getenv(podNamespaceEnv); namespace != "" {
+		return namespace
+	}
+	data, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func (im *ConfigMapRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
+	return im.provider.GetDomainFilter()
+}
+
+func (im *ConfigMapRegistry) OwnerID() string {
+	return im.ownerID
+}
+
+// Records returns the current records from the registry, labeled by their entries in the ConfigMaps.
+func (im *ConfigMapRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	entries, err := im.readEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := im.provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[endpoint.EndpointKey]struct{}, len(records))
+	for _, record := range records {
+		key := record.Key()
+		existing[key] = struct{}{}
+		record.Labels = endpoint.NewLabels()
+		if entry, ok := entries[key]; ok {
+			for k, v := range entry.Labels {
+				record.Labels[k] = v
+			}
+			record.Labels[endpoint.OwnerLabelKey] = entry.Owner
+		}
+	}
+
+	// the records of other domains are not read, e.g. those of another instance with the same owner ID
+	domainFilter := im.provider.GetDomainFilter()
+	var orphaned []endpoint.EndpointKey
+	for key, entry := range entries {
+		if _, ok := existing[key]; !ok && entry.Owner == im.ownerID && domainFilter.Match(key.DNSName) {
+			orphaned = append(orphaned, key)
+		}
+	}
+
+	im.entries = entries
+	im.orphanedEntries = orphaned
+	return records, nil
+}
+
+// ApplyChanges writes the entries of the created and updated records owned by us to the ConfigMaps, before the
+// changes are applied to the DNS provider, and deletes the entries of the deleted and orphaned records afterwards.
+// Records whose entry is owned by another owner in the meantime are skipped.
+func (im *ConfigMapRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	filteredChanges := &plan.Changes{
+		Create:    changes.Create,
+		UpdateNew: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateNew),
+		UpdateOld: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateOld),
+		Delete:    endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.Delete),
+	}
+
+	puts := make(map[endpoint.EndpointKey]configMapEntry, len(filteredChanges.Create)+len(filteredChanges.UpdateNew))
+	for _, r := range filteredChanges.Create {
+		if r.Labels == nil {
+			r.Labels = make(map[string]string)
+		}
+		r.Labels[endpoint.OwnerLabelKey] = im.ownerID
+		puts[r.Key()] = im.newEntry(r.Key(), im.ownerID, r.Labels)
+	}
+	for _, r := range filteredChanges.UpdateNew {
+		puts[r.Key()] = im.newEntry(r.Key(), im.ownerID, r.Labels)
+	}
+
+	rejected, err := im.writeEntries(ctx, puts, nil)
+	if err != nil {
+		im.entries = nil
+		return err
+	}
+	if len(rejected) > 0 {
+		filteredChanges.Create = withoutKeys(filteredChanges.Create, rejected)
+		filteredChanges.UpdateOld = withoutKeys(filteredChanges.UpdateOld, rejected)
+		filteredChanges.UpdateNew = withoutKeys(filteredChanges.UpdateNew, rejected)
+	}
+
+	if err := im.provider.ApplyChanges(ctx, filteredChanges); err != nil {
+		im.entries = nil
+		return err
+	}
+
+	deletes := slices.Clone(im.orphanedEntries)
+	for _, r := range filteredChanges.Delete {
+		deletes = append(deletes, r.Key())
+	}
+	im.orphanedEntries = nil
+	_, err = im.writeEntries(ctx, nil, deletes)
+	im.entries = nil
+	return err
+}
+
+// TransferOwnership changes the owner of the entries of the records owned by the registry to the owner ID to.
+// The records in the provider are not modified.
+func (im *ConfigMapRegistry) TransferOwnership(ctx context.Context, records []*endpoint.Endpoint, to string) error {
+	puts := map[endpoint.EndpointKey]configMapEntry{}
+	for _, r := range endpoint.FilterEndpointsByOwnerID(im.ownerID, records) {
+		puts[r.Key()] = im.newEntry(r.Key(), to, r.Labels)
+	}
+	rejected, err := im.writeEntries(ctx, puts, nil)
+	im.entries = nil
+	if err != nil {
+		return err
+	}
+	if len(rejected) > 0 {
+		return fmt.Errorf("%d records are owned by another owner", len(rejected))
+	}
+	return nil
+}
+
+// OrphanedOwnership returns the entries of all owners in the domain filter of the provider whose record no
+// longer exists.
+func (im *ConfigMapRegistry) OrphanedOwnership(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	entries, err := im.readEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records, err := im.provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		delete(entries, record.Key())
+	}
+	domainFilter := im.provider.GetDomainFilter()
+	var orphaned []*endpoint.Endpoint
+	for _, entry := range entries {
+		if !domainFilter.Match(entry.DNSName) {
+			continue
+		}
+		orphan := &endpoint.Endpoint{
+			DNSName:       entry.DNSName,
+			RecordType:    entry.RecordType,
+			SetIdentifier: entry.SetIdentifier,
+			Labels:        endpoint.NewLabels(),
+		}
+		for k, v := range entry.Labels {
+			orphan.Labels[k] = v
+		}
+		orphan.Labels[endpoint.OwnerLabelKey] = entry.Owner
+		orphaned = append(orphaned, orphan)
+	}
+	return orphaned, nil
+}
+
+// AdjustEndpoints modifies the endpoints as needed by the specific provider
+func (im *ConfigMapRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return im.provider.AdjustEndpoints(endpoints)
+}
+
+// newEntry returns the entry of the record with the given key, stored in its current ConfigMap if it has one.
+func (im *ConfigMapRegistry) newEntry(key endpoint.EndpointKey, owner string, recordLabels endpoint.Labels) configMapEntry {
+	entryLabels := endpoint.NewLabels()
+	for k, v := range recordLabels {
+		if k != endpoint.OwnerLabelKey {
+			entryLabels[k] = v
+		}
+	}
+	shard := im.shardName(key)
+	if current, ok := im.entries[key]; ok {
+		shard = current.shard
+	}
+	return configMapEntry{
+		DNSName:       key.DNSName,
+		RecordType:    key.RecordType,
+		SetIdentifier: key.SetIdentifier,
+		Owner:         owner,
+		Labels:        entryLabels,
+		shard:         shard,
+	}
+}
+
+// shardName returns the name of the ConfigMap which stores new entries of the record with the given key.
+func (im *ConfigMapRegistry) shardName(key endpoint.EndpointKey) string {
+	h := fnv.New32a()
+	h.Write([]byte(configMapDataKey(key)))
+	return fmt.Sprintf("%s-%d", im.name, h.Sum32()%uint32(im.shards))
+}
+
+// readEntries lists the ConfigMaps of the registry, including those of a previous number of shards, and
+// returns their entries.
+func (im *ConfigMapRegistry) readEntries(ctx context.Context) (map[endpoint.EndpointKey]configMapEntry, error) {
+	list, err := im.client.CoreV1().ConfigMaps(im.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(im.configMapLabels()).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing registry ConfigMaps in namespace %q: %w", im.namespace, err)
+	}
+
+	entries := map[endpoint.EndpointKey]configMapEntry{}
+	for _, cm := range list.Items {
+		for dataKey, value := range cm.Data {
+			var entry configMapEntry
+			if err := json.Unmarshal([]byte(value), &entry); err != nil {
+				log.Warnf("Skipping invalid entry %q of registry ConfigMap %s/%s: %v", dataKey, cm.Namespace, cm.Name, err)
+				continue
+			}
+			entry.shard = cm.Name
+			entries[entry.key()] = entry
+		}
+	}
+	return entries, nil
+}
+
+// writeEntries stores and deletes the entries in their ConfigMaps. Entries owned by another owner in the
+// ConfigMaps are neither overwritten nor deleted, the keys of the rejected stores are returned. Every ConfigMap
+// is updated based on its resourceVersion and the update is retried on conflicts.
+func (im *ConfigMapRegistry) writeEntries(ctx context.Context, puts map[endpoint.EndpointKey]configMapEntry, deletes []endpoint.EndpointKey) (map[endpoint.EndpointKey]bool, error) {
+	putsByShard := map[string][]configMapEntry{}
+	for _, entry := range puts {
+		putsByShard[entry.shard] = append(putsByShard[entry.shard], entry)
+	}
+	deletesByShard := map[string][]endpoint.EndpointKey{}
+	for _, key := range deletes {
+		shard := im.shardName(key)
+		if current, ok := im.entries[key]; ok {
+			shard = current.shard
+		}
+		deletesByShard[shard] = append(deletesByShard[shard], key)
+	}
+
+	shards := make([]string, 0, len(putsByShard)+len(deletesByShard))
+	for shard := range putsByShard {
+		shards = append(shards, shard)
+	}
+	for shard := range deletesByShard {
+		if _, ok := putsByShard[shard]; !ok {
+			shards = append(shards, shard)
+		}
+	}
+	slices.Sort(shards)
+
+	rejected := map[endpoint.EndpointKey]bool{}
+	for _, shard := range shards {
+		var shardRejected []endpoint.EndpointKey
+		err := im.updateConfigMap(ctx, shard, func(data map[string]string) error {
+			// the data is read again on every retry
+			shardRejected = nil
+			for _, entry := range putsByShard[shard] {
+				dataKey := configMapDataKey(entry.key())
+				if owner := entryOwner(data[dataKey]); owner != "" && owner != im.ownerID {
+					log.Infof("Skipping record %s %s because it is owned by %q", entry.RecordType, entry.DNSName, owner)
+					shardRejected = append(shardRejected, entry.key())
+					continue
+				}
+				value, err := json.Marshal(entry)
+				if err != nil {
+					return err
+				}
+				data[dataKey] = string(value)
+			}
+			for _, key := range deletesByShard[shard] {
+				dataKey := configMapDataKey(key)
+				if entryOwner(data[dataKey]) == im.ownerID {
+					delete(data, dataKey)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("updating registry ConfigMap %s/%s: %w", im.namespace, shard, err)
+		}
+		for _, key := range shardRejected {
+			rejected[key] = true
+		}
+	}
+	return rejected, nil
+}
+
+// updateConfigMap reads the ConfigMap, creating it if needed, modifies its data and updates it with the
+// resourceVersion it was read with. The modification is retried on conflicts with other writers.
+func (im *ConfigMapRegistry) updateConfigMap(ctx context.Context, name string, modify func(data map[string]string) error) error {
+	configMaps := im.client.CoreV1().ConfigMaps(im.namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: im.namespace,
+					Labels:    im.configMapLabels(),
+				},
+				Data: map[string]string{},
+			}
+			if err := modify(cm.Data); err != nil {
+				return err
+			}
+			_, err = configMaps.Create(ctx, cm, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// created by another writer since it was read
+				return apierrors.NewConflict(corev1.Resource("configmaps"), name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		if err := modify(cm.Data); err != nil {
+			return err
+		}
+		_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (im *ConfigMapRegistry) configMapLabels() map[string]string {
+	return map[string]string{
+		configMapManagedByLabel:   "external-dns",
+		configMapRegistryLabelKey: im.name,
+	}
+}
+
+func (e configMapEntry) key() endpoint.EndpointKey {
+	return endpoint.EndpointKey{DNSName: e.DNSName, RecordType: e.RecordType, SetIdentifier: e.SetIdentifier}
+}
+
+// configMapDataKey returns the key of the entry of a record in the data of a ConfigMap. Record names and set
+// identifiers may contain characters which are not allowed in ConfigMap keys, so the key is hashed.
+func configMapDataKey(key endpoint.EndpointKey) string {
+	sum := sha256.Sum256([]byte(key.DNSName + "#" + key.RecordType + "#" + key.SetIdentifier))
+	return hex.EncodeToString(sum[:16])
+}
+
+// entryOwner returns the owner of the entry stored in the value, empty if there is none.
+func entryOwner(value string) string {
+	if value == "" {
+		return ""
+	}
+	var entry configMapEntry
+	if err := json.Unmarshal([]byte(value), &entry); err != nil {
+		return ""
+	}
+	return entry.Owner
+}
+
+// withoutKeys returns the endpoints whose key is not in keys.
+func withoutKeys(endpoints []*endpoint.Endpoint, keys map[endpoint.EndpointKey]bool) []*endpoint.Endpoint {
+	var result []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if !keys[ep.Key()] {
+			result = append(result, ep)
+		}
+	}
+	return result
+}
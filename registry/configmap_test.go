@@ -0,0 +1,296 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+func newConfigMapRegistryProvider(t *testing.T) *inmemory.InMemoryProvider {
+	t.Helper()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	return p
+}
+
+func registryEntryCount(t *testing.T, client *fake.Clientset) int {
+	t.Helper()
+	list, err := client.CoreV1().ConfigMaps("external-dns").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	count := 0
+	for _, cm := range list.Items {
+		assert.Equal(t, "registry", cm.Labels[configMapRegistryLabelKey])
+		count += len(cm.Data)
+	}
+	return count
+}
+
+func TestNewConfigMapRegistry(t *testing.T) {
+	p := newConfigMapRegistryProvider(t)
+	client := fake.NewClientset()
+
+	_, err := NewConfigMapRegistry(p, "", client, "external-dns", "registry", 1)
+	require.EqualError(t, err, "owner id cannot be empty")
+	_, err = NewConfigMapRegistry(p, "owner", client, "external-dns", "", 1)
+	require.Error(t, err)
+	_, err = NewConfigMapRegistry(p, "owner", client, "external-dns", "registry", 0)
+	require.EqualError(t, err, "the number of ConfigMap shards must be positive")
+
+	r, err := NewConfigMapRegistry(p, "owner", client, "external-dns", "registry", 4)
+	require.NoError(t, err)
+	assert.Equal(t, "owner", r.OwnerID())
+}
+
+func TestNewConfigMapRegistryPodNamespace(t *testing.T) {
+	p := newConfigMapRegistryProvider(t)
+	client := fake.NewClientset()
+
+	namespaceFile := filepath.Join(t.TempDir(), "namespace")
+	defer func(file string) { serviceAccountNamespaceFile = file }(serviceAccountNamespaceFile)
+	serviceAccountNamespaceFile = namespaceFile
+	t.Setenv(podNamespaceEnv, "")
+
+	_, err := NewConfigMapRegistry(p, "owner", client, "", "registry", 1)
+	require.EqualError(t, err, "the namespace and name of the ConfigMaps cannot be empty")
+
+	require.NoError(t, os.WriteFile(namespaceFile, []byte("from-service-account\n"), 0o600))
+	r, err := NewConfigMapRegistry(p, "owner", client, "", "registry", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "from-service-account", r.namespace)
+
+	t.Setenv(podNamespaceEnv, "from-downward-api")
+	r, err = NewConfigMapRegistry(p, "owner", client, "", "registry", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "from-downward-api", r.namespace)
+
+	r, err = NewConfigMapRegistry(p, "owner", client, "external-dns", "registry", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "external-dns", r.namespace)
+}
+
+func TestConfigMapRegistryApplyChanges(t *testing.T) {
+	ctx := context.Background()
+	p := newConfigMapRegistryProvider(t)
+	client := fake.NewClientset()
+	r, err := NewConfigMapRegistry(p, "owner", client, "external-dns", "registry", 4)
+	require.NoError(t, err)
+
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwnerResource("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "", "ingress/default/foo"),
+			newEndpointWithOwnerResource("bar.test-zone.example.org", "foo.test-zone.example.org", endpoint.RecordTypeCNAME, "", "ingress/default/bar"),
+			newEndpointWithOwnerResource("*.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, "", "ingress/default/wildcard"),
+		},
+	}))
+	assert.Equal(t, 3, registryEntryCount(t, client))
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+		newEndpointWithOwnerResource("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "owner", "ingress/default/foo"),
+		newEndpointWithOwnerResource("bar.test-zone.example.org", "foo.test-zone.example.org", endpoint.RecordTypeCNAME, "owner", "ingress/default/bar"),
+		newEndpointWithOwnerResource("*.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, "owner", "ingress/default/wildcard"),
+	}))
+
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{
+			newEndpointWithOwnerResource("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "owner", "ingress/default/foo"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			newEndpointWithOwnerResource("foo.test-zone.example.org", "1.1.1.3", endpoint.RecordTypeA, "owner", "ingress/default/foo-new"),
+		},
+		Delete: []*endpoint.Endpoint{
+			newEndpointWithOwnerResource("bar.test-zone.example.org", "foo.test-zone.example.org", endpoint.RecordTypeCNAME, "owner", "ingress/default/bar"),
+		},
+	}))
+	assert.Equal(t, 2, registryEntryCount(t, client))
+
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+		newEndpointWithOwnerResource("foo.test-zone.example.org", "1.1.1.3", endpoint.RecordTypeA, "owner", "ingress/default/foo-new"),
+		newEndpointWithOwnerResource("*.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, "owner", "ingress/default/wildcard"),
+	}))
+}
+
+func TestConfigMapRegistryOrphanedEntries(t *testing.T) {
+	ctx := context.Background()
+	p := newConfigMapRegistryProvider(t)
+	client := fake.NewClientset()
+	r, err := NewConfigMapRegistry(p, "owner", client, "external-dns", "registry", 1)
+	require.NoError(t, err)
+	other, err := NewConfigMapRegistry(newConfigMapRegistryProvider(t), "other", client, "external-dns", "registry", 1)
+	require.NoError(t, err)
+
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""),
+		},
+	}))
+	_, err = other.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, other.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("bar.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, ""),
+		},
+	}))
+
+	// the record is deleted outside of external-dns
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Delete: []*endpoint.Endpoint{newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "")},
+	}))
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{}))
+
+	// only the entry of the other owner is left
+	assert.Equal(t, 1, registryEntryCount(t, client))
+}
+
+func TestConfigMapRegistryOrphanedOwnership(t *testing.T) {
+	ctx := context.Background()
+	p := newConfigMapRegistryProvider(t)
+	client := fake.NewClientset()
+	r, err := NewConfigMapRegistry(p, "owner", client, "external-dns", "registry", 1)
+	require.NoError(t, err)
+	other, err := NewConfigMapRegistry(p, "other", client, "external-dns", "registry", 1)
+	require.NoError(t, err)
+
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "")},
+	}))
+	_, err = other.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, other.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("bar.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, "")},
+	}))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Delete: []*endpoint.Endpoint{newEndpointWithOwner("bar.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, "")},
+	}))
+
+	orphaned, err := r.OrphanedOwnership(ctx)
+	require.NoError(t, err)
+	require.Len(t, orphaned, 1)
+	assert.Equal(t, "bar.test-zone.example.org", orphaned[0].DNSName)
+	assert.Equal(t, "other", orphaned[0].Labels[endpoint.OwnerLabelKey])
+	// the orphaned entries are only reported
+	assert.Equal(t, 2, registryEntryCount(t, client))
+}
+
+func TestConfigMapRegistryOwnedByOtherOwner(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+	r, err := NewConfigMapRegistry(newConfigMapRegistryProvider(t), "owner", client, "external-dns", "registry", 2)
+	require.NoError(t, err)
+	otherProvider := newConfigMapRegistryProvider(t)
+	other, err := NewConfigMapRegistry(otherProvider, "other", client, "external-dns", "registry", 2)
+	require.NoError(t, err)
+
+	// both instances read the registry before either created the record
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	_, err = other.Records(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "")},
+	}))
+	require.NoError(t, other.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("foo.test-zone.example.org", "2.2.2.2", endpoint.RecordTypeA, "")},
+	}))
+
+	// the record of the other owner was skipped
+	records, err := otherProvider.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.Equal(t, 1, registryEntryCount(t, client))
+}
+
+func TestConfigMapRegistryRetriesOnConflict(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "registry-0",
+			Namespace: "external-dns",
+			Labels:    map[string]string{configMapRegistryLabelKey: "registry"},
+		},
+	})
+	conflicts := 0
+	client.PrependReactor("update", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicts < 2 {
+			conflicts++
+			return true, nil, apierrors.NewConflict(corev1.Resource("configmaps"), "registry-0", nil)
+		}
+		return false, nil, nil
+	})
+	r, err := NewConfigMapRegistry(newConfigMapRegistryProvider(t), "owner", client, "external-dns", "registry", 1)
+	require.NoError(t, err)
+
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "")},
+	}))
+	assert.Equal(t, 2, conflicts)
+	assert.Equal(t, 1, registryEntryCount(t, client))
+}
+
+func TestConfigMapRegistryTransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientset()
+	p := newConfigMapRegistryProvider(t)
+	r, err := NewConfigMapRegistry(p, "old", client, "external-dns", "registry", 1)
+	require.NoError(t, err)
+
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwnerResource("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "", "ingress/default/foo")},
+	}))
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.TransferOwnership(ctx, records, "new"))
+
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+		newEndpointWithOwnerResource("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "new", "ingress/default/foo"),
+	}))
+}
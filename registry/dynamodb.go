@@ -264,7 +264,8 @@ func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Chan
 	for _, r := range filteredChanges.UpdateOld {
 		oldLabels[r.Key()] = r.Labels
 
-		if _, ok := r.GetProviderSpecificProperty(dynamodbAttributeMigrate); ok {
+		// records adopted by the plan are inserted like records migrated from the TXT registry
+		if _, ok := r.GetProviderSpecificProperty(dynamodbAttributeMigrate); ok || r.Labels[endpoint.AdoptedLabelKey] == "true" {
 			needMigration[r.Key()] = true
 		}
 
@@ -361,6 +362,87 @@ func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Chan
 	})
 }
 
+// TransferOwnership changes the owner of the DynamoDB records of the records owned by the registry to the
+// owner ID to. Records still owned through TXT records are inserted with the new owner, as they would be migrated,
+// and the new owner deletes their TXT records. Leftover TXT ownership records are skipped, and the records in the
+// provider are not modified.
+func (im *DynamoDBRegistry) TransferOwnership(ctx context.Context, records []*endpoint.Endpoint, to string) error {
+	if im.labels == nil {
+		if err := im.readLabels(ctx); err != nil {
+			return err
+		}
+	}
+
+	owned := endpoint.FilterEndpointsByOwnerID(im.ownerID, records)
+	statements := make([]dynamodbtypes.BatchStatementRequest, 0, len(owned))
+	for _, r := range owned {
+		key := r.Key()
+		if _, ok := r.GetProviderSpecificProperty(dynamodbAttributeMigrate); ok {
+			statements = append(statements, dynamodbtypes.BatchStatementRequest{
+				Statement:      aws.String(fmt.Sprintf("INSERT INTO %q VALUE {'k':?, 'o':?, 'l':?}", im.table)),
+				ConsistentRead: aws.Bool(true),
+				Parameters: []dynamodbtypes.AttributeValue{
+					toDynamoKey(key),
+					&dynamodbtypes.AttributeValueMemberS{Value: to},
+					toDynamoLabels(r.Labels),
+				},
+			})
+		} else if _, ok := im.labels[key]; ok {
+			statements = append(statements, dynamodbtypes.BatchStatementRequest{
+				Statement: aws.String(fmt.Sprintf("UPDATE %q SET \"o\"=? WHERE \"k\"=? AND \"o\"=?", im.table)),
+				Parameters: []dynamodbtypes.AttributeValue{
+					&dynamodbtypes.AttributeValueMemberS{Value: to},
+					toDynamoKey(key),
+					&dynamodbtypes.AttributeValueMemberS{Value: im.ownerID},
+				},
+			})
+		}
+	}
+
+	// the cached labels are no longer owned by us
+	im.recordsCache = nil
+	im.labels = nil
+	return im.executeStatements(ctx, statements, func(request dynamodbtypes.BatchStatementRequest, response dynamodbtypes.BatchStatementResponse) error {
+		record, err := fromDynamoKey(request.Parameters[1])
+		if strings.HasPrefix(*request.Statement, "INSERT") {
+			record, err = fromDynamoKey(request.Parameters[0])
+		}
+		if err != nil {
+			return fmt.Errorf("transferring dynamodb record: %w", err)
+		}
+		return fmt.Errorf("transferring dynamodb record %q: %s: %s", record, response.Error.Code, *response.Error.Message)
+	})
+}
+
+// OrphanedOwnership returns the items of the table owned by the registry whose record no longer exists.
+// The items of other owners are not read.
+func (im *DynamoDBRegistry) OrphanedOwnership(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	if err := im.readLabels(ctx); err != nil {
+		return nil, err
+	}
+	records, err := im.provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := sets.New[endpoint.EndpointKey]()
+	for _, record := range records {
+		existing.Insert(record.Key())
+	}
+	var orphaned []*endpoint.Endpoint
+	for key, labels := range im.labels {
+		if !existing.Has(key) {
+			orphaned = append(orphaned, &endpoint.Endpoint{
+				DNSName:       key.DNSName,
+				RecordType:    key.RecordType,
+				SetIdentifier: key.SetIdentifier,
+				Labels:        labels,
+			})
+		}
+	}
+	return orphaned, nil
+}
+
 // AdjustEndpoints modifies the endpoints as needed by the specific provider.
 func (im *DynamoDBRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	return im.provider.AdjustEndpoints(endpoints)
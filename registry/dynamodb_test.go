@@ -942,6 +942,79 @@ func TestDynamoDBRegistryApplyChanges(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "update adopted",
+			changes: plan.Changes{
+				UpdateOld: []*endpoint.Endpoint{
+					{
+						DNSName:    "foo.test-zone.example.org",
+						Targets:    endpoint.Targets{"foo.loadbalancer.com"},
+						RecordType: endpoint.RecordTypeCNAME,
+						Labels: map[string]string{
+							endpoint.OwnerLabelKey:   "test-owner",
+							endpoint.AdoptedLabelKey: "true",
+						},
+					},
+				},
+				UpdateNew: []*endpoint.Endpoint{
+					{
+						DNSName:    "foo.test-zone.example.org",
+						Targets:    endpoint.Targets{"foo.loadbalancer.com"},
+						RecordType: endpoint.RecordTypeCNAME,
+						Labels: map[string]string{
+							endpoint.OwnerLabelKey:    "test-owner",
+							endpoint.ResourceLabelKey: "ingress/default/foo-ingress",
+						},
+					},
+				},
+			},
+			stubConfig: DynamoDBStubConfig{
+				ExpectDelete: sets.New("quux.test-zone.example.org#A#set-2"),
+				ExpectInsert: map[string]map[string]string{
+					"foo.test-zone.example.org#CNAME#": {endpoint.ResourceLabelKey: "ingress/default/foo-ingress"},
+				},
+			},
+			expectedRecords: []*endpoint.Endpoint{
+				{
+					DNSName:    "foo.test-zone.example.org",
+					Targets:    endpoint.Targets{"foo.loadbalancer.com"},
+					RecordType: endpoint.RecordTypeCNAME,
+					Labels: map[string]string{
+						endpoint.OwnerLabelKey:    "test-owner",
+						endpoint.ResourceLabelKey: "ingress/default/foo-ingress",
+					},
+				},
+				{
+					DNSName:    "bar.test-zone.example.org",
+					Targets:    endpoint.Targets{"my-domain.com"},
+					RecordType: endpoint.RecordTypeCNAME,
+					Labels: map[string]string{
+						endpoint.OwnerLabelKey:    "test-owner",
+						endpoint.ResourceLabelKey: "ingress/default/my-ingress",
+					},
+				},
+				{
+					DNSName:       "baz.test-zone.example.org",
+					Targets:       endpoint.Targets{"1.1.1.1"},
+					RecordType:    endpoint.RecordTypeA,
+					SetIdentifier: "set-1",
+					Labels: map[string]string{
+						endpoint.OwnerLabelKey:    "test-owner",
+						endpoint.ResourceLabelKey: "ingress/default/my-ingress",
+					},
+				},
+				{
+					DNSName:       "baz.test-zone.example.org",
+					Targets:       endpoint.Targets{"2.2.2.2"},
+					RecordType:    endpoint.RecordTypeA,
+					SetIdentifier: "set-2",
+					Labels: map[string]string{
+						endpoint.OwnerLabelKey:    "test-owner",
+						endpoint.ResourceLabelKey: "ingress/default/other-ingress",
+					},
+				},
+			},
+		},
 		{
 			name: "update error",
 			changes: plan.Changes{
@@ -1110,6 +1183,54 @@ func TestDynamoDBRegistryApplyChanges(t *testing.T) {
 	}
 }
 
+func TestDynamoDBRegistryTransferOwnership(t *testing.T) {
+	api, p := newDynamoDBAPIStub(t, &DynamoDBStubConfig{
+		ExpectInsert: map[string]map[string]string{
+			"migrate.test-zone.example.org#A#set-3": {endpoint.ResourceLabelKey: "ingress/default/other-ingress"},
+		},
+		ExpectTransfer: map[string]string{
+			"bar.test-zone.example.org#CNAME#":      "new-owner",
+			"baz.test-zone.example.org#A#set-1":     "new-owner",
+			"baz.test-zone.example.org#A#set-2":     "new-owner",
+			"migrate.test-zone.example.org#A#set-3": "new-owner",
+		},
+	})
+	_ = p.(*wrappedProvider).Provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("migrate.test-zone.example.org", endpoint.RecordTypeA, "3.3.3.3").WithSetIdentifier("set-3"),
+			endpoint.NewEndpoint("txt.migrate.test-zone.example.org", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=test-owner,external-dns/resource=ingress/default/other-ingress\"").WithSetIdentifier("set-3"),
+			endpoint.NewEndpoint("txt.orphaned.test-zone.example.org", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=test-owner,external-dns/resource=ingress/default/other-ingress\"").WithSetIdentifier("set-3"),
+		},
+	})
+
+	ctx := context.Background()
+	r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "txt.", "", "", []string{}, []string{}, nil, time.Hour)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, r.TransferOwnership(ctx, records, "new-owner"))
+	assert.Empty(t, api.stubConfig.ExpectInsert, "all expected inserts made")
+	assert.Empty(t, api.stubConfig.ExpectTransfer, "all expected transfers made")
+	// the records in the provider are not modified
+	assert.False(t, api.changesApplied)
+	assert.Nil(t, r.labels)
+	assert.Nil(t, r.recordsCache)
+}
+
+func TestDynamoDBRegistryOrphanedOwnership(t *testing.T) {
+	api, p := newDynamoDBAPIStub(t, &DynamoDBStubConfig{})
+	r, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, 0)
+	require.NoError(t, err)
+
+	orphaned, err := r.OrphanedOwnership(context.Background())
+	require.NoError(t, err)
+	require.Len(t, orphaned, 1)
+	assert.Equal(t, endpoint.EndpointKey{DNSName: "quux.test-zone.example.org", RecordType: endpoint.RecordTypeA, SetIdentifier: "set-2"}, orphaned[0].Key())
+	assert.Equal(t, "test-owner", orphaned[0].Labels[endpoint.OwnerLabelKey])
+	assert.Equal(t, "ingress/default/quux-ingress", orphaned[0].Labels[endpoint.ResourceLabelKey])
+	assert.False(t, api.changesApplied)
+}
+
 // DynamoDBAPIStub is a minimal implementation of DynamoDBAPI, used primarily for unit testing.
 type DynamoDBStub struct {
 	t                *testing.T
@@ -1124,6 +1245,8 @@ type DynamoDBStubConfig struct {
 	ExpectUpdate      map[string]map[string]string
 	ExpectUpdateError map[string]dynamodbtypes.BatchStatementErrorCodeEnum
 	ExpectDelete      sets.Set[string]
+	// ExpectTransfer maps keys to the owner their ownership is transferred to
+	ExpectTransfer map[string]string
 }
 
 type wrappedProvider struct {
@@ -1264,9 +1387,14 @@ func (r *DynamoDBStub) BatchExecuteStatement(context context.Context, input *dyn
 			assert.True(r.t, found, "unexpected insert for key %q", key)
 			delete(r.stubConfig.ExpectInsert, key)
 
+			expectedOwner := "test-owner"
+			if owner, ok := r.stubConfig.ExpectTransfer[key]; ok {
+				delete(r.stubConfig.ExpectTransfer, key)
+				expectedOwner = owner
+			}
 			var testOwner string
 			require.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[1], &testOwner))
-			assert.Equal(r.t, "test-owner", testOwner)
+			assert.Equal(r.t, expectedOwner, testOwner)
 
 			var labels map[string]string
 			err := attributevalue.Unmarshal(statement.Parameters[2], &labels)
@@ -1321,6 +1449,21 @@ func (r *DynamoDBStub) BatchExecuteStatement(context context.Context, input *dyn
 
 			responses = append(responses, dynamodbtypes.BatchStatementResponse{})
 
+		case "UPDATE \"test-table\" SET \"o\"=? WHERE \"k\"=? AND \"o\"=?":
+			var key string
+			require.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[1], &key))
+			expectedOwner, found := r.stubConfig.ExpectTransfer[key]
+			assert.True(r.t, found, "unexpected transfer for key %q", key)
+			delete(r.stubConfig.ExpectTransfer, key)
+
+			var newOwner, testOwner string
+			require.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[0], &newOwner))
+			assert.Equal(r.t, expectedOwner, newOwner)
+			require.NoError(r.t, attributevalue.Unmarshal(statement.Parameters[2], &testOwner))
+			assert.Equal(r.t, "test-owner", testOwner)
+
+			responses = append(responses, dynamodbtypes.BatchStatementResponse{})
+
 		default:
 			r.t.Errorf("unexpected statement: %s", *statement.Statement)
 		}
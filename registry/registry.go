@@ -34,3 +34,17 @@ type Registry interface {
 	GetDomainFilter() endpoint.DomainFilterInterface
 	OwnerID() string
 }
+
+// OwnershipTransferer is implemented by registries which can transfer the ownership of records to another owner ID.
+type OwnershipTransferer interface {
+	// TransferOwnership changes the owner of the records owned by the registry's owner ID to the owner ID to.
+	// Only the ownership information is modified, never the records themselves.
+	TransferOwnership(ctx context.Context, records []*endpoint.Endpoint, to string) error
+}
+
+// OrphanReader is implemented by registries which can report ownership information whose record no longer exists.
+type OrphanReader interface {
+	// OrphanedOwnership returns the ownership information of all owners whose record no longer exists in the
+	// provider, as endpoints labeled with their owner, e.g. the TXT records of the TXT registry.
+	OrphanedOwnership(ctx context.Context) ([]*endpoint.Endpoint, error)
+}
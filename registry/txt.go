@@ -108,7 +108,28 @@ func NewTXTRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID st
 }
 
 func getSupportedTypes() []string {
-	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeNS}
+	return []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeNS, endpoint.RecordTypePTR, endpoint.RecordTypeCAA, endpoint.RecordTypeHTTPS, endpoint.RecordTypeSVCB}
+}
+
+// hasLegacyFormat returns false for the record types which are not tracked by TXT records in
+// the legacy format. Their names are usually shared with an A record, e.g. a CAA record on the
+// zone apex, so that a TXT record without the record type would be ambiguous.
+func hasLegacyFormat(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeAAAA, endpoint.RecordTypeCAA, endpoint.RecordTypeHTTPS, endpoint.RecordTypeSVCB:
+		return false
+	}
+	return true
+}
+
+// isTypedOnly returns true for the record types which were never tracked by TXT records, before
+// they were tracked by TXT records in the new format only.
+func isTypedOnly(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeCAA, endpoint.RecordTypeHTTPS, endpoint.RecordTypeSVCB:
+		return true
+	}
+	return false
 }
 
 func (im *TXTRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
@@ -170,6 +191,17 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 			SetIdentifier: record.SetIdentifier,
 		}
 		labelMap[key] = labels
+		// The TXT records of the CAA, HTTPS and SVCB records are named like the TXT records in the legacy
+		// format of the hosts named caa-*, https-* or svcb-*, which keep their ownership as well.
+		if isTypedOnly(recordType) && !im.mapper.recordTypeInAffix() {
+			legacyKey := endpoint.EndpointKey{
+				DNSName:       strings.ToLower(recordType) + "-" + endpointName,
+				SetIdentifier: record.SetIdentifier,
+			}
+			if _, exists := labelMap[legacyKey]; !exists {
+				labelMap[legacyKey] = labels
+			}
+		}
 		txtRecordsMap[record.DNSName] = struct{}{}
 	}
 
@@ -196,7 +228,7 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 
 		// Handle both new and old registry format with the preference for the new one
 		labels, labelsExist := labelMap[key]
-		if !labelsExist && ep.RecordType != endpoint.RecordTypeAAAA {
+		if !labelsExist && hasLegacyFormat(ep.RecordType) {
 			key.RecordType = ""
 			labels, labelsExist = labelMap[key]
 		}
@@ -237,7 +269,7 @@ func (im *TXTRegistry) generateTXTRecord(r *endpoint.Endpoint) []*endpoint.Endpo
 	endpoints := make([]*endpoint.Endpoint, 0)
 
 	// Create legacy format record by default unless newFormatOnly is true
-	if !im.newFormatOnly && !im.txtEncryptEnabled && !im.mapper.recordTypeInAffix() && r.RecordType != endpoint.RecordTypeAAAA {
+	if !im.newFormatOnly && !im.txtEncryptEnabled && !im.mapper.recordTypeInAffix() && hasLegacyFormat(r.RecordType) {
 		// old TXT record format
 		txt := endpoint.NewEndpoint(im.mapper.toTXTName(r.DNSName), endpoint.RecordTypeTXT, r.Labels.Serialize(true, im.txtEncryptEnabled, im.txtEncryptAESKey))
 		if txt != nil {
@@ -249,19 +281,30 @@ func (im *TXTRegistry) generateTXTRecord(r *endpoint.Endpoint) []*endpoint.Endpo
 	}
 
 	// Always create new format record
-	recordType := r.RecordType
-	// AWS Alias records are encoded as type "cname"
-	if isAlias, found := r.GetProviderSpecificProperty("alias"); found && isAlias == "true" && recordType == endpoint.RecordTypeA {
-		recordType = endpoint.RecordTypeCNAME
+	if txtNew := im.generateNewFormatTXTRecord(r); txtNew != nil {
+		endpoints = append(endpoints, txtNew)
 	}
-	txtNew := endpoint.NewEndpoint(im.mapper.toNewTXTName(r.DNSName, recordType), endpoint.RecordTypeTXT, r.Labels.Serialize(true, im.txtEncryptEnabled, im.txtEncryptAESKey))
+	return endpoints
+}
+
+// generateNewFormatTXTRecord generates the TXT record in the new format, which includes the record type.
+func (im *TXTRegistry) generateNewFormatTXTRecord(r *endpoint.Endpoint) *endpoint.Endpoint {
+	txtNew := endpoint.NewEndpoint(im.mapper.toNewTXTName(r.DNSName, txtRecordType(r)), endpoint.RecordTypeTXT, r.Labels.Serialize(true, im.txtEncryptEnabled, im.txtEncryptAESKey))
 	if txtNew != nil {
 		txtNew.WithSetIdentifier(r.SetIdentifier)
 		txtNew.Labels[endpoint.OwnedRecordLabelKey] = r.DNSName
 		txtNew.ProviderSpecific = r.ProviderSpecific
-		endpoints = append(endpoints, txtNew)
 	}
-	return endpoints
+	return txtNew
+}
+
+// txtRecordType returns the record type in the name of the new format TXT record of the record.
+func txtRecordType(r *endpoint.Endpoint) string {
+	// AWS Alias records are encoded as type "cname"
+	if isAlias, found := r.GetProviderSpecificProperty("alias"); found && isAlias == "true" && r.RecordType == endpoint.RecordTypeA {
+		return endpoint.RecordTypeCNAME
+	}
+	return r.RecordType
 }
 
 // ApplyChanges updates dns provider with the changes
@@ -298,10 +341,16 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	}
 
 	// make sure TXT records are consistently updated as well
+	adopted := map[endpoint.EndpointKey]bool{}
 	for _, r := range filteredChanges.UpdateOld {
-		// when we updateOld TXT records for which value has changed (due to new label) this would still work because
-		// !!! TXT record value is uniquely generated from the Labels of the endpoint. Hence old TXT record can be uniquely reconstructed
-		filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, im.generateTXTRecord(r)...)
+		if r.Labels[endpoint.AdoptedLabelKey] == "true" {
+			// records adopted by the plan have no TXT records yet
+			adopted[r.Key()] = true
+		} else {
+			// when we updateOld TXT records for which value has changed (due to new label) this would still work because
+			// !!! TXT record value is uniquely generated from the Labels of the endpoint. Hence old TXT record can be uniquely reconstructed
+			filteredChanges.UpdateOld = append(filteredChanges.UpdateOld, im.generateTXTRecord(r)...)
+		}
 		// remove old version of record from cache
 		if im.cacheInterval > 0 {
 			im.removeFromCache(r)
@@ -310,7 +359,11 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 
 	// make sure TXT records are consistently updated as well
 	for _, r := range filteredChanges.UpdateNew {
-		filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, im.generateTXTRecord(r)...)
+		if adopted[r.Key()] {
+			filteredChanges.Create = append(filteredChanges.Create, im.generateTXTRecord(r)...)
+		} else {
+			filteredChanges.UpdateNew = append(filteredChanges.UpdateNew, im.generateTXTRecord(r)...)
+		}
 		// add new version of record to cache
 		if im.cacheInterval > 0 {
 			im.addToCache(r)
@@ -324,6 +377,46 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	return im.provider.ApplyChanges(ctx, filteredChanges)
 }
 
+// TransferOwnership updates the TXT records of the records owned by the registry to the owner ID to.
+// Missing TXT records, e.g. of the new format, are created. Only TXT changes are applied, the records the
+// TXT records belong to are not modified.
+func (im *TXTRegistry) TransferOwnership(ctx context.Context, records []*endpoint.Endpoint, to string) error {
+	current, err := im.provider.Records(ctx)
+	if err != nil {
+		return err
+	}
+	existing := map[endpoint.EndpointKey]struct{}{}
+	for _, r := range current {
+		if r.RecordType == endpoint.RecordTypeTXT {
+			existing[r.Key()] = struct{}{}
+		}
+	}
+
+	changes := &plan.Changes{}
+	for _, r := range endpoint.FilterEndpointsByOwnerID(im.ownerID, records) {
+		transferred := r.DeepCopy()
+		transferred.Labels[endpoint.OwnerLabelKey] = to
+		// both records are generated in the same formats, in the same order
+		newTXTs := im.generateTXTRecord(transferred)
+		for i, txt := range im.generateTXTRecord(r) {
+			if _, ok := existing[txt.Key()]; ok {
+				changes.UpdateOld = append(changes.UpdateOld, txt)
+				changes.UpdateNew = append(changes.UpdateNew, newTXTs[i])
+			} else {
+				changes.Create = append(changes.Create, newTXTs[i])
+			}
+		}
+	}
+	if !changes.HasChanges() {
+		return nil
+	}
+
+	// the cached records still have the previous owner
+	im.recordsCache = nil
+	ctx = context.WithValue(ctx, provider.RecordsContextKey, nil)
+	return im.provider.ApplyChanges(ctx, changes)
+}
+
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (im *TXTRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	return im.provider.AdjustEndpoints(endpoints)
@@ -359,12 +452,24 @@ func extractRecordTypeDefaultPosition(name string) (baseName, recordType string)
 	nameS := strings.Split(name, "-")
 	for _, t := range getSupportedTypes() {
 		if nameS[0] == strings.ToLower(t) {
-			return strings.TrimPrefix(name, nameS[0]+"-"), t
+			baseName = strings.TrimPrefix(name, nameS[0]+"-")
+			// PTR records only exist in the reverse zones, the old format TXT records of other hosts named
+			// ptr-* must not be taken for the ownership records of PTR records
+			if t == endpoint.RecordTypePTR && !isReverseZoneName(baseName) {
+				return name, ""
+			}
+			return baseName, t
 		}
 	}
 	return name, ""
 }
 
+// isReverseZoneName returns true if the name is in an in-addr.arpa or ip6.arpa reverse zone.
+func isReverseZoneName(name string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	return strings.HasSuffix(name, ".in-addr.arpa") || strings.HasSuffix(name, ".ip6.arpa")
+}
+
 // dropAffixExtractType strips TXT record to find an endpoint name it manages
 // it also returns the record type
 func (pr affixNameMapper) dropAffixExtractType(name string) (baseName, recordType string) {
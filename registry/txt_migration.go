@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// TXTMigration holds the changes which migrate the TXT records owned by a TXTRegistry to the new format
+// and delete its orphaned TXT records.
+type TXTMigration struct {
+	// Create are the new format TXT records of records which are only tracked by an old format TXT record
+	Create []*endpoint.Endpoint
+	// Migrated are the old format TXT records which are replaced by new format TXT records
+	Migrated []*endpoint.Endpoint
+	// Orphaned are the TXT records whose record no longer exists
+	Orphaned []*endpoint.Endpoint
+}
+
+// HasChanges returns true if the migration modifies any TXT record.
+func (m *TXTMigration) HasChanges() bool {
+	return len(m.Create) > 0 || len(m.Migrated) > 0 || len(m.Orphaned) > 0
+}
+
+// Changes returns the provider changes of the migration.
+func (m *TXTMigration) Changes() *plan.Changes {
+	return &plan.Changes{
+		Create: m.Create,
+		Delete: append(slices.Clone(m.Migrated), m.Orphaned...),
+	}
+}
+
+// PlanMigration scans the records of the provider for the TXT records owned by the registry. Old format TXT
+// records are replaced by the new format TXT records, which include the record type, of the records they track.
+// An old format TXT record is kept if it still tracks a record type which has no new format, e.g. MX.
+// TXT records which would not be generated for any existing record are orphaned and deleted.
+func (im *TXTRegistry) PlanMigration(ctx context.Context) (*TXTMigration, error) {
+	scan, err := im.scanTXTRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the old format TXT records of the records supported by the new format, and the TXT records which must be
+	// kept since they track a record not supported by the new format, or a record in the new format
+	legacy := map[endpoint.EndpointKey]struct{}{}
+	kept := map[endpoint.EndpointKey]struct{}{}
+	migratable := map[endpoint.EndpointKey][]*endpoint.Endpoint{}
+	for _, ep := range scan.endpoints {
+		newKey := txtKey(im.mapper.toNewTXTName(ep.DNSName, txtRecordType(ep)), ep.SetIdentifier)
+		// the names of both formats may collide, e.g. the new format TXT record of an A record
+		// "app" and the old format TXT record of a record "a-app"
+		kept[newKey] = struct{}{}
+		if !hasLegacyFormat(ep.RecordType) {
+			continue
+		}
+		legacyKey := txtKey(im.mapper.toTXTName(ep.DNSName), ep.SetIdentifier)
+		if !slices.Contains(getSupportedTypes(), ep.RecordType) {
+			// the ownership of the other record types is only read from the old format
+			kept[legacyKey] = struct{}{}
+			continue
+		}
+		legacy[legacyKey] = struct{}{}
+		if _, exists := scan.existing[newKey]; !exists {
+			migratable[legacyKey] = append(migratable[legacyKey], ep)
+		}
+	}
+
+	migration := &TXTMigration{}
+	for _, txt := range scan.txts {
+		if txt.labels[endpoint.OwnerLabelKey] != im.ownerID {
+			continue
+		}
+		key := txtKey(txt.record.DNSName, txt.record.SetIdentifier)
+		if _, ok := scan.expected[key]; !ok {
+			migration.Orphaned = append(migration.Orphaned, txt.record)
+			continue
+		}
+		if _, ok := legacy[key]; !ok {
+			continue
+		}
+		for _, ep := range migratable[key] {
+			r := ep.DeepCopy()
+			r.Labels = txt.labels
+			migration.Create = append(migration.Create, im.generateNewFormatTXTRecord(r))
+		}
+		if _, ok := kept[key]; !ok {
+			migration.Migrated = append(migration.Migrated, txt.record)
+		}
+	}
+	return migration, nil
+}
+
+// OrphanedOwnership returns the TXT records of all owners which would not be generated for any existing record.
+func (im *TXTRegistry) OrphanedOwnership(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	scan, err := im.scanTXTRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var orphaned []*endpoint.Endpoint
+	for _, txt := range scan.txts {
+		if _, ok := scan.expected[txtKey(txt.record.DNSName, txt.record.SetIdentifier)]; !ok {
+			orphan := txt.record.DeepCopy()
+			orphan.Labels = txt.labels
+			orphaned = append(orphaned, orphan)
+		}
+	}
+	return orphaned, nil
+}
+
+// heritageTXT is a TXT record of the registry, with the labels of its value.
+type heritageTXT struct {
+	record *endpoint.Endpoint
+	labels endpoint.Labels
+}
+
+// txtScan are the records of the provider, split into the TXT records of the registry and the other records.
+type txtScan struct {
+	txts      []heritageTXT
+	endpoints []*endpoint.Endpoint
+	// the keys of the TXT records of the registry
+	existing map[endpoint.EndpointKey]struct{}
+	// the keys of the TXT records in both formats which the registry generates for the other records
+	expected map[endpoint.EndpointKey]struct{}
+}
+
+// scanTXTRecords reads the records of the provider and scans them for the TXT records of the registry.
+func (im *TXTRegistry) scanTXTRecords(ctx context.Context) (*txtScan, error) {
+	records, err := im.provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scan := &txtScan{
+		existing: map[endpoint.EndpointKey]struct{}{},
+		expected: map[endpoint.EndpointKey]struct{}{},
+	}
+	for _, record := range records {
+		if record.RecordType != endpoint.RecordTypeTXT || len(record.Targets) == 0 {
+			scan.endpoints = append(scan.endpoints, record)
+			continue
+		}
+		labels, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey)
+		if errors.Is(err, endpoint.ErrInvalidHeritage) {
+			scan.endpoints = append(scan.endpoints, record)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		scan.existing[record.Key()] = struct{}{}
+		scan.txts = append(scan.txts, heritageTXT{record: record, labels: labels})
+	}
+
+	for _, ep := range scan.endpoints {
+		scan.expected[txtKey(im.mapper.toNewTXTName(ep.DNSName, txtRecordType(ep)), ep.SetIdentifier)] = struct{}{}
+		if hasLegacyFormat(ep.RecordType) {
+			scan.expected[txtKey(im.mapper.toTXTName(ep.DNSName), ep.SetIdentifier)] = struct{}{}
+		}
+	}
+	return scan, nil
+}
+
+// Migrate applies the changes of the migration to the provider.
+func (im *TXTRegistry) Migrate(ctx context.Context, migration *TXTMigration) error {
+	if !migration.HasChanges() {
+		return nil
+	}
+	// the cached records may still be labeled by the deleted TXT records
+	im.recordsCache = nil
+	ctx = context.WithValue(ctx, provider.RecordsContextKey, nil)
+	return im.provider.ApplyChanges(ctx, migration.Changes())
+}
+
+// txtKey returns the key of the TXT record with the given name and set identifier.
+func txtKey(dnsName, setIdentifier string) endpoint.EndpointKey {
+	return endpoint.EndpointKey{DNSName: dnsName, RecordType: endpoint.RecordTypeTXT, SetIdentifier: setIdentifier}
+}
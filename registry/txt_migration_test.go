@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+func dnsNames(endpoints []*endpoint.Endpoint) []string {
+	names := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		names = append(names, ep.DNSName)
+	}
+	return names
+}
+
+func TestTXTRegistryMigration(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	owned := "\"heritage=external-dns,external-dns/owner=owner,external-dns/resource=ingress/default/foo\""
+	other := "\"heritage=external-dns,external-dns/owner=other\""
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			// only tracked by the old format
+			newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("foo.test-zone.example.org", owned, endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("web.test-zone.example.org", "foo.test-zone.example.org", endpoint.RecordTypeCNAME, ""),
+			newEndpointWithOwner("web.test-zone.example.org", owned, endpoint.RecordTypeTXT, ""),
+			// tracked by both formats
+			newEndpointWithOwner("bar.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("bar.test-zone.example.org", owned, endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("a-bar.test-zone.example.org", owned, endpoint.RecordTypeTXT, ""),
+			// the ownership of MX records is only read from the old format
+			newEndpointWithOwner("mail.test-zone.example.org", "10 mx.example.org", endpoint.RecordTypeMX, ""),
+			newEndpointWithOwner("mail.test-zone.example.org", owned, endpoint.RecordTypeTXT, ""),
+			// orphaned
+			newEndpointWithOwner("gone.test-zone.example.org", owned, endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("a-gone.test-zone.example.org", owned, endpoint.RecordTypeTXT, ""),
+			// owned by another owner
+			newEndpointWithOwner("baz.test-zone.example.org", "1.1.1.3", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("baz.test-zone.example.org", other, endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("a-old.test-zone.example.org", other, endpoint.RecordTypeTXT, ""),
+			// not a registry record
+			newEndpointWithOwner("txt.test-zone.example.org", "\"v=spf1 -all\"", endpoint.RecordTypeTXT, ""),
+		},
+	}))
+
+	r, err := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, true)
+	require.NoError(t, err)
+	migration, err := r.PlanMigration(ctx)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"a-foo.test-zone.example.org", "cname-web.test-zone.example.org"}, dnsNames(migration.Create))
+	assert.ElementsMatch(t, []string{"foo.test-zone.example.org", "web.test-zone.example.org", "bar.test-zone.example.org"}, dnsNames(migration.Migrated))
+	assert.ElementsMatch(t, []string{"gone.test-zone.example.org", "a-gone.test-zone.example.org"}, dnsNames(migration.Orphaned))
+	for _, txt := range migration.Create {
+		assert.Equal(t, endpoint.RecordTypeTXT, txt.RecordType)
+		assert.Equal(t, endpoint.Targets{owned}, txt.Targets)
+	}
+
+	// planning does not modify any record
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 15)
+
+	require.NoError(t, r.Migrate(ctx, migration))
+	records, err = p.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 12)
+
+	// the ownership is kept after the migration
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	owners := map[string]string{}
+	for _, ep := range records {
+		if ep.RecordType != endpoint.RecordTypeTXT {
+			owners[ep.DNSName] = ep.Labels[endpoint.OwnerLabelKey]
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"foo.test-zone.example.org":  "owner",
+		"web.test-zone.example.org":  "owner",
+		"bar.test-zone.example.org":  "owner",
+		"mail.test-zone.example.org": "owner",
+		"baz.test-zone.example.org":  "other",
+	}, owners)
+
+	// the migration is complete
+	migration, err = r.PlanMigration(ctx)
+	require.NoError(t, err)
+	assert.False(t, migration.HasChanges())
+}
+
+func TestTXTRegistryOrphanedOwnership(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("a-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("a-gone.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("cname-old.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=other\"", endpoint.RecordTypeTXT, ""),
+		},
+	}))
+
+	r, err := NewTXTRegistry(p, "", "", "owner", 0, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, err)
+	orphaned, err := r.OrphanedOwnership(ctx)
+	require.NoError(t, err)
+
+	// the orphaned TXT records of all owners are returned
+	owners := map[string]string{}
+	for _, txt := range orphaned {
+		owners[txt.DNSName] = txt.Labels[endpoint.OwnerLabelKey]
+	}
+	assert.Equal(t, map[string]string{
+		"a-gone.test-zone.example.org":    "owner",
+		"cname-old.test-zone.example.org": "other",
+	}, owners)
+}
+
+func TestTXTRegistryMigrationWithPrefix(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	owned := "\"heritage=external-dns,external-dns/owner=owner\""
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("*.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("txt.wildcard.test-zone.example.org", owned, endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("txt.gone.test-zone.example.org", owned, endpoint.RecordTypeTXT, ""),
+		},
+	}))
+
+	r, err := NewTXTRegistry(p, "txt.", "", "owner", 0, "wildcard", []string{}, []string{}, false, nil, true)
+	require.NoError(t, err)
+	migration, err := r.PlanMigration(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"txt.a-wildcard.test-zone.example.org"}, dnsNames(migration.Create))
+	assert.Equal(t, []string{"txt.wildcard.test-zone.example.org"}, dnsNames(migration.Migrated))
+	assert.Equal(t, []string{"txt.gone.test-zone.example.org"}, dnsNames(migration.Orphaned))
+}
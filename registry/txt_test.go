@@ -1266,11 +1266,31 @@ func TestExtractRecordTypeDefaultPosition(t *testing.T) {
 			expectedName: "zone.example.com",
 			expectedType: "AAAA",
 		},
+		{
+			input:        "caa-zone.example.com",
+			expectedName: "zone.example.com",
+			expectedType: "CAA",
+		},
+		{
+			input:        "ptr-5.2.0.192.in-addr.arpa",
+			expectedName: "5.2.0.192.in-addr.arpa",
+			expectedType: "PTR",
+		},
 		{
 			input:        "ptr-zone.example.com",
 			expectedName: "ptr-zone.example.com",
 			expectedType: "",
 		},
+		{
+			input:        "ptr-8.b.d.0.1.0.0.2.ip6.arpa",
+			expectedName: "8.b.d.0.1.0.0.2.ip6.arpa",
+			expectedType: "PTR",
+		},
+		{
+			input:        "mx-zone.example.com",
+			expectedName: "mx-zone.example.com",
+			expectedType: "",
+		},
 		{
 			input:        "zone.example.com",
 			expectedName: "zone.example.com",
@@ -1535,6 +1555,140 @@ func TestGenerateTXTForAAAA(t *testing.T) {
 	assert.Equal(t, expectedTXT, gotTXT)
 }
 
+func TestGenerateTXTForCAA(t *testing.T) {
+	record := newEndpointWithOwner("foo.test-zone.example.org", `0 issue "letsencrypt.org"`, endpoint.RecordTypeCAA, "owner")
+	expectedTXT := []*endpoint.Endpoint{
+		{
+			DNSName:    "caa-foo.test-zone.example.org",
+			Targets:    endpoint.Targets{"\"heritage=external-dns,external-dns/owner=owner\""},
+			RecordType: endpoint.RecordTypeTXT,
+			Labels: map[string]string{
+				endpoint.OwnedRecordLabelKey: "foo.test-zone.example.org",
+			},
+		},
+	}
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, false)
+	gotTXT := r.generateTXTRecord(record)
+	assert.Equal(t, expectedTXT, gotTXT)
+}
+
+func TestGenerateTXTForServiceBinding(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	r, _ := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, false)
+
+	for _, recordType := range []string{endpoint.RecordTypeHTTPS, endpoint.RecordTypeSVCB} {
+		record := newEndpointWithOwner("foo.test-zone.example.org", "1 . alpn=h2", recordType, "owner")
+		expectedTXT := []*endpoint.Endpoint{
+			{
+				DNSName:    strings.ToLower(recordType) + "-foo.test-zone.example.org",
+				Targets:    endpoint.Targets{"\"heritage=external-dns,external-dns/owner=owner\""},
+				RecordType: endpoint.RecordTypeTXT,
+				Labels: map[string]string{
+					endpoint.OwnedRecordLabelKey: "foo.test-zone.example.org",
+				},
+			},
+		}
+		assert.Equal(t, expectedTXT, r.generateTXTRecord(record), recordType)
+	}
+}
+
+func TestTXTRegistryCAAOwnership(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	r, _ := NewTXTRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA, endpoint.RecordTypeCAA}, []string{}, false, nil, false)
+
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("test-zone.example.org", `0 issue "letsencrypt.org"`, endpoint.RecordTypeCAA, ""),
+		},
+	}))
+	// a CAA record created by someone else on the same name must not be owned through the A record
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("foo.test-zone.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("foo.test-zone.example.org", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\""),
+			endpoint.NewEndpoint("foo.test-zone.example.org", endpoint.RecordTypeCAA, `0 issue "pki.goog"`),
+		},
+	}))
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+
+	owners := map[string]string{}
+	for _, record := range records {
+		if record.RecordType != endpoint.RecordTypeTXT {
+			owners[record.DNSName+"/"+record.RecordType] = record.Labels[endpoint.OwnerLabelKey]
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"test-zone.example.org/A":       "owner",
+		"test-zone.example.org/CAA":     "owner",
+		"foo.test-zone.example.org/A":   "owner",
+		"foo.test-zone.example.org/CAA": "",
+	}, owners)
+}
+
+func TestTXTRegistryLegacyOwnershipOfTypedNames(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	r, _ := NewTXTRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME, endpoint.RecordTypeHTTPS}, []string{}, false, nil, false)
+
+	// the TXT records in the legacy format of hosts named like the TXT records of HTTPS and CAA records
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("https-api.test-zone.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("https-api.test-zone.example.org", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\""),
+			endpoint.NewEndpoint("caa-gw.test-zone.example.org", endpoint.RecordTypeCNAME, "gw.example.com"),
+			endpoint.NewEndpoint("caa-gw.test-zone.example.org", endpoint.RecordTypeTXT, "\"heritage=external-dns,external-dns/owner=owner\""),
+		},
+	}))
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("api.test-zone.example.org", "1.2.3.5", endpoint.RecordTypeA, ""),
+		},
+	}))
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+
+	owners := map[string]string{}
+	for _, record := range records {
+		if record.RecordType != endpoint.RecordTypeTXT {
+			owners[record.DNSName+"/"+record.RecordType] = record.Labels[endpoint.OwnerLabelKey]
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"https-api.test-zone.example.org/A":  "owner",
+		"caa-gw.test-zone.example.org/CNAME": "owner",
+		"api.test-zone.example.org/A":        "owner",
+	}, owners)
+}
+
+func TestTXTRegistryPTROwnership(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone("2.0.192.in-addr.arpa")
+	r, _ := NewTXTRegistry(p, "", "", "owner", 0, "", []string{endpoint.RecordTypePTR}, []string{}, false, nil, true)
+
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("5.2.0.192.in-addr.arpa", "foo.example.org", endpoint.RecordTypePTR, ""),
+		},
+	}))
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "5.2.0.192.in-addr.arpa", records[0].DNSName)
+	assert.Equal(t, "owner", records[0].Labels[endpoint.OwnerLabelKey])
+}
+
 func TestFailGenerateTXT(t *testing.T) {
 
 	cnameRecord := &endpoint.Endpoint{
@@ -1838,3 +1992,66 @@ func TestTXTRegistryRecordsWithEmptyTargets(t *testing.T) {
 
 	testutils.TestHelperLogContains("TXT record has no targets empty-targets.test-zone.example.org", hook, t)
 }
+
+func TestTXTRegistryTransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=old\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("a-foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=old\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("bar.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("a-bar.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=other\"", endpoint.RecordTypeTXT, ""),
+		},
+	})
+
+	r, err := NewTXTRegistry(p, "", "", "old", time.Hour, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, err)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.TransferOwnership(ctx, records, "new"))
+
+	expectedRecords := []*endpoint.Endpoint{
+		newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "new"),
+		newEndpointWithOwner("bar.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, "other"),
+	}
+	r, err = NewTXTRegistry(p, "", "", "new", time.Hour, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, err)
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
+}
+
+func TestTXTRegistryApplyChangesAdopted(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, ""),
+		},
+	})
+
+	r, err := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, err)
+	adopted := newEndpointWithOwnerAndLabels("foo.test-zone.example.org", "1.1.1.1", endpoint.RecordTypeA, "owner", endpoint.Labels{endpoint.AdoptedLabelKey: "true"})
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{adopted},
+		UpdateNew: []*endpoint.Endpoint{newEndpointWithOwnerResource("foo.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, "owner", "ingress/default/foo")},
+	}))
+
+	// the TXT records of the adopted record are created
+	r, err = NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, err)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	expectedRecords := []*endpoint.Endpoint{
+		newEndpointWithOwnerResource("foo.test-zone.example.org", "1.1.1.2", endpoint.RecordTypeA, "owner", "ingress/default/foo"),
+	}
+	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
+	providerRecords, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, providerRecords, 3)
+}
@@ -174,7 +174,9 @@ func (sc *ambassadorHostSource) Endpoints(ctx context.Context) ([]*endpoint.Endp
 			continue
 		}
 
+		hostEndpoints = append(hostEndpoints, recordEndpointsFromAnnotations(host.Annotations, hostEndpoints)...)
 		log.Debugf("Endpoints generated from Host: %s: %v", fullname, hostEndpoints)
+		setResourceMetadata(hostEndpoints, host)
 		endpoints = append(endpoints, hostEndpoints...)
 	}
 
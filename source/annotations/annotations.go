@@ -42,6 +42,22 @@ const (
 	HostnameKey = "external-dns.alpha.kubernetes.io/hostname"
 	// The annotation used for specifying whether the public or private interface address is used
 	AccessKey = "external-dns.alpha.kubernetes.io/access"
+	// The annotation used for routing the endpoints of a resource to the named backends, see --backend
+	ProviderKey = "external-dns.alpha.kubernetes.io/provider"
+	// The annotation used for ranking resources claiming the same DNS name, see --conflict-resolution=priority
+	ConflictPriorityKey = "external-dns.alpha.kubernetes.io/conflict-priority"
+	// The annotation used for merging the targets of the resource with those of other resources claiming the same DNS name
+	MergeTargetsKey = "external-dns.alpha.kubernetes.io/merge-targets"
+	// The annotation used for adopting unowned records of the DNS names of the resource
+	AdoptKey = "external-dns.alpha.kubernetes.io/adopt"
+	// The annotation used for publishing SRV records for the named ports of a service, or the listeners of a route
+	SRVPortsKey = "external-dns.alpha.kubernetes.io/srv-ports"
+	// The annotations used for the priority and the weight of the SRV records
+	SRVPriorityKey = "external-dns.alpha.kubernetes.io/srv-priority"
+	SRVWeightKey   = "external-dns.alpha.kubernetes.io/srv-weight"
+	// The annotations used for declaring the MX and TXT records of the hostnames of a resource
+	MXKey  = "external-dns.alpha.kubernetes.io/mx"
+	TXTKey = "external-dns.alpha.kubernetes.io/txt"
 	// The annotation used for specifying the type of endpoints to use for headless services
 	EndpointsTypeKey = "external-dns.alpha.kubernetes.io/endpoints-type"
 	// The annotation used to determine the source of hostnames for ingresses.  This is an optional field - all
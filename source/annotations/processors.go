@@ -14,6 +14,8 @@ limitations under the License.
 package annotations
 
 import (
+	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -32,14 +34,20 @@ func hasAliasFromAnnotations(annotations map[string]string) bool {
 
 // TTLFromAnnotations extracts the TTL from the annotations of the given resource.
 func TTLFromAnnotations(annotations map[string]string, resource string) endpoint.TTL {
-	ttlNotConfigured := endpoint.TTL(0)
 	ttlAnnotation, ok := annotations[TtlKey]
 	if !ok {
-		return ttlNotConfigured
+		return endpoint.TTL(0)
 	}
-	ttlValue, err := parseTTL(ttlAnnotation)
+	return TTLFromValue(ttlAnnotation, resource)
+}
+
+// TTLFromValue parses a TTL of the given resource, either an integer number of seconds or a duration.
+// It returns the unconfigured TTL if the value is invalid.
+func TTLFromValue(value string, resource string) endpoint.TTL {
+	ttlNotConfigured := endpoint.TTL(0)
+	ttlValue, err := parseTTL(value)
 	if err != nil {
-		log.Warnf("%s: %q is not a valid TTL value: %v", resource, ttlAnnotation, err)
+		log.Warnf("%s: %q is not a valid TTL value: %v", resource, value, err)
 		return ttlNotConfigured
 	}
 	if ttlValue < ttlMinimum || ttlValue > ttlMaximum {
@@ -67,6 +75,87 @@ func parseTTL(s string) (int64, error) {
 	return int64(ttlDuration.Seconds()), nil
 }
 
+// SRVRecords are the SRV records a resource publishes for its named ports, as given by its srv annotations.
+type SRVRecords struct {
+	// Ports are the names of the ports, "*" for all named ports.
+	Ports    []string
+	Priority uint16
+	Weight   uint16
+}
+
+// SRVRecordsFromAnnotations extracts the SRV records from the annotations of the given resource, or returns nil
+// if it doesn't publish SRV records. The priority defaults to 0 and the weight to 50, like for NodePort services.
+func SRVRecordsFromAnnotations(annotations map[string]string, resource string) *SRVRecords {
+	portsAnnotation, ok := annotations[SRVPortsKey]
+	if !ok || strings.TrimSpace(portsAnnotation) == "" {
+		return nil
+	}
+	return &SRVRecords{
+		Ports:    SplitHostnameAnnotation(portsAnnotation),
+		Priority: srvValueFromAnnotations(annotations, SRVPriorityKey, 0, resource),
+		Weight:   srvValueFromAnnotations(annotations, SRVWeightKey, 50, resource),
+	}
+}
+
+func srvValueFromAnnotations(annotations map[string]string, key string, defaultValue uint16, resource string) uint16 {
+	annotation, ok := annotations[key]
+	if !ok {
+		return defaultValue
+	}
+	value, err := strconv.ParseUint(annotation, 10, 16)
+	if err != nil {
+		log.Warnf("%s: %q is not a valid value of %s, it must be between [0, 65535]", resource, annotation, key)
+		return defaultValue
+	}
+	return uint16(value)
+}
+
+// Publishes tells whether the SRV records of the named port are published.
+func (s *SRVRecords) Publishes(port string) bool {
+	return port != "" && (slices.Contains(s.Ports, "*") || slices.Contains(s.Ports, port))
+}
+
+// Target returns the target of an SRV record for the port on the host.
+func (s *SRVRecords) Target(port int32, host string) string {
+	return fmt.Sprintf("%d %d %d %s", s.Priority, s.Weight, port, host)
+}
+
+// MXTargetsFromAnnotations extracts the targets of the MX records from the annotations of the given resource, a
+// comma-separated list of preferences and mail exchanges, e.g. "10 mx1.example.com,20 mx2.example.com".
+// Invalid targets are skipped.
+func MXTargetsFromAnnotations(annotations map[string]string, resource string) endpoint.Targets {
+	mxAnnotation := strings.TrimSpace(annotations[MXKey])
+	if mxAnnotation == "" {
+		return nil
+	}
+	var targets endpoint.Targets
+	for _, value := range strings.Split(mxAnnotation, ",") {
+		fields := strings.Fields(value)
+		if len(fields) != 2 {
+			log.Warnf("%s: %q is not a valid MX target, it must be a preference and a mail exchange, e.g. \"10 mx.example.com\"", resource, value)
+			continue
+		}
+		if _, err := strconv.ParseUint(fields[0], 10, 16); err != nil {
+			log.Warnf("%s: %q is not a valid MX target, the preference must be between [0, 65535]", resource, value)
+			continue
+		}
+		targets = append(targets, fields[0]+" "+strings.TrimSuffix(fields[1], "."))
+	}
+	return targets
+}
+
+// TXTTargetsFromAnnotations extracts the values of the TXT records from the annotations, one per line since
+// the values may contain commas. Blank lines are skipped.
+func TXTTargetsFromAnnotations(annotations map[string]string) endpoint.Targets {
+	var targets endpoint.Targets
+	for _, line := range strings.Split(annotations[TXTKey], "\n") {
+		if value := strings.TrimSpace(line); value != "" {
+			targets = append(targets, value)
+		}
+	}
+	return targets
+}
+
 // ParseFilter parses an annotation filter string into a labels.Selector.
 // Returns nil if the annotation filter is invalid.
 func ParseFilter(annotationFilter string) (labels.Selector, error) {
@@ -198,6 +198,88 @@ func TestTTLFromAnnotations(t *testing.T) {
 	}
 }
 
+func TestSRVRecordsFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *SRVRecords
+	}{
+		{
+			name:        "no srv annotation",
+			annotations: map[string]string{},
+		},
+		{
+			name:        "empty srv annotation",
+			annotations: map[string]string{SRVPortsKey: " "},
+		},
+		{
+			name:        "named ports with the default priority and weight",
+			annotations: map[string]string{SRVPortsKey: "sip, ldap"},
+			expected:    &SRVRecords{Ports: []string{"sip", "ldap"}, Priority: 0, Weight: 50},
+		},
+		{
+			name:        "all named ports with a priority and a weight",
+			annotations: map[string]string{SRVPortsKey: "*", SRVPriorityKey: "10", SRVWeightKey: "5"},
+			expected:    &SRVRecords{Ports: []string{"*"}, Priority: 10, Weight: 5},
+		},
+		{
+			name:        "invalid priority and weight",
+			annotations: map[string]string{SRVPortsKey: "sip", SRVPriorityKey: "-1", SRVWeightKey: "65536"},
+			expected:    &SRVRecords{Ports: []string{"sip"}, Priority: 0, Weight: 50},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SRVRecordsFromAnnotations(tt.annotations, "service/default/sip"))
+		})
+	}
+
+	srv := &SRVRecords{Ports: []string{"sip"}, Priority: 10, Weight: 5}
+	assert.True(t, srv.Publishes("sip"))
+	assert.False(t, srv.Publishes("http"))
+	assert.False(t, (&SRVRecords{Ports: []string{"*"}}).Publishes(""))
+	assert.True(t, (&SRVRecords{Ports: []string{"*"}}).Publishes("http"))
+	assert.Equal(t, "10 5 5060 sip.example.org", srv.Target(5060, "sip.example.org"))
+}
+
+func TestMXTargetsFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    endpoint.Targets
+	}{
+		{
+			name:        "no mx annotation",
+			annotations: map[string]string{},
+		},
+		{
+			name:        "mail exchanges with their preferences",
+			annotations: map[string]string{MXKey: "10 mx1.example.org., 20  mx2.example.org"},
+			expected:    endpoint.Targets{"10 mx1.example.org", "20 mx2.example.org"},
+		},
+		{
+			name:        "invalid targets are skipped",
+			annotations: map[string]string{MXKey: "mx1.example.org,65536 mx2.example.org,10 mx3.example.org"},
+			expected:    endpoint.Targets{"10 mx3.example.org"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MXTargetsFromAnnotations(tt.annotations, "ingress/default/mail"))
+		})
+	}
+}
+
+func TestTXTTargetsFromAnnotations(t *testing.T) {
+	assert.Empty(t, TXTTargetsFromAnnotations(map[string]string{}))
+	assert.Equal(t,
+		endpoint.Targets{"v=spf1 include:_spf.example.org ~all", "verification=abc,def"},
+		TXTTargetsFromAnnotations(map[string]string{TXTKey: "v=spf1 include:_spf.example.org ~all\n\n  verification=abc,def\n"}),
+	)
+}
+
 func TestGetAliasFromAnnotations(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -33,6 +33,12 @@ func ProviderSpecificAnnotations(annotations map[string]string) (endpoint.Provid
 	for k, v := range annotations {
 		if k == SetIdentifierKey {
 			setIdentifier = v
+		} else if k == ProviderKey || k == AccessKey {
+			// used by the controller to route the endpoints to backends
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  k,
+				Value: v,
+			})
 		} else if strings.HasPrefix(k, AWSPrefix) {
 			attr := strings.TrimPrefix(k, AWSPrefix)
 			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
@@ -64,6 +64,26 @@ func TestProviderSpecificAnnotations(t *testing.T) {
 			},
 			setIdentifier: "",
 		},
+		{
+			name: "Provider annotation",
+			annotations: map[string]string{
+				ProviderKey: "private",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: ProviderKey, Value: "private"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "Access annotation",
+			annotations: map[string]string{
+				AccessKey: "private",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: AccessKey, Value: "private"},
+			},
+			setIdentifier: "",
+		},
 		{
 			name: "Set identifier annotation",
 			annotations: map[string]string{
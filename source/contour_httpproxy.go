@@ -168,7 +168,9 @@ func (sc *httpProxySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 			continue
 		}
 
+		hpEndpoints = append(hpEndpoints, recordEndpointsFromAnnotations(hp.Annotations, hpEndpoints)...)
 		log.Debugf("Endpoints generated from HTTPProxy: %s/%s: %v", hp.Namespace, hp.Name, hpEndpoints)
+		setResourceMetadata(hpEndpoints, hp)
 		endpoints = append(endpoints, hpEndpoints...)
 	}
 
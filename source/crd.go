@@ -29,6 +29,9 @@ import (
 	"sigs.k8s.io/external-dns/source/annotations"
 
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -40,6 +43,8 @@ import (
 
 	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
+	"sigs.k8s.io/external-dns/plan"
 )
 
 // crdSource is an implementation of Source that provides endpoints by listing
@@ -52,6 +57,19 @@ type crdSource struct {
 	annotationFilter string
 	labelSelector    labels.Selector
 	informer         *cache.SharedInformer
+	eventRecorder    events.Recorder
+
+	// observed holds the DNSEndpoints seen by the last call to Endpoints, their status
+	// is written by ReportStatus once the changes were submitted to the provider.
+	// Both are called sequentially by the controller.
+	observed map[string]*observedDNSEndpoint
+}
+
+// observedDNSEndpoint is a DNSEndpoint seen by Endpoints, along with the reasons why some
+// of its endpoints were rejected.
+type observedDNSEndpoint struct {
+	dnsEndpoint *apiv1alpha1.DNSEndpoint
+	rejected    []string
 }
 
 func addKnownTypes(scheme *runtime.Scheme, groupVersion schema.GroupVersion) error {
@@ -111,8 +129,10 @@ func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiS
 }
 
 // NewCRDSource creates a new crdSource with the given config.
-func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, scheme *runtime.Scheme, startInformer bool) (Source, error) {
+// Rejected endpoints are reported on the DNSEndpoint through the recorder, which may be nil.
+func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, scheme *runtime.Scheme, startInformer bool, recorder events.Recorder) (Source, error) {
 	sourceCrd := crdSource{
+		eventRecorder:    recorder,
 		crdResource:      strings.ToLower(kind) + "s",
 		namespace:        namespace,
 		annotationFilter: annotationFilter,
@@ -181,12 +201,19 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 		return nil, err
 	}
 
-	for _, dnsEndpoint := range result.Items {
+	observed := make(map[string]*observedDNSEndpoint, len(result.Items))
+	for i := range result.Items {
+		dnsEndpoint := &result.Items[i]
+		resource := fmt.Sprintf("crd/%s/%s", dnsEndpoint.Namespace, dnsEndpoint.Name)
+		obs := &observedDNSEndpoint{dnsEndpoint: dnsEndpoint}
+		observed[resource] = obs
+
 		// Make sure that all endpoints have targets for A or CNAME type
 		var crdEndpoints []*endpoint.Endpoint
 		for _, ep := range dnsEndpoint.Spec.Endpoints {
 			if (ep.RecordType == endpoint.RecordTypeCNAME || ep.RecordType == endpoint.RecordTypeA || ep.RecordType == endpoint.RecordTypeAAAA) && len(ep.Targets) < 1 {
 				log.Warnf("Endpoint %s with DNSName %s has an empty list of targets", dnsEndpoint.Name, ep.DNSName)
+				cs.reject(obs, resource, fmt.Sprintf("%s record %s has an empty list of targets", ep.RecordType, ep.DNSName))
 				continue
 			}
 
@@ -203,29 +230,153 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 			}
 			if illegalTarget {
 				log.Warnf("Endpoint %s with DNSName %s has an illegal target. The subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com')", dnsEndpoint.Name, ep.DNSName)
+				cs.reject(obs, resource, fmt.Sprintf("%s record %s has an illegal target", ep.RecordType, ep.DNSName))
 				continue
 			}
 
-			ep.WithLabel(endpoint.ResourceLabelKey, fmt.Sprintf("crd/%s/%s", dnsEndpoint.Namespace, dnsEndpoint.Name))
+			if !ep.CheckEndpoint() {
+				log.Warnf("Endpoint %s with DNSName %s has a malformed %s target", dnsEndpoint.Name, ep.DNSName, ep.RecordType)
+				cs.reject(obs, resource, fmt.Sprintf("%s record %s has a malformed target", ep.RecordType, ep.DNSName))
+				continue
+			}
+
+			ep.WithLabel(endpoint.ResourceLabelKey, resource)
 
 			crdEndpoints = append(crdEndpoints, ep)
 		}
 
+		setResourceMetadata(crdEndpoints, dnsEndpoint)
 		endpoints = append(endpoints, crdEndpoints...)
+	}
+
+	cs.observed = observed
+
+	return endpoints, nil
+}
+
+// reject records why an endpoint of a DNSEndpoint was not accepted.
+func (cs *crdSource) reject(obs *observedDNSEndpoint, resource, reason string) {
+	obs.rejected = append(obs.rejected, reason)
+	if cs.eventRecorder != nil && obs.dnsEndpoint.Status.ObservedGeneration != obs.dnsEndpoint.Generation {
+		cs.eventRecorder.Eventf(resource, corev1.EventTypeWarning, events.ReasonRecordRejected, "Rejected: %s", reason)
+	}
+}
+
+// ReportStatus writes the conditions and the live records of every DNSEndpoint seen by the
+// last call to Endpoints. Objects whose status did not change are not updated.
+func (cs *crdSource) ReportStatus(ctx context.Context, results []*plan.Result) {
+	byResource := map[string][]*plan.Result{}
+	for _, r := range results {
+		resource := r.Endpoint.Labels[endpoint.ResourceLabelKey]
+		if _, ok := cs.observed[resource]; ok {
+			byResource[resource] = append(byResource[resource], r)
+		}
+	}
 
-		if dnsEndpoint.Status.ObservedGeneration == dnsEndpoint.Generation {
+	for resource, obs := range cs.observed {
+		status := dnsEndpointStatus(obs, byResource[resource])
+		if equality.Semantic.DeepEqual(status, obs.dnsEndpoint.Status) {
 			continue
 		}
 
-		dnsEndpoint.Status.ObservedGeneration = dnsEndpoint.Generation
-		// Update the ObservedGeneration
-		_, err = cs.UpdateStatus(ctx, &dnsEndpoint)
-		if err != nil {
-			log.Warnf("Could not update ObservedGeneration of the CRD: %v", err)
+		dnsEndpoint := obs.dnsEndpoint.DeepCopy()
+		dnsEndpoint.Status = status
+		if _, err := cs.UpdateStatus(ctx, dnsEndpoint); err != nil {
+			log.Warnf("Could not update status of DNSEndpoint %s/%s: %v", dnsEndpoint.Namespace, dnsEndpoint.Name, err)
+			continue
 		}
+		obs.dnsEndpoint.Status = status
 	}
+}
 
-	return endpoints, nil
+// dnsEndpointStatus computes the status of a DNSEndpoint from the results of its endpoints.
+func dnsEndpointStatus(obs *observedDNSEndpoint, results []*plan.Result) apiv1alpha1.DNSEndpointStatus {
+	status := *obs.dnsEndpoint.Status.DeepCopy()
+	generation := obs.dnsEndpoint.Generation
+	status.ObservedGeneration = generation
+
+	var failed, pending, conflicted []string
+	status.Endpoints = make([]apiv1alpha1.EndpointStatus, 0, len(results))
+	for _, r := range results {
+		// deleted records are reported through the remaining endpoints of the object
+		if r.Outcome == plan.OutcomeDeleted {
+			continue
+		}
+		epStatus := apiv1alpha1.EndpointStatus{
+			DNSName:       r.Endpoint.DNSName,
+			RecordType:    r.Endpoint.RecordType,
+			SetIdentifier: r.Endpoint.SetIdentifier,
+			State:         string(r.Outcome),
+			Message:       r.Message,
+		}
+		if r.Live != nil {
+			epStatus.Targets = r.Live.Targets
+			epStatus.RecordTTL = r.Live.RecordTTL
+		}
+		status.Endpoints = append(status.Endpoints, epStatus)
+
+		msg := fmt.Sprintf("%s record %s: %s", r.Endpoint.RecordType, r.Endpoint.DNSName, r.Message)
+		switch r.Outcome {
+		case plan.OutcomeFailed:
+			failed = append(failed, msg)
+		case plan.OutcomeConflicted:
+			conflicted = append(conflicted, msg)
+		case plan.OutcomeSkipped, plan.OutcomeIgnored:
+			pending = append(pending, msg)
+		}
+	}
+	if len(status.Endpoints) == 0 {
+		status.Endpoints = nil
+	}
+
+	accepted := metav1.Condition{
+		Type:               apiv1alpha1.DNSEndpointAccepted,
+		Status:             metav1.ConditionTrue,
+		Reason:             apiv1alpha1.DNSEndpointReasonAccepted,
+		Message:            "All endpoints are valid",
+		ObservedGeneration: generation,
+	}
+	if len(obs.rejected) > 0 {
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = apiv1alpha1.DNSEndpointReasonInvalidEndpoints
+		accepted.Message = strings.Join(obs.rejected, "; ")
+	}
+	meta.SetStatusCondition(&status.Conditions, accepted)
+
+	programmed := metav1.Condition{
+		Type:               apiv1alpha1.DNSEndpointProgrammed,
+		Status:             metav1.ConditionTrue,
+		Reason:             apiv1alpha1.DNSEndpointReasonProgrammed,
+		Message:            "All accepted endpoints are live in the DNS provider",
+		ObservedGeneration: generation,
+	}
+	switch {
+	case len(failed) > 0:
+		programmed.Status = metav1.ConditionFalse
+		programmed.Reason = apiv1alpha1.DNSEndpointReasonProviderError
+		programmed.Message = strings.Join(failed, "; ")
+	case len(conflicted) > 0 || len(pending) > 0:
+		programmed.Status = metav1.ConditionFalse
+		programmed.Reason = apiv1alpha1.DNSEndpointReasonPending
+		programmed.Message = strings.Join(append(conflicted, pending...), "; ")
+	}
+	meta.SetStatusCondition(&status.Conditions, programmed)
+
+	conflict := metav1.Condition{
+		Type:               apiv1alpha1.DNSEndpointConflicted,
+		Status:             metav1.ConditionFalse,
+		Reason:             apiv1alpha1.DNSEndpointReasonNoConflicts,
+		Message:            "No endpoint is in conflict",
+		ObservedGeneration: generation,
+	}
+	if len(conflicted) > 0 {
+		conflict.Status = metav1.ConditionTrue
+		conflict.Reason = apiv1alpha1.DNSEndpointReasonConflicted
+		conflict.Message = strings.Join(conflicted, "; ")
+	}
+	meta.SetStatusCondition(&status.Conditions, conflict)
+
+	return status
 }
 
 func (cs *crdSource) watch(ctx context.Context, opts *metav1.ListOptions) (watch.Interface, error) {
@@ -31,6 +31,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -43,6 +45,7 @@ import (
 	cachetesting "k8s.io/client-go/tools/cache/testing"
 	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
 )
 
 type CRDSuite struct {
@@ -111,7 +114,7 @@ func fakeRESTClient(endpoints []*endpoint.Endpoint, apiVersion, kind, namespace,
 				if err != nil {
 					return nil, err
 				}
-				dnsEndpoint.Status.ObservedGeneration = body.Status.ObservedGeneration
+				dnsEndpoint.Status = body.Status
 				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, dnsEndpoint)}, nil
 			default:
 				return nil, fmt.Errorf("unexpected request: %#v\n%#v", req.URL, req)
@@ -433,6 +436,48 @@ func testCRDSourceEndpoints(t *testing.T) {
 			expectEndpoints: true,
 			expectError:     false,
 		},
+		{
+			title:                "Create CAA record",
+			registeredAPIVersion: "test.k8s.io/v1alpha1",
+			apiVersion:           "test.k8s.io/v1alpha1",
+			registeredKind:       "DNSEndpoint",
+			kind:                 "DNSEndpoint",
+			namespace:            "foo",
+			registeredNamespace:  "foo",
+			labels:               map[string]string{"test": "that"},
+			labelFilter:          "test=that",
+			endpoints: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					Targets:    endpoint.Targets{`0 issue "letsencrypt.org"`, `0 issuewild ";"`, `0 iodef "mailto:security@example.org"`},
+					RecordType: endpoint.RecordTypeCAA,
+					RecordTTL:  180,
+				},
+			},
+			expectEndpoints: true,
+			expectError:     false,
+		},
+		{
+			title:                "malformed target CAA",
+			registeredAPIVersion: "test.k8s.io/v1alpha1",
+			apiVersion:           "test.k8s.io/v1alpha1",
+			registeredKind:       "DNSEndpoint",
+			kind:                 "DNSEndpoint",
+			namespace:            "foo",
+			registeredNamespace:  "foo",
+			labels:               map[string]string{"test": "that"},
+			labelFilter:          "test=that",
+			endpoints: []*endpoint.Endpoint{
+				{
+					DNSName:    "example.org",
+					Targets:    endpoint.Targets{"0 issue letsencrypt.org"},
+					RecordType: endpoint.RecordTypeCAA,
+					RecordTTL:  180,
+				},
+			},
+			expectEndpoints: false,
+			expectError:     false,
+		},
 		{
 			title:                "illegal target CNAME",
 			registeredAPIVersion: "test.k8s.io/v1alpha1",
@@ -494,7 +539,7 @@ func testCRDSourceEndpoints(t *testing.T) {
 			// At present, client-go's fake.RESTClient (used by crd_test.go) is known to cause race conditions when used
 			// with informers: https://github.com/kubernetes/kubernetes/issues/95372
 			// So don't start the informer during testing.
-			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, scheme, false)
+			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, scheme, false, nil)
 			require.NoError(t, err)
 
 			receivedEndpoints, err := cs.Endpoints(t.Context())
@@ -509,6 +554,7 @@ func testCRDSourceEndpoints(t *testing.T) {
 			}
 
 			if err == nil {
+				cs.(StatusReporter).ReportStatus(t.Context(), nil)
 				validateCRDResource(t, cs, ti.expectError)
 			}
 
@@ -721,6 +767,81 @@ func TestDNSEndpointsWithSetResourceLabels(t *testing.T) {
 	}
 }
 
+func TestCRDSource_ReportStatus(t *testing.T) {
+	apiVersion := apiv1alpha1.GroupVersion.String()
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("live.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("taken.example.org", endpoint.RecordTypeA, "1.2.3.5"),
+		endpoint.NewEndpoint("empty.example.org", endpoint.RecordTypeA),
+	}
+	restClient := fakeRESTClient(endpoints, apiVersion, "DNSEndpoint", "default", "test", nil, nil, t)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, addKnownTypes(scheme, apiv1alpha1.GroupVersion))
+	cs, err := NewCRDSource(restClient, "default", "DNSEndpoint", "", labels.Everything(), scheme, false, nil)
+	require.NoError(t, err)
+
+	received, err := cs.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, received, 2)
+
+	cs.(StatusReporter).ReportStatus(t.Context(), []*plan.Result{
+		{Endpoint: received[0], Live: received[0], Outcome: plan.OutcomeCreated},
+		{Endpoint: received[1], Outcome: plan.OutcomeConflicted, Message: `owned by another owner "other"`},
+		{Endpoint: endpoint.NewEndpoint("unrelated.example.org", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "ingress/default/other"), Outcome: plan.OutcomeCreated},
+	})
+
+	result, err := cs.(*crdSource).List(t.Context(), &metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	status := result.Items[0].Status
+
+	require.Equal(t, result.Items[0].Generation, status.ObservedGeneration)
+	require.Len(t, status.Endpoints, 2)
+	require.Equal(t, "live.example.org", status.Endpoints[0].DNSName)
+	require.Equal(t, string(plan.OutcomeCreated), status.Endpoints[0].State)
+	require.Equal(t, endpoint.Targets{"1.2.3.4"}, status.Endpoints[0].Targets)
+	require.Equal(t, string(plan.OutcomeConflicted), status.Endpoints[1].State)
+	require.Empty(t, status.Endpoints[1].Targets)
+
+	accepted := meta.FindStatusCondition(status.Conditions, apiv1alpha1.DNSEndpointAccepted)
+	require.NotNil(t, accepted)
+	require.Equal(t, metav1.ConditionFalse, accepted.Status)
+	require.Contains(t, accepted.Message, "empty.example.org")
+
+	programmed := meta.FindStatusCondition(status.Conditions, apiv1alpha1.DNSEndpointProgrammed)
+	require.NotNil(t, programmed)
+	require.Equal(t, metav1.ConditionFalse, programmed.Status)
+	require.Equal(t, apiv1alpha1.DNSEndpointReasonPending, programmed.Reason)
+
+	conflicted := meta.FindStatusCondition(status.Conditions, apiv1alpha1.DNSEndpointConflicted)
+	require.NotNil(t, conflicted)
+	require.Equal(t, metav1.ConditionTrue, conflicted.Status)
+	require.Contains(t, conflicted.Message, "taken.example.org")
+}
+
+func TestDNSEndpointStatus(t *testing.T) {
+	ep := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	obs := &observedDNSEndpoint{dnsEndpoint: &apiv1alpha1.DNSEndpoint{ObjectMeta: metav1.ObjectMeta{Generation: 2}}}
+
+	status := dnsEndpointStatus(obs, []*plan.Result{{Endpoint: ep, Live: ep, Outcome: plan.OutcomeUnchanged}})
+	require.Equal(t, int64(2), status.ObservedGeneration)
+	require.True(t, meta.IsStatusConditionTrue(status.Conditions, apiv1alpha1.DNSEndpointAccepted))
+	require.True(t, meta.IsStatusConditionTrue(status.Conditions, apiv1alpha1.DNSEndpointProgrammed))
+	require.True(t, meta.IsStatusConditionFalse(status.Conditions, apiv1alpha1.DNSEndpointConflicted))
+
+	obs.dnsEndpoint.Status = status
+	failed := dnsEndpointStatus(obs, []*plan.Result{{Endpoint: ep, Outcome: plan.OutcomeFailed, Message: "provider rejected change: throttled"}})
+	programmed := meta.FindStatusCondition(failed.Conditions, apiv1alpha1.DNSEndpointProgrammed)
+	require.Equal(t, metav1.ConditionFalse, programmed.Status)
+	require.Equal(t, apiv1alpha1.DNSEndpointReasonProviderError, programmed.Reason)
+	require.Contains(t, programmed.Message, "throttled")
+
+	// unchanged conditions keep their transition time so the status is not rewritten every loop
+	again := dnsEndpointStatus(obs, []*plan.Result{{Endpoint: ep, Live: ep, Outcome: plan.OutcomeUnchanged}})
+	require.True(t, equality.Semantic.DeepEqual(status, again))
+}
+
 func helperCreateWatcherWithInformer(t *testing.T) (*cachetesting.FakeControllerSource, crdSource) {
 	t.Helper()
 	ctx := t.Context()
@@ -23,6 +23,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
 )
 
 // dedupSource is a Source that removes duplicate endpoints from its wrapped source.
@@ -67,3 +68,7 @@ func (ms *dedupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, err
 func (ms *dedupSource) AddEventHandler(ctx context.Context, handler func()) {
 	ms.source.AddEventHandler(ctx, handler)
 }
+
+func (ms *dedupSource) ReportStatus(ctx context.Context, results []*plan.Result) {
+	reportStatus(ctx, ms.source, results)
+}
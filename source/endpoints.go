@@ -15,11 +15,15 @@ package source
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
+	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/labels"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 // endpointsForHostname returns the endpoint objects for each host-target combination.
@@ -81,6 +85,118 @@ func endpointsForHostname(hostname string, targets endpoint.Targets, ttl endpoin
 	return endpoints
 }
 
+// recordEndpointsFromAnnotations returns the MX and TXT endpoints declared by the annotations of a resource for
+// the hostnames of its A and AAAA endpoints, with the TTL, provider specific properties and set identifier
+// of these endpoints. The hostnames of CNAME endpoints are skipped, a CNAME record cannot coexist with other
+// records (RFC 1034 3.6.2).
+func recordEndpointsFromAnnotations(annots map[string]string, endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if annots[annotations.MXKey] == "" && annots[annotations.TXTKey] == "" {
+		return nil
+	}
+
+	var hostnameEndpoints, cnameEndpoints []*endpoint.Endpoint
+	visited := make(map[endpoint.EndpointKey]bool)
+	for _, ep := range endpoints {
+		switch ep.RecordType {
+		case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+			key := endpoint.EndpointKey{DNSName: ep.DNSName, SetIdentifier: ep.SetIdentifier}
+			if !visited[key] {
+				visited[key] = true
+				hostnameEndpoints = append(hostnameEndpoints, ep)
+			}
+		case endpoint.RecordTypeCNAME:
+			cnameEndpoints = append(cnameEndpoints, ep)
+		}
+	}
+	for _, ep := range cnameEndpoints {
+		key := endpoint.EndpointKey{DNSName: ep.DNSName, SetIdentifier: ep.SetIdentifier}
+		if !visited[key] {
+			visited[key] = true
+			log.Warnf("Skipping the MX and TXT records of %s declared by the annotations of %s, a CNAME record cannot coexist with other records",
+				ep.DNSName, ep.Labels[endpoint.ResourceLabelKey])
+		}
+	}
+	if len(hostnameEndpoints) == 0 {
+		return nil
+	}
+
+	resource := hostnameEndpoints[0].Labels[endpoint.ResourceLabelKey]
+	records := []struct {
+		recordType string
+		targets    endpoint.Targets
+	}{
+		{endpoint.RecordTypeMX, annotations.MXTargetsFromAnnotations(annots, resource)},
+		{endpoint.RecordTypeTXT, annotations.TXTTargetsFromAnnotations(annots)},
+	}
+
+	var recordEndpoints []*endpoint.Endpoint
+	for _, hostnameEndpoint := range hostnameEndpoints {
+		for _, record := range records {
+			if len(record.targets) == 0 {
+				continue
+			}
+			ep := endpoint.NewEndpointWithTTL(hostnameEndpoint.DNSName, record.recordType, hostnameEndpoint.RecordTTL, slices.Clone(record.targets)...)
+			if ep == nil {
+				continue
+			}
+			ep.ProviderSpecific = hostnameEndpoint.ProviderSpecific
+			ep.SetIdentifier = hostnameEndpoint.SetIdentifier
+			if resource, ok := hostnameEndpoint.Labels[endpoint.ResourceLabelKey]; ok {
+				ep.Labels[endpoint.ResourceLabelKey] = resource
+			}
+			recordEndpoints = append(recordEndpoints, ep)
+		}
+	}
+	return recordEndpoints
+}
+
+// srvEndpoint returns the SRV endpoint of a named port of a hostname, named following RFC 2782
+// "_port._protocol.hostname", whose targets point at the port on each of the hosts.
+func srvEndpoint(srv *annotations.SRVRecords, portName string, protocol string, port int32, hostname string, hosts []string, ttl endpoint.TTL, providerSpecific endpoint.ProviderSpecific, setIdentifier string, resource string) *endpoint.Endpoint {
+	if len(hosts) == 0 || port <= 0 {
+		return nil
+	}
+	targets := make(endpoint.Targets, 0, len(hosts))
+	for _, host := range hosts {
+		targets = append(targets, srv.Target(port, host))
+	}
+	recordName := fmt.Sprintf("_%s._%s.%s", portName, strings.ToLower(protocol), hostname)
+	ep := endpoint.NewEndpointWithTTL(recordName, endpoint.RecordTypeSRV, ttl, targets...)
+	if ep == nil {
+		return nil
+	}
+	ep.ProviderSpecific = providerSpecific
+	ep.SetIdentifier = setIdentifier
+	if resource != "" {
+		ep.Labels[endpoint.ResourceLabelKey] = resource
+	}
+	return ep
+}
+
+// srvHosts returns the hosts the SRV records of a hostname point at, given the endpoints generated for it: the
+// pods of a headless service with a hostname, else the hostname itself, or the targets of its CNAME record since
+// the target of an SRV record must not be an alias.
+func srvHosts(hostname string, endpoints []*endpoint.Endpoint) []string {
+	var hosts, podHosts []string
+	for _, ep := range endpoints {
+		switch {
+		case ep.DNSName == hostname && ep.RecordType == endpoint.RecordTypeCNAME:
+			hosts = append(hosts, ep.Targets...)
+		case ep.RecordType != endpoint.RecordTypeA && ep.RecordType != endpoint.RecordTypeAAAA:
+			continue
+		case ep.DNSName == hostname:
+			hosts = append(hosts, hostname)
+		case strings.HasSuffix(ep.DNSName, "."+hostname):
+			podHosts = append(podHosts, ep.DNSName)
+		}
+	}
+	if len(podHosts) > 0 {
+		hosts = podHosts
+	}
+	slices.Sort(hosts)
+	return slices.Compact(hosts)
+}
+
 func EndpointTargetsFromServices(svcInformer coreinformers.ServiceInformer, namespace string, selector map[string]string) (endpoint.Targets, error) {
 	targets := endpoint.Targets{}
 
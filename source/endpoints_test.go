@@ -17,12 +17,15 @@ import (
 	"context"
 	"testing"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 func TestEndpointsForHostname(t *testing.T) {
@@ -122,6 +125,49 @@ func TestEndpointsForHostname(t *testing.T) {
 	}
 }
 
+func TestRecordEndpointsFromAnnotations(t *testing.T) {
+	providerSpecific := endpoint.ProviderSpecific{{Name: "provider", Value: "value"}}
+	endpoints := append(
+		endpointsForHostname("example.com", endpoint.Targets{"192.0.2.1"}, endpoint.TTL(300), providerSpecific, "identifier", "ingress/default/mail"),
+		endpointsForHostname("example.com", endpoint.Targets{"2001:db8::1"}, endpoint.TTL(300), providerSpecific, "identifier", "ingress/default/mail")...,
+	)
+	endpoints = append(endpoints, endpoint.NewEndpoint("_sip._udp.example.com", endpoint.RecordTypeSRV, "0 50 5060 example.com"))
+
+	assert.Empty(t, recordEndpointsFromAnnotations(map[string]string{}, endpoints))
+	assert.Empty(t, recordEndpointsFromAnnotations(map[string]string{annotations.MXKey: "10 mx.example.com"}, nil))
+
+	expected := []*endpoint.Endpoint{
+		{
+			DNSName:          "example.com",
+			Targets:          endpoint.Targets{"10 mx1.example.com", "20 mx2.example.com"},
+			RecordType:       endpoint.RecordTypeMX,
+			RecordTTL:        endpoint.TTL(300),
+			ProviderSpecific: providerSpecific,
+			SetIdentifier:    "identifier",
+			Labels:           map[string]string{endpoint.ResourceLabelKey: "ingress/default/mail"},
+		},
+		{
+			DNSName:          "example.com",
+			Targets:          endpoint.Targets{"v=spf1 mx -all", "verification=abc"},
+			RecordType:       endpoint.RecordTypeTXT,
+			RecordTTL:        endpoint.TTL(300),
+			ProviderSpecific: providerSpecific,
+			SetIdentifier:    "identifier",
+			Labels:           map[string]string{endpoint.ResourceLabelKey: "ingress/default/mail"},
+		},
+	}
+	assert.Equal(t, expected, recordEndpointsFromAnnotations(map[string]string{
+		annotations.MXKey:  "10 mx1.example.com,20 mx2.example.com",
+		annotations.TXTKey: "v=spf1 mx -all\nverification=abc",
+	}, endpoints))
+
+	// a CNAME record cannot coexist with the MX and TXT records
+	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+	cnameEndpoints := endpointsForHostname("www.example.com", endpoint.Targets{"lb.example.net"}, endpoint.TTL(300), nil, "", "ingress/default/mail")
+	assert.Empty(t, recordEndpointsFromAnnotations(map[string]string{annotations.MXKey: "10 mx1.example.com"}, cnameEndpoints))
+	testutils.TestHelperLogContains("Skipping the MX and TXT records of www.example.com declared by the annotations of ingress/default/mail", hook, t)
+}
+
 func TestEndpointTargetsFromServices(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -163,7 +163,10 @@ func (ts *f5TransportServerSource) endpointsFromTransportServers(transportServer
 			targets = append(targets, transportServer.Status.VSAddress)
 		}
 
-		endpoints = append(endpoints, endpointsForHostname(transportServer.Spec.Host, targets, ttl, nil, "", resource)...)
+		tsEndpoints := endpointsForHostname(transportServer.Spec.Host, targets, ttl, nil, "", resource)
+		tsEndpoints = append(tsEndpoints, recordEndpointsFromAnnotations(transportServer.Annotations, tsEndpoints)...)
+		setResourceMetadata(tsEndpoints, transportServer)
+		endpoints = append(endpoints, tsEndpoints...)
 	}
 
 	return endpoints, nil
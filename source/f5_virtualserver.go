@@ -169,7 +169,10 @@ func (vs *f5VirtualServerSource) endpointsFromVirtualServers(virtualServers []*f
 			targets = append(targets, virtualServer.Status.VSAddress)
 		}
 
-		endpoints = append(endpoints, endpointsForHostname(virtualServer.Spec.Host, targets, ttl, nil, "", resource)...)
+		vsEndpoints := endpointsForHostname(virtualServer.Spec.Host, targets, ttl, nil, "", resource)
+		vsEndpoints = append(vsEndpoints, recordEndpointsFromAnnotations(virtualServer.Annotations, vsEndpoints)...)
+		setResourceMetadata(vsEndpoints, virtualServer)
+		endpoints = append(endpoints, vsEndpoints...)
 	}
 
 	return endpoints, nil
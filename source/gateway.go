@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net/netip"
+	"slices"
 	"sort"
 	"strings"
 	"text/template"
@@ -227,7 +228,7 @@ func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpo
 		}
 
 		// Get Route hostnames and their targets.
-		hostTargets, err := resolver.resolve(rt)
+		hostTargets, hostListeners, err := resolver.resolve(rt)
 		if err != nil {
 			return nil, err
 		}
@@ -241,11 +242,26 @@ func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpo
 		resource := fmt.Sprintf("%s/%s/%s", kind, meta.Namespace, meta.Name)
 		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(annots)
 		ttl := annotations.TTLFromAnnotations(annots, resource)
+		srv := annotations.SRVRecordsFromAnnotations(annots, resource)
 		for host, targets := range hostTargets {
-			routeEndpoints = append(routeEndpoints, endpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
+			hostEndpoints := endpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)
+			routeEndpoints = append(routeEndpoints, hostEndpoints...)
+			if srv != nil && (rt.Protocol() == v1.TCPProtocolType || rt.Protocol() == v1.UDPProtocolType) {
+				// the listeners of {TCP,UDP}Routes are the named ports of the SRV records
+				for _, lis := range hostListeners[host] {
+					if srv.Publishes(string(lis.Name)) {
+						ep := srvEndpoint(srv, string(lis.Name), string(rt.Protocol()), int32(lis.Port), host, srvHosts(host, hostEndpoints), ttl, providerSpecific, setIdentifier, resource)
+						if ep != nil {
+							routeEndpoints = append(routeEndpoints, ep)
+						}
+					}
+				}
+			}
 		}
+		routeEndpoints = append(routeEndpoints, recordEndpointsFromAnnotations(annots, routeEndpoints)...)
 		log.Debugf("Endpoints generated from %s %s/%s: %v", src.rtKind, meta.Namespace, meta.Name, routeEndpoints)
 
+		setResourceMetadata(routeEndpoints, meta)
 		endpoints = append(endpoints, routeEndpoints...)
 	}
 	return endpoints, nil
@@ -292,18 +308,20 @@ func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gatewa
 	}
 }
 
-func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Targets, error) {
+// resolve returns the targets of the hostnames of the route, and the listeners of the gateways they match.
+func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Targets, map[string][]*v1.Listener, error) {
 	rtHosts, err := c.hosts(rt)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	hostTargets := make(map[string]endpoint.Targets)
+	hostListeners := make(map[string][]*v1.Listener)
 
 	routeParentRefs := rt.ParentRefs()
 
 	if len(routeParentRefs) == 0 {
 		log.Debugf("No parent references found for %s %s/%s", c.src.rtKind, rt.Metadata().Namespace, rt.Metadata().Name)
-		return hostTargets, nil
+		return hostTargets, hostListeners, nil
 	}
 
 	meta := rt.Metadata()
@@ -377,6 +395,9 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 				if !ok {
 					continue
 				}
+				if !slices.ContainsFunc(hostListeners[host], func(l *v1.Listener) bool { return l.Name == lis.Name && l.Port == lis.Port }) {
+					hostListeners[host] = append(hostListeners[host], lis)
+				}
 				override := annotations.TargetsFromTargetAnnotation(gw.gateway.Annotations)
 				hostTargets[host] = append(hostTargets[host], override...)
 				if len(override) == 0 {
@@ -396,7 +417,7 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 	for host, targets := range hostTargets {
 		hostTargets[host] = uniqueTargets(targets)
 	}
-	return hostTargets, nil
+	return hostTargets, hostListeners, nil
 }
 
 func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, error) {
@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -100,3 +101,74 @@ func TestGatewayTCPRouteSourceEndpoints(t *testing.T) {
 		newTestEndpoint("api-template.foobar.internal", "A", ips...),
 	})
 }
+
+func TestGatewayTCPRouteSourceSRVRecords(t *testing.T) {
+	t.Parallel()
+
+	gwClient := gatewayfake.NewSimpleClientset()
+	kubeClient := kubefake.NewSimpleClientset()
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+
+	ctx := context.Background()
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+		},
+	}
+	_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Namespace")
+
+	gw := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "internal",
+			Namespace: "default",
+		},
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{
+				{Name: "ldap", Protocol: v1.TCPProtocolType, Port: 389},
+				{Name: "ldaps", Protocol: v1.TCPProtocolType, Port: 636},
+				{Name: "metrics", Protocol: v1.TCPProtocolType, Port: 9090},
+			},
+		},
+		Status: gatewayStatus("10.64.0.1"),
+	}
+	_, err = gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create Gateway")
+
+	rt := &v1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ldap",
+			Namespace: "default",
+			Annotations: map[string]string{
+				hostnameAnnotationKey:      "ldap.foobar.internal",
+				annotations.SRVPortsKey:    "ldap,ldaps",
+				annotations.SRVPriorityKey: "10",
+			},
+		},
+		Spec: v1alpha2.TCPRouteSpec{
+			CommonRouteSpec: v1.CommonRouteSpec{
+				ParentRefs: []v1.ParentReference{
+					gwParentRef("default", "internal"),
+				},
+			},
+		},
+		Status: v1alpha2.TCPRouteStatus{
+			RouteStatus: gwRouteStatus(gwParentRef("default", "internal")),
+		},
+	}
+	_, err = gwClient.GatewayV1alpha2().TCPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
+	require.NoError(t, err, "failed to create TCPRoute")
+
+	src, err := NewGatewayTCPRouteSource(clients, &Config{})
+	require.NoError(t, err, "failed to create Gateway TCPRoute Source")
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err, "failed to get Endpoints")
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("ldap.foobar.internal", "A", "10.64.0.1"),
+		newTestEndpoint("_ldap._tcp.ldap.foobar.internal", "SRV", "10 50 389 ldap.foobar.internal"),
+		newTestEndpoint("_ldaps._tcp.ldap.foobar.internal", "SRV", "10 50 636 ldap.foobar.internal"),
+	})
+}
@@ -174,7 +174,9 @@ func (sc *ingressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			continue
 		}
 
+		ingEndpoints = append(ingEndpoints, recordEndpointsFromAnnotations(ing.Annotations, ingEndpoints)...)
 		log.Debugf("Endpoints generated from ingress: %s/%s: %v", ing.Namespace, ing.Name, ingEndpoints)
+		setResourceMetadata(ingEndpoints, ing)
 		endpoints = append(endpoints, ingEndpoints...)
 	}
 
@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 // Validates that ingressSource is a Source
@@ -1064,6 +1065,51 @@ func testIngressEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			title:           "ingress rules with mx and txt annotations",
+			targetNamespace: "",
+			ingressItems: []fakeIngress{
+				{
+					name:      "fake1",
+					namespace: namespace,
+					annotations: map[string]string{
+						hostnameAnnotationKey: "mail.example.org",
+						ttlAnnotationKey:      "60",
+						annotations.MXKey:     "10 mx1.example.org,20 mx2.example.org",
+						annotations.TXTKey:    "v=spf1 mx -all\ngoogle-site-verification=abc",
+					},
+					dnsnames: []string{"example.org"},
+					ips:      []string{"8.8.8.8"},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.8.8"}, RecordTTL: endpoint.TTL(60)},
+				{DNSName: "mail.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"8.8.8.8"}, RecordTTL: endpoint.TTL(60)},
+				{DNSName: "example.org", RecordType: endpoint.RecordTypeMX, Targets: endpoint.Targets{"10 mx1.example.org", "20 mx2.example.org"}, RecordTTL: endpoint.TTL(60)},
+				{DNSName: "mail.example.org", RecordType: endpoint.RecordTypeMX, Targets: endpoint.Targets{"10 mx1.example.org", "20 mx2.example.org"}, RecordTTL: endpoint.TTL(60)},
+				{DNSName: "example.org", RecordType: endpoint.RecordTypeTXT, Targets: endpoint.Targets{"google-site-verification=abc", "v=spf1 mx -all"}, RecordTTL: endpoint.TTL(60)},
+				{DNSName: "mail.example.org", RecordType: endpoint.RecordTypeTXT, Targets: endpoint.Targets{"google-site-verification=abc", "v=spf1 mx -all"}, RecordTTL: endpoint.TTL(60)},
+			},
+		},
+		{
+			title:           "ingress rules with mx and txt annotations and a hostname target",
+			targetNamespace: "",
+			ingressItems: []fakeIngress{
+				{
+					name:      "fake1",
+					namespace: namespace,
+					annotations: map[string]string{
+						annotations.MXKey:  "10 mx1.example.org",
+						annotations.TXTKey: "v=spf1 mx -all",
+					},
+					dnsnames:  []string{"example.org"},
+					hostnames: []string{"elb.com"},
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"elb.com"}},
+			},
+		},
 		{
 			title:           "ingress rules with alias and target annotation",
 			targetNamespace: "",
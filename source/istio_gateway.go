@@ -183,7 +183,9 @@ func (sc *gatewaySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 			continue
 		}
 
+		gwEndpoints = append(gwEndpoints, recordEndpointsFromAnnotations(gateway.Annotations, gwEndpoints)...)
 		log.Debugf("Endpoints generated from gateway: %s/%s: %v", gateway.Namespace, gateway.Name, gwEndpoints)
+		setResourceMetadata(gwEndpoints, gateway)
 		endpoints = append(endpoints, gwEndpoints...)
 	}
 
@@ -183,7 +183,9 @@ func (sc *virtualServiceSource) Endpoints(ctx context.Context) ([]*endpoint.Endp
 			continue
 		}
 
+		gwEndpoints = append(gwEndpoints, recordEndpointsFromAnnotations(virtualService.Annotations, gwEndpoints)...)
 		log.Debugf("Endpoints generated from VirtualService: %s/%s: %v", virtualService.Namespace, virtualService.Name, gwEndpoints)
+		setResourceMetadata(gwEndpoints, virtualService)
 		endpoints = append(endpoints, gwEndpoints...)
 	}
 
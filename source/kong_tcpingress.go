@@ -151,7 +151,9 @@ func (sc *kongTCPIngressSource) Endpoints(ctx context.Context) ([]*endpoint.Endp
 			continue
 		}
 
+		ingressEndpoints = append(ingressEndpoints, recordEndpointsFromAnnotations(tcpIngress.Annotations, ingressEndpoints)...)
 		log.Debugf("Endpoints generated from TCPIngress: %s: %v", fullname, ingressEndpoints)
+		setResourceMetadata(ingressEndpoints, tcpIngress)
 		endpoints = append(endpoints, ingressEndpoints...)
 	}
 
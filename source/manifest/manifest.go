@@ -0,0 +1,254 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manifest serves the objects of Kubernetes manifests to the sources, so that their endpoints can be
+// calculated offline, without a cluster. It is backed by the fake clientsets, and used by the offline plans only.
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry-community/go-cfclient"
+	openshift "github.com/openshift/client-go/route/clientset/versioned"
+	openshiftfake "github.com/openshift/client-go/route/clientset/versioned/fake"
+	openshiftscheme "github.com/openshift/client-go/route/clientset/versioned/scheme"
+	log "github.com/sirupsen/logrus"
+	istioclient "istio.io/client-go/pkg/clientset/versioned"
+	istiofake "istio.io/client-go/pkg/clientset/versioned/fake"
+	istioscheme "istio.io/client-go/pkg/clientset/versioned/scheme"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+	gatewayscheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
+)
+
+// errNotSupportedOffline is returned for clients which cannot be served from manifests.
+var errNotSupportedOffline = errors.New("not supported with manifests")
+
+// clusterScopedKinds are the kinds read from manifests which are not put into the default namespace.
+var clusterScopedKinds = sets.New("Namespace", "Node", "GatewayClass")
+
+// ClientGenerator provides clients which serve the objects of Kubernetes manifests instead of
+// the objects of a cluster, so that the endpoints of the manifests can be calculated offline.
+// Only the objects of the Kubernetes, Gateway API, Istio and OpenShift route clients are served.
+type ClientGenerator struct {
+	kubeClient      kubernetes.Interface
+	gatewayClient   gateway.Interface
+	istioClient     istioclient.Interface
+	openshiftClient openshift.Interface
+}
+
+// NewClientGenerator creates the clients serving the given objects. Namespaced objects without
+// a namespace are served in the default namespace, and the namespaces of all objects are served even if
+// they are not part of the objects. Objects of unknown kinds are ignored.
+func NewClientGenerator(objects []*unstructured.Unstructured) (*ClientGenerator, error) {
+	kubeClient := kubefake.NewClientset()
+	gatewayClient := gatewayfake.NewSimpleClientset()
+	istioClient := istiofake.NewSimpleClientset()
+	openshiftClient := openshiftfake.NewSimpleClientset()
+	// the objects are added with their resource, since the trackers cannot tell the version of
+	// types which are registered for several versions, like the Gateway API types
+	trackers := []struct {
+		scheme  *runtime.Scheme
+		tracker k8stesting.ObjectTracker
+	}{
+		{kubescheme.Scheme, kubeClient.Tracker()},
+		{gatewayscheme.Scheme, gatewayClient.Tracker()},
+		{istioscheme.Scheme, istioClient.Tracker()},
+		{openshiftscheme.Scheme, openshiftClient.Tracker()},
+	}
+
+	namespaces := sets.New[string]()
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		if !clusterScopedKinds.Has(gvk.Kind) {
+			if obj.GetNamespace() == "" {
+				obj.SetNamespace(metav1.NamespaceDefault)
+			}
+			namespaces.Insert(obj.GetNamespace())
+		} else if gvk.Kind == "Namespace" && gvk.Group == "" {
+			namespaces.Delete(obj.GetName())
+		}
+
+		added := false
+		for _, t := range trackers {
+			if !t.scheme.Recognizes(gvk) {
+				continue
+			}
+			typed, err := t.scheme.New(gvk)
+			if err == nil {
+				err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typed)
+			}
+			if err == nil {
+				err = t.tracker.Create(resourceOf(gvk), typed, obj.GetNamespace())
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s %s/%s: %w", gvk.Kind, obj.GetNamespace(), obj.GetName(), err)
+			}
+			added = true
+			break
+		}
+		if !added {
+			log.Debugf("Ignoring %s %s/%s of the manifests, its kind is not supported", gvk, obj.GetNamespace(), obj.GetName())
+		}
+	}
+	for _, name := range sets.List(namespaces) {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if err := kubeClient.Tracker().Create(corev1.SchemeGroupVersion.WithResource("namespaces"), ns, ""); err != nil && !apierrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+	}
+
+	return &ClientGenerator{
+		kubeClient:      kubeClient,
+		gatewayClient:   gatewayClient,
+		istioClient:     istioClient,
+		openshiftClient: openshiftClient,
+	}, nil
+}
+
+// resourceOf returns the resource of the kind, which the clients are called with.
+func resourceOf(gvk schema.GroupVersionKind) schema.GroupVersionResource {
+	kind := strings.ToLower(gvk.Kind)
+	// the guess turns a trailing y into ies, also after a vowel, e.g. gatewaies
+	if len(kind) > 1 && strings.HasSuffix(kind, "y") && strings.ContainsRune("aeiou", rune(kind[len(kind)-2])) {
+		return gvk.GroupVersion().WithResource(kind + "s")
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	return gvr
+}
+
+// KubeClient returns the client serving the Kubernetes objects of the manifests.
+func (g *ClientGenerator) KubeClient() (kubernetes.Interface, error) {
+	return g.kubeClient, nil
+}
+
+// GatewayClient returns the client serving the Gateway API objects of the manifests.
+func (g *ClientGenerator) GatewayClient() (gateway.Interface, error) {
+	return g.gatewayClient, nil
+}
+
+// IstioClient returns the client serving the Istio objects of the manifests.
+func (g *ClientGenerator) IstioClient() (istioclient.Interface, error) {
+	return g.istioClient, nil
+}
+
+// CloudFoundryClient is not supported with manifests.
+func (g *ClientGenerator) CloudFoundryClient(string, string, string) (*cfclient.Client, error) {
+	return nil, fmt.Errorf("cloudfoundry client: %w", errNotSupportedOffline)
+}
+
+// DynamicKubernetesClient is not supported with manifests.
+func (g *ClientGenerator) DynamicKubernetesClient() (dynamic.Interface, error) {
+	return nil, fmt.Errorf("dynamic client: %w", errNotSupportedOffline)
+}
+
+// OpenShiftClient returns the client serving the OpenShift routes of the manifests.
+func (g *ClientGenerator) OpenShiftClient() (openshift.Interface, error) {
+	return g.openshiftClient, nil
+}
+
+// Read reads the objects of the YAML or JSON manifests in the given files, and in the files
+// with a .yaml, .yml or .json extension in the given directories and their subdirectories.
+// The items of lists are returned as separate objects.
+func Read(paths []string) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(file)) {
+			case ".yaml", ".yml", ".json":
+			default:
+				// files given explicitly are read whatever their extension
+				if file != path {
+					return nil
+				}
+			}
+			read, err := readManifest(file)
+			if err != nil {
+				return fmt.Errorf("reading manifest %s: %w", file, err)
+			}
+			objects = append(objects, read...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+func readManifest(file string) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var objects []*unstructured.Unstructured
+	decoder := yaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		// empty documents
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.GetKind() == "" {
+			return nil, fmt.Errorf("object without kind: %v", obj.Object)
+		}
+		if !obj.IsList() {
+			objects = append(objects, obj)
+			continue
+		}
+		err := obj.EachListItem(func(item runtime.Object) error {
+			objects = append(objects, item.(*unstructured.Unstructured))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
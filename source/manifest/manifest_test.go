@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// This is a compile-time validation that ClientGenerator is a source.ClientGenerator.
+var _ source.ClientGenerator = &ClientGenerator{}
+
+const testManifests = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+  annotations:
+    external-dns.alpha.kubernetes.io/hostname: app.example.com
+spec:
+  type: LoadBalancer
+status:
+  loadBalancer:
+    ingress:
+    - ip: 1.2.3.4
+---
+# an empty document
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: networking.k8s.io/v1
+  kind: Ingress
+  metadata:
+    name: web
+    namespace: web
+  spec:
+    rules:
+    - host: web.example.com
+- apiVersion: v1
+  kind: Node
+  metadata:
+    name: node-1
+`
+
+func writeManifest(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func objectNames(objects []*unstructured.Unstructured) []string {
+	names := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		names = append(names, obj.GetKind()+"/"+obj.GetName())
+	}
+	return names
+}
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "app.yaml"), testManifests)
+	writeManifest(t, filepath.Join(dir, "base", "gateway.json"), `{"apiVersion": "gateway.networking.k8s.io/v1", "kind": "Gateway", "metadata": {"name": "gw"}}`)
+	writeManifest(t, filepath.Join(dir, "base", "README.md"), "not a manifest")
+	writeManifest(t, filepath.Join(dir, "extra.txt"), "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: extra\n")
+
+	objects, err := Read([]string{filepath.Join(dir, "app.yaml"), filepath.Join(dir, "base")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Service/app", "Ingress/web", "Node/node-1", "Gateway/gw"}, objectNames(objects))
+
+	// the files of directories are filtered by their extension, files given explicitly are not
+	objects, err = Read([]string{dir})
+	require.NoError(t, err)
+	assert.Len(t, objects, 4)
+	objects, err = Read([]string{filepath.Join(dir, "extra.txt")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ConfigMap/extra"}, objectNames(objects))
+
+	writeManifest(t, filepath.Join(dir, "invalid.yaml"), "metadata:\n  name: invalid\n")
+	_, err = Read([]string{filepath.Join(dir, "invalid.yaml")})
+	assert.ErrorContains(t, err, "object without kind")
+
+	_, err = Read([]string{filepath.Join(dir, "missing.yaml")})
+	assert.Error(t, err)
+}
+
+func TestClientGenerator(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "app.yaml"), testManifests+`---
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: gw
+---
+apiVersion: externaldns.k8s.io/v1alpha1
+kind: DNSEndpoint
+metadata:
+  name: unsupported
+`)
+	objects, err := Read([]string{dir})
+	require.NoError(t, err)
+	generator, err := NewClientGenerator(objects)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	client, err := generator.KubeClient()
+	require.NoError(t, err)
+
+	// objects without a namespace are in the default namespace
+	svc, err := client.CoreV1().Services(metav1.NamespaceDefault).Get(ctx, "app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3.4", svc.Status.LoadBalancer.Ingress[0].IP)
+	_, err = client.NetworkingV1().Ingresses("web").Get(ctx, "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	node, err := client.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, node.Namespace)
+
+	// the namespaces of the objects exist
+	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, namespaces.Items, 2)
+	assert.Equal(t, "default", namespaces.Items[0].Name)
+	assert.Equal(t, "web", namespaces.Items[1].Name)
+
+	gatewayClient, err := generator.GatewayClient()
+	require.NoError(t, err)
+	_, err = gatewayClient.GatewayV1().Gateways(metav1.NamespaceDefault).Get(ctx, "gw", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	_, err = generator.DynamicKubernetesClient()
+	assert.ErrorIs(t, err, errNotSupportedOffline)
+}
+
+func TestClientGeneratorSource(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "app.yaml"), testManifests)
+	objects, err := Read([]string{dir})
+	require.NoError(t, err)
+	generator, err := NewClientGenerator(objects)
+	require.NoError(t, err)
+
+	src, err := source.BuildWithConfig(context.Background(), "service", generator, &source.Config{LabelFilter: labels.Everything()})
+	require.NoError(t, err)
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "app.example.com", endpoints[0].DNSName)
+	assert.Equal(t, "service/default/app", endpoints[0].Labels[endpoint.ResourceLabelKey])
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
 )
 
 // multiSource is a Source that merges the endpoints of its nested Sources.
@@ -59,6 +60,12 @@ func (ms *multiSource) AddEventHandler(ctx context.Context, handler func()) {
 	}
 }
 
+func (ms *multiSource) ReportStatus(ctx context.Context, results []*plan.Result) {
+	for _, s := range ms.children {
+		reportStatus(ctx, s, results)
+	}
+}
+
 // NewMultiSource creates a new multiSource.
 func NewMultiSource(children []Source, defaultTargets []string) Source {
 	return &multiSource{children: children, defaultTargets: defaultTargets}
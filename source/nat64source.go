@@ -22,6 +22,7 @@ import (
 	"net/netip"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
 )
 
 // nat64Source is a Source that adds A endpoints for AAAA records including an NAT64 address.
@@ -110,3 +111,7 @@ func (s *nat64Source) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, erro
 func (s *nat64Source) AddEventHandler(ctx context.Context, handler func()) {
 	s.source.AddEventHandler(ctx, handler)
 }
+
+func (s *nat64Source) ReportStatus(ctx context.Context, results []*plan.Result) {
+	reportStatus(ctx, s.source, results)
+}
@@ -19,6 +19,8 @@ package source
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"text/template"
 
 	log "github.com/sirupsen/logrus"
@@ -172,7 +174,43 @@ func (ns *nodeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 	return endpointsSlice, nil
 }
 
-func (ns *nodeSource) AddEventHandler(_ context.Context, _ func()) {
+// AddEventHandler triggers the handler on the additions and deletions of the nodes selected by the annotation
+// filter and the label selector, and on the changes of their addresses, annotations, labels or schedulability.
+func (ns *nodeSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handler for node")
+
+	selector, err := annotations.ParseFilter(ns.annotationFilter)
+	if err != nil {
+		log.Warnf("Failed to parse the annotation filter of the node events, all nodes trigger a synchronization: %v", err)
+		selector = labels.Everything()
+	}
+
+	// Right now there is no way to remove event handler from informer, see:
+	// https://github.com/kubernetes/kubernetes/issues/79610
+	_, _ = ns.nodeInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			node, ok := eventObject(obj).(*v1.Node)
+			return ok && ns.labelSelector.Matches(labels.Set(node.Labels)) && selector.Matches(labels.Set(node.Annotations))
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc: func(interface{}) { handler() },
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if nodeChanged(oldObj.(*v1.Node), newObj.(*v1.Node)) {
+					handler()
+				}
+			},
+			DeleteFunc: func(interface{}) { handler() },
+		},
+	})
+}
+
+// nodeChanged tells whether a node changed in a way which may change its endpoints, the frequent
+// updates of its status conditions don't.
+func nodeChanged(old, node *v1.Node) bool {
+	return !maps.Equal(old.Annotations, node.Annotations) ||
+		!maps.Equal(old.Labels, node.Labels) ||
+		!slices.Equal(old.Status.Addresses, node.Status.Addresses) ||
+		old.Spec.Unschedulable != node.Spec.Unschedulable
 }
 
 // nodeAddress returns the node's externalIP and if that's not found, the node's internalIP
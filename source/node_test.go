@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"maps"
 	"math/rand"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -623,6 +624,53 @@ type nodeListBuilder struct {
 	nodes []v1.Node
 }
 
+func TestNodeSourceAddEventHandler(t *testing.T) {
+	kubeClient := fake.NewClientset()
+	selector, err := labels.Parse("role=edge")
+	require.NoError(t, err)
+	src, err := NewNodeSource(t.Context(), kubeClient, "external-dns.alpha.kubernetes.io/exclude notin (true)", "", selector, true, false, false)
+	require.NoError(t, err)
+
+	var counter atomic.Int32
+	src.AddEventHandler(t.Context(), func() { counter.Add(1) })
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"role": "edge"}},
+		Status:     v1.NodeStatus{Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "1.2.3.4"}}},
+	}
+	node, err = kubeClient.CoreV1().Nodes().Create(t.Context(), node, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return counter.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	// nodes which are not selected don't trigger
+	_, err = kubeClient.CoreV1().Nodes().Create(t.Context(), &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-2", Labels: map[string]string{"role": "worker"}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = kubeClient.CoreV1().Nodes().Create(t.Context(), &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node-3",
+			Labels:      map[string]string{"role": "edge"},
+			Annotations: map[string]string{"external-dns.alpha.kubernetes.io/exclude": "true"},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// the updates of the status conditions don't trigger, the updates of the addresses do
+	node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}
+	node, err = kubeClient.CoreV1().Nodes().UpdateStatus(t.Context(), node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	node.Status.Addresses = append(node.Status.Addresses, v1.NodeAddress{Type: v1.NodeExternalIP, Address: "1.2.3.5"})
+	_, err = kubeClient.CoreV1().Nodes().UpdateStatus(t.Context(), node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return counter.Load() == 2 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, kubeClient.CoreV1().Nodes().Delete(t.Context(), "node-2", metav1.DeleteOptions{}))
+	require.NoError(t, kubeClient.CoreV1().Nodes().Delete(t.Context(), "node-1", metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool { return counter.Load() == 3 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(3), counter.Load())
+}
+
 func helperNodeBuilder() *nodeListBuilder {
 	return &nodeListBuilder{nodes: []v1.Node{}}
 }
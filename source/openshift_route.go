@@ -159,7 +159,9 @@ func (ors *ocpRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 			continue
 		}
 
+		orEndpoints = append(orEndpoints, recordEndpointsFromAnnotations(ocpRoute.Annotations, orEndpoints)...)
 		log.Debugf("Endpoints generated from OpenShift Route: %s/%s: %v", ocpRoute.Namespace, ocpRoute.Name, orEndpoints)
+		setResourceMetadata(orEndpoints, ocpRoute)
 		endpoints = append(endpoints, orEndpoints...)
 	}
 
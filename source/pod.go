@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"slices"
 	"text/template"
 
 	log "github.com/sirupsen/logrus"
@@ -40,6 +41,8 @@ import (
 type podSource struct {
 	client                kubernetes.Interface
 	namespace             string
+	annotationFilter      string
+	labelSelector         labels.Selector
 	fqdnTemplate          *template.Template
 	combineFQDNAnnotation bool
 
@@ -55,6 +58,8 @@ func NewPodSource(
 	ctx context.Context,
 	kubeClient kubernetes.Interface,
 	namespace string,
+	annotationFilter string,
+	labelSelector labels.Selector,
 	compatibility string,
 	ignoreNonHostNetworkPods bool,
 	podSourceDomain string,
@@ -95,6 +100,8 @@ func NewPodSource(
 		podInformer:              podInformer,
 		nodeInformer:             nodeInformer,
 		namespace:                namespace,
+		annotationFilter:         annotationFilter,
+		labelSelector:            labelSelector,
 		compatibility:            compatibility,
 		ignoreNonHostNetworkPods: ignoreNonHostNetworkPods,
 		podSourceDomain:          podSourceDomain,
@@ -103,7 +110,50 @@ func NewPodSource(
 	}, nil
 }
 
-func (*podSource) AddEventHandler(_ context.Context, _ func()) {
+// AddEventHandler triggers the handler on the additions and deletions of the pods selected by the annotation
+// filter and the label selector, and on the changes of their addresses, annotations, labels or node.
+// The filters only apply to the events, the endpoints of all pods are still returned by Endpoints.
+func (ps *podSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handler for pod")
+
+	selector, err := annotations.ParseFilter(ps.annotationFilter)
+	if err != nil {
+		log.Warnf("Failed to parse the annotation filter of the pod events, all pods trigger a synchronization: %v", err)
+		selector = labels.Everything()
+	}
+
+	// Right now there is no way to remove event handler from informer, see:
+	// https://github.com/kubernetes/kubernetes/issues/79610
+	_, _ = ps.podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			pod, ok := eventObject(obj).(*corev1.Pod)
+			if !ok || (ps.ignoreNonHostNetworkPods && !pod.Spec.HostNetwork) {
+				return false
+			}
+			return ps.labelSelector.Matches(labels.Set(pod.Labels)) && selector.Matches(labels.Set(pod.Annotations))
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc: func(interface{}) { handler() },
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if podChanged(oldObj.(*corev1.Pod), newObj.(*corev1.Pod)) {
+					handler()
+				}
+			},
+			DeleteFunc: func(interface{}) { handler() },
+		},
+	})
+}
+
+// podChanged tells whether a pod changed in a way which may change its endpoints, the frequent
+// updates of its container statuses don't.
+func podChanged(old, pod *corev1.Pod) bool {
+	return !maps.Equal(old.Annotations, pod.Annotations) ||
+		!maps.Equal(old.Labels, pod.Labels) ||
+		old.Spec.NodeName != pod.Spec.NodeName ||
+		old.Status.PodIP != pod.Status.PodIP ||
+		old.Status.HostIP != pod.Status.HostIP ||
+		!slices.Equal(old.Status.PodIPs, pod.Status.PodIPs) ||
+		!slices.Equal(old.Status.HostIPs, pod.Status.HostIPs)
 }
 
 func (ps *podSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/external-dns/endpoint"
 )
@@ -54,6 +55,8 @@ func TestNewPodSourceWithFqdn(t *testing.T) {
 				t.Context(),
 				fake.NewClientset(),
 				"",
+				tt.annotationFilter,
+				labels.Everything(),
 				"",
 				false,
 				"",
@@ -402,6 +405,8 @@ func TestPodSourceFqdnTemplatingExamples(t *testing.T) {
 				kubeClient,
 				"",
 				"",
+				labels.Everything(),
+				"",
 				false,
 				tt.sourceDomain,
 				tt.fqdnTemplate,
@@ -464,6 +469,8 @@ func TestPodSourceFqdnTemplatingExamples_Failed(t *testing.T) {
 				kubeClient,
 				"",
 				"",
+				labels.Everything(),
+				"",
 				false,
 				tt.sourceDomain,
 				tt.fqdnTemplate,
@@ -20,12 +20,15 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
@@ -652,7 +655,7 @@ func TestPodSource(t *testing.T) {
 				}
 			}
 
-			client, err := NewPodSource(ctx, kubernetes, tc.targetNamespace, tc.compatibility, tc.ignoreNonHostNetworkPods, tc.PodSourceDomain, "", false)
+			client, err := NewPodSource(ctx, kubernetes, tc.targetNamespace, "", labels.Everything(), tc.compatibility, tc.ignoreNonHostNetworkPods, tc.PodSourceDomain, "", false)
 			require.NoError(t, err)
 
 			endpoints, err := client.Endpoints(ctx)
@@ -880,7 +883,7 @@ func TestPodSourceLogs(t *testing.T) {
 				}
 			}
 
-			client, err := NewPodSource(ctx, kubernetes, "", "", tc.ignoreNonHostNetworkPods, "", "", false)
+			client, err := NewPodSource(ctx, kubernetes, "", "", labels.Everything(), "", tc.ignoreNonHostNetworkPods, "", "", false)
 			require.NoError(t, err)
 
 			hook := testutils.LogsUnderTestWithLogLevel(log.DebugLevel, t)
@@ -955,3 +958,85 @@ func nodesFixturesIPv4() []*corev1.Node {
 		},
 	}
 }
+
+func TestPodSourceAddEventHandler(t *testing.T) {
+	kubeClient := fake.NewClientset()
+	selector, err := labels.Parse("app=dns")
+	require.NoError(t, err)
+	src, err := NewPodSource(t.Context(), kubeClient, "", "external-dns.alpha.kubernetes.io/exclude notin (true)", selector, "", true, "", "", false)
+	require.NoError(t, err)
+
+	var counter atomic.Int32
+	src.AddEventHandler(t.Context(), func() { counter.Add(1) })
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "kube-system",
+			Name:        "my-pod",
+			Labels:      map[string]string{"app": "dns"},
+			Annotations: map[string]string{hostnameAnnotationKey: "a.foo.example.org"},
+		},
+		Spec:   corev1.PodSpec{HostNetwork: true, NodeName: "my-node"},
+		Status: corev1.PodStatus{PodIP: "10.0.1.1"},
+	}
+	pod, err = kubeClient.CoreV1().Pods("kube-system").Create(t.Context(), pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return counter.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	// pods which are not selected, or don't use the host network, don't trigger
+	for _, other := range []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "other-app", Labels: map[string]string{"app": "web"}}, Spec: corev1.PodSpec{HostNetwork: true}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "no-host-network", Labels: map[string]string{"app": "dns"}}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "kube-system",
+			Name:        "excluded",
+			Labels:      map[string]string{"app": "dns"},
+			Annotations: map[string]string{"external-dns.alpha.kubernetes.io/exclude": "true"},
+		}, Spec: corev1.PodSpec{HostNetwork: true}},
+	} {
+		_, err = kubeClient.CoreV1().Pods("kube-system").Create(t.Context(), other, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	// the updates of the container statuses don't trigger, the updates of the addresses do
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "dns", RestartCount: 1}}
+	pod, err = kubeClient.CoreV1().Pods("kube-system").UpdateStatus(t.Context(), pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	pod.Status.PodIP = "10.0.1.2"
+	_, err = kubeClient.CoreV1().Pods("kube-system").UpdateStatus(t.Context(), pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return counter.Load() == 2 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, kubeClient.CoreV1().Pods("kube-system").Delete(t.Context(), "other-app", metav1.DeleteOptions{}))
+	require.NoError(t, kubeClient.CoreV1().Pods("kube-system").Delete(t.Context(), "my-pod", metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool { return counter.Load() == 3 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(3), counter.Load())
+}
+
+func TestPodSourceEndpointsIgnoreFilters(t *testing.T) {
+	kubeClient := fake.NewClientset()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "kube-system",
+			Name:        "other-app",
+			Labels:      map[string]string{"app": "web"},
+			Annotations: map[string]string{internalHostnameAnnotationKey: "a.foo.example.org", "external-dns.alpha.kubernetes.io/exclude": "true"},
+		},
+		Spec:   corev1.PodSpec{HostNetwork: true, NodeName: "my-node"},
+		Status: corev1.PodStatus{PodIP: "10.0.1.1"},
+	}
+	_, err := kubeClient.CoreV1().Pods("kube-system").Create(t.Context(), pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	selector, err := labels.Parse("app=dns")
+	require.NoError(t, err)
+	src, err := NewPodSource(t.Context(), kubeClient, "", "external-dns.alpha.kubernetes.io/exclude notin (true)", selector, "", true, "", "", false)
+	require.NoError(t, err)
+
+	// the filters only apply to the events, the records of the pods which don't match them are still registered
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "a.foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.1.1"}},
+	})
+}
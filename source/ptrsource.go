@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/rfc2317"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// reverseZone is a reverse lookup zone of a network, e.g. "30.20.10.in-addr.arpa" for 10.20.30.0/24
+// or the RFC 2317 classless delegation "0/25.30.20.10.in-addr.arpa" for 10.20.30.0/25.
+type reverseZone struct {
+	prefix netip.Prefix
+	name   string
+}
+
+// classless returns true if the zone is an RFC 2317 classless in-addr.arpa delegation.
+func (z reverseZone) classless() bool {
+	return z.prefix.Addr().Is4() && z.prefix.Bits() > 24 && z.prefix.Bits() < 32
+}
+
+// ptrName returns the name of the PTR record of ip in the zone.
+func (z reverseZone) ptrName(ip netip.Addr) (string, error) {
+	if z.classless() {
+		return fmt.Sprintf("%d.%s", ip.As4()[3], z.name), nil
+	}
+	return rfc2317.CidrToInAddr(ip.String())
+}
+
+// parseReverseZones parses the networks of reverse lookup zones given as CIDRs.
+func parseReverseZones(cidrs []string) ([]reverseZone, error) {
+	zones := make([]reverseZone, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reverse zone %q: %w", cidr, err)
+		}
+		name, err := rfc2317.CidrToInAddr(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reverse zone %q: %w", cidr, err)
+		}
+		zones = append(zones, reverseZone{prefix: prefix, name: name})
+	}
+	return zones, nil
+}
+
+// ReverseZoneNames returns the names of the reverse lookup zones of the networks given as CIDRs.
+func ReverseZoneNames(cidrs []string) ([]string, error) {
+	zones, err := parseReverseZones(cidrs)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		names = append(names, zone.name)
+	}
+	return names, nil
+}
+
+// ptrSource is a Source that adds PTR endpoints for the targets of A and AAAA endpoints
+// which are in the configured reverse zones.
+type ptrSource struct {
+	source Source
+	zones  []reverseZone
+}
+
+// NewPTRSource creates a new ptrSource wrapping the provided Source. The reverse zones are
+// given as the CIDRs of their networks.
+func NewPTRSource(source Source, reverseZones []string) (Source, error) {
+	zones, err := parseReverseZones(reverseZones)
+	if err != nil {
+		return nil, err
+	}
+	// prefer the most specific zone for an address
+	sort.SliceStable(zones, func(i, j int) bool {
+		return zones[i].prefix.Bits() > zones[j].prefix.Bits()
+	})
+	return &ptrSource{source: source, zones: zones}, nil
+}
+
+// Endpoints collects endpoints from its wrapped source and adds a PTR endpoint for every address
+// in a reverse zone, pointing to all names resolving to the address.
+func (s *ptrSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.zones) == 0 {
+		return endpoints, nil
+	}
+
+	ptrs := map[string]*endpoint.Endpoint{}
+	var names []string
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeA && ep.RecordType != endpoint.RecordTypeAAAA {
+			continue
+		}
+		// a PTR record cannot point to a wildcard
+		if strings.HasPrefix(ep.DNSName, "*.") {
+			continue
+		}
+
+		for _, target := range ep.Targets {
+			// alias targets of providers like AWS are not addresses
+			ip, err := netip.ParseAddr(target)
+			if err != nil {
+				continue
+			}
+			name, ok := s.ptrName(ip.Unmap())
+			if !ok {
+				continue
+			}
+
+			hostname := strings.TrimSuffix(ep.DNSName, ".")
+			ptr, ok := ptrs[name]
+			if !ok {
+				ptr = endpoint.NewEndpointWithTTL(name, endpoint.RecordTypePTR, ep.RecordTTL)
+				if resource, ok := ep.Labels[endpoint.ResourceLabelKey]; ok {
+					ptr.WithLabel(endpoint.ResourceLabelKey, resource)
+				}
+				ptrs[name] = ptr
+				names = append(names, name)
+			}
+			if !slices.Contains(ptr.Targets, hostname) {
+				ptr.Targets = append(ptr.Targets, hostname)
+			}
+		}
+	}
+
+	for _, name := range names {
+		sort.Strings(ptrs[name].Targets)
+		endpoints = append(endpoints, ptrs[name])
+	}
+	return endpoints, nil
+}
+
+// ptrName returns the name of the PTR record of ip in the most specific reverse zone containing it.
+func (s *ptrSource) ptrName(ip netip.Addr) (string, bool) {
+	for _, zone := range s.zones {
+		if !zone.prefix.Contains(ip) {
+			continue
+		}
+		name, err := zone.ptrName(ip)
+		if err != nil {
+			log.Debugf("Skipping PTR record for %s in reverse zone %s: %v", ip, zone.name, err)
+			return "", false
+		}
+		return name, true
+	}
+	return "", false
+}
+
+func (s *ptrSource) AddEventHandler(ctx context.Context, handler func()) {
+	s.source.AddEventHandler(ctx, handler)
+}
+
+func (s *ptrSource) ReportStatus(ctx context.Context, results []*plan.Result) {
+	reportStatus(ctx, s.source, results)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+// Validates that ptrSource is a Source
+var _ Source = &ptrSource{}
+
+func TestPTRSource(t *testing.T) {
+	reverseZones := []string{"10.0.0.0/8", "10.20.30.0/25", "2001:db8::/32"}
+
+	for _, tc := range []struct {
+		title     string
+		endpoints []*endpoint.Endpoint
+		expected  []*endpoint.Endpoint
+	}{
+		{
+			"A endpoint in a classful zone",
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 300, "10.1.2.3"),
+			},
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("foo.example.org", endpoint.RecordTypeA, 300, "10.1.2.3"),
+				endpoint.NewEndpointWithTTL("3.2.1.10.in-addr.arpa", endpoint.RecordTypePTR, 300, "foo.example.org"),
+			},
+		},
+		{
+			"A endpoint in a classless delegation",
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "10.20.30.5", "10.20.30.200"),
+			},
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "10.20.30.5", "10.20.30.200"),
+				endpoint.NewEndpoint("5.0/25.30.20.10.in-addr.arpa", endpoint.RecordTypePTR, "foo.example.org"),
+				endpoint.NewEndpoint("200.30.20.10.in-addr.arpa", endpoint.RecordTypePTR, "foo.example.org"),
+			},
+		},
+		{
+			"AAAA endpoint",
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			},
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+				endpoint.NewEndpoint("1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", endpoint.RecordTypePTR, "foo.example.org"),
+			},
+		},
+		{
+			"names sharing an address",
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "10.1.2.3"),
+				endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeA, "10.1.2.3"),
+				endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeA, "10.1.2.3").WithSetIdentifier("second"),
+			},
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "10.1.2.3"),
+				endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeA, "10.1.2.3"),
+				endpoint.NewEndpoint("bar.example.org", endpoint.RecordTypeA, "10.1.2.3").WithSetIdentifier("second"),
+				endpoint.NewEndpoint("3.2.1.10.in-addr.arpa", endpoint.RecordTypePTR, "bar.example.org", "foo.example.org"),
+			},
+		},
+		{
+			"addresses outside of the reverse zones, wildcards, aliases and other record types are skipped",
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "192.168.1.1"),
+				endpoint.NewEndpoint("*.example.org", endpoint.RecordTypeA, "10.1.2.3"),
+				endpoint.NewEndpoint("alias.example.org", endpoint.RecordTypeA, "lb.example.com"),
+				endpoint.NewEndpoint("cname.example.org", endpoint.RecordTypeCNAME, "10.1.2.3.example.com"),
+			},
+			[]*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "192.168.1.1"),
+				endpoint.NewEndpoint("*.example.org", endpoint.RecordTypeA, "10.1.2.3"),
+				endpoint.NewEndpoint("alias.example.org", endpoint.RecordTypeA, "lb.example.com"),
+				endpoint.NewEndpoint("cname.example.org", endpoint.RecordTypeCNAME, "10.1.2.3.example.com"),
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			mockSource := new(testutils.MockSource)
+			mockSource.On("Endpoints").Return(tc.endpoints, nil)
+
+			source, err := NewPTRSource(mockSource, reverseZones)
+			require.NoError(t, err)
+
+			endpoints, err := source.Endpoints(context.Background())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+			mockSource.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPTRSourceResourceLabel(t *testing.T) {
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "10.1.2.3").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+	}, nil)
+
+	source, err := NewPTRSource(mockSource, []string{"10.1.2.0/24"})
+	require.NoError(t, err)
+
+	endpoints, err := source.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, "3.2.1.10.in-addr.arpa", endpoints[1].DNSName)
+	assert.Equal(t, "service/default/foo", endpoints[1].Labels[endpoint.ResourceLabelKey])
+}
+
+func TestReverseZoneNames(t *testing.T) {
+	names, err := ReverseZoneNames([]string{"10.0.0.0/8", "10.20.30.0/26", "2001:db8::/32"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.in-addr.arpa", "0/26.30.20.10.in-addr.arpa", "8.b.d.0.1.0.0.2.ip6.arpa"}, names)
+
+	for _, cidr := range []string{"10.0.0.1/8", "10.0.0.0/12", "not-a-network"} {
+		_, err := ReverseZoneNames([]string{cidr})
+		assert.Error(t, err, cidr)
+	}
+}
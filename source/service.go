@@ -28,10 +28,13 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	kubeinformers "k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	discoveryinformers "k8s.io/client-go/informers/discovery/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
@@ -72,18 +75,19 @@ type serviceSource struct {
 	resolveLoadBalancerHostname    bool
 	listenEndpointEvents           bool
 	serviceInformer                coreinformers.ServiceInformer
-	endpointsInformer              coreinformers.EndpointsInformer
+	endpointSlicesInformer         discoveryinformers.EndpointSliceInformer
 	podInformer                    coreinformers.PodInformer
 	nodeInformer                   coreinformers.NodeInformer
 	serviceTypeFilter              *serviceTypes
 	exposeInternalIPv6             bool
+	topologyZones                  []string
 
 	// process Services with legacy annotations
 	compatibility string
 }
 
 // NewServiceSource creates a new serviceSource with the given config.
-func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter, fqdnTemplate string, combineFqdnAnnotation bool, compatibility string, publishInternal, publishHostIP, alwaysPublishNotReadyAddresses bool, serviceTypeFilter []string, ignoreHostnameAnnotation bool, labelSelector labels.Selector, resolveLoadBalancerHostname, listenEndpointEvents bool, exposeInternalIPv6 bool) (Source, error) {
+func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter, fqdnTemplate string, combineFqdnAnnotation bool, compatibility string, publishInternal, publishHostIP, alwaysPublishNotReadyAddresses bool, serviceTypeFilter []string, ignoreHostnameAnnotation bool, labelSelector labels.Selector, resolveLoadBalancerHostname, listenEndpointEvents bool, exposeInternalIPv6 bool, topologyZones []string) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
@@ -93,7 +97,7 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 	// Set the resync period to 0 to prevent processing when nothing has changed
 	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
 	serviceInformer := informerFactory.Core().V1().Services()
-	endpointsInformer := informerFactory.Core().V1().Endpoints()
+	endpointSlicesInformer := informerFactory.Discovery().V1().EndpointSlices()
 	podInformer := informerFactory.Core().V1().Pods()
 	nodeInformer := informerFactory.Core().V1().Nodes()
 
@@ -104,7 +108,7 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 			},
 		},
 	)
-	endpointsInformer.Informer().AddEventHandler(
+	endpointSlicesInformer.Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
 			},
@@ -148,7 +152,7 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		publishHostIP:                  publishHostIP,
 		alwaysPublishNotReadyAddresses: alwaysPublishNotReadyAddresses,
 		serviceInformer:                serviceInformer,
-		endpointsInformer:              endpointsInformer,
+		endpointSlicesInformer:         endpointSlicesInformer,
 		podInformer:                    podInformer,
 		nodeInformer:                   nodeInformer,
 		serviceTypeFilter:              sTypesFilter,
@@ -156,6 +160,7 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		resolveLoadBalancerHostname:    resolveLoadBalancerHostname,
 		listenEndpointEvents:           listenEndpointEvents,
 		exposeInternalIPv6:             exposeInternalIPv6,
+		topologyZones:                  topologyZones,
 	}, nil
 }
 
@@ -215,6 +220,7 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 		}
 
 		log.Debugf("Endpoints generated from service: %s/%s: %v", svc.Namespace, svc.Name, svcEndpoints)
+		setResourceMetadata(svcEndpoints, svc)
 		endpoints = append(endpoints, svcEndpoints...)
 	}
 
@@ -265,7 +271,7 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 	return endpoints, nil
 }
 
-// extractHeadlessEndpoints extracts endpoints from a headless service using the "Endpoints" Kubernetes API resource
+// extractHeadlessEndpoints extracts endpoints from a headless service using its "EndpointSlice" Kubernetes API resources
 func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname string, ttl endpoint.TTL) []*endpoint.Endpoint {
 	var endpoints []*endpoint.Endpoint
 
@@ -278,11 +284,15 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 		return nil
 	}
 
-	endpointsObject, err := sc.endpointsInformer.Lister().Endpoints(svc.Namespace).Get(svc.GetName())
+	endpointSlices, err := sc.endpointSlicesInformer.Lister().EndpointSlices(svc.Namespace).List(labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: svc.GetName()}))
 	if err != nil {
-		log.Errorf("Get endpoints of service[%s] error:%v", svc.GetName(), err)
+		log.Errorf("List endpoint slices of service[%s] error:%v", svc.GetName(), err)
 		return endpoints
 	}
+	// a service has an endpoint slice per address family, and more when it has many endpoints
+	sort.Slice(endpointSlices, func(i, j int) bool {
+		return endpointSlices[i].Name < endpointSlices[j].Name
+	})
 
 	pods, err := sc.podInformer.Lister().Pods(svc.Namespace).List(selector)
 	if err != nil {
@@ -291,15 +301,19 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 	}
 
 	endpointsType := getEndpointsTypeFromAnnotations(svc.Annotations)
+	publishNotReadyAddresses := svc.Spec.PublishNotReadyAddresses || sc.alwaysPublishNotReadyAddresses
 
 	targetsByHeadlessDomainAndType := make(map[endpoint.EndpointKey]endpoint.Targets)
-	for _, subset := range endpointsObject.Subsets {
-		addresses := subset.Addresses
-		if svc.Spec.PublishNotReadyAddresses || sc.alwaysPublishNotReadyAddresses {
-			addresses = append(addresses, subset.NotReadyAddresses...)
+	for _, endpointSlice := range endpointSlices {
+		if endpointSlice.AddressType != discoveryv1.AddressTypeIPv4 && endpointSlice.AddressType != discoveryv1.AddressTypeIPv6 {
+			log.Debugf("Skipping endpoint slice %s because its addresses are not IP addresses: %s", endpointSlice.Name, endpointSlice.AddressType)
+			continue
 		}
-
-		for _, address := range addresses {
+		for _, address := range endpointSlice.Endpoints {
+			if !sc.publishEndpoint(address, publishNotReadyAddresses) {
+				log.Debugf("Skipping endpoint %v of endpoint slice %s because of its conditions or zone", address.Addresses, endpointSlice.Name)
+				continue
+			}
 			// find pod for this address
 			if address.TargetRef == nil || address.TargetRef.APIVersion != "" || address.TargetRef.Kind != "Pod" {
 				log.Debugf("Skipping address because its target is not a pod: %v", address)
@@ -338,11 +352,11 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 							}
 						}
 					} else if endpointsType == EndpointsTypeHostIP || sc.publishHostIP {
-						targets = endpoint.Targets{pod.Status.HostIP}
-						log.Debugf("Generating matching endpoint %s with HostIP %s", headlessDomain, pod.Status.HostIP)
+						targets = podHostIPs(pod)
+						log.Debugf("Generating matching endpoint %s with HostIP %v", headlessDomain, targets)
 					} else {
-						targets = endpoint.Targets{address.IP}
-						log.Debugf("Generating matching endpoint %s with EndpointAddress IP %s", headlessDomain, address.IP)
+						targets = address.Addresses
+						log.Debugf("Generating matching endpoint %s with Endpoint addresses %v", headlessDomain, address.Addresses)
 					}
 				}
 				for _, target := range targets {
@@ -397,6 +411,38 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 	return endpoints
 }
 
+// publishEndpoint tells whether the addresses of an endpoint of a headless service are published: the endpoint
+// is in one of the topology zones, if any, and it is ready, or not ready and publishNotReadyAddresses is set.
+// Terminating endpoints are never published, even while they are still serving.
+func (sc *serviceSource) publishEndpoint(ep discoveryv1.Endpoint, publishNotReadyAddresses bool) bool {
+	if len(sc.topologyZones) > 0 && (ep.Zone == nil || !slices.Contains(sc.topologyZones, *ep.Zone)) {
+		return false
+	}
+	conditions := ep.Conditions
+	if conditions.Terminating != nil && *conditions.Terminating {
+		return false
+	}
+	// a nil ready condition is unknown and must be interpreted as ready, the serving condition is the
+	// same as the ready condition for endpoints which are not terminating
+	ready := conditions.Ready == nil || *conditions.Ready
+	if conditions.Ready == nil && conditions.Serving != nil {
+		ready = *conditions.Serving
+	}
+	return ready || publishNotReadyAddresses
+}
+
+// podHostIPs returns the IP addresses of the host of a pod, both the IPv4 and the IPv6 address on dual-stack nodes.
+func podHostIPs(pod *v1.Pod) endpoint.Targets {
+	if len(pod.Status.HostIPs) == 0 {
+		return endpoint.Targets{pod.Status.HostIP}
+	}
+	targets := make(endpoint.Targets, 0, len(pod.Status.HostIPs))
+	for _, hostIP := range pod.Status.HostIPs {
+		targets = append(targets, hostIP.IP)
+	}
+	return targets
+}
+
 func (sc *serviceSource) endpointsFromTemplate(svc *v1.Service) ([]*endpoint.Endpoint, error) {
 	hostnames, err := fqdn.ExecTemplate(sc.fqdnTemplate, svc)
 	if err != nil {
@@ -521,9 +567,72 @@ func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, pro
 
 	endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
 
+	// the records of the annotations are declared for the hostname, not for the pods of headless services
+	hostnameEndpoints := slices.DeleteFunc(slices.Clone(endpoints), func(ep *endpoint.Endpoint) bool { return ep.DNSName != hostname })
+	endpoints = append(endpoints, recordEndpointsFromAnnotations(svc.Annotations, hostnameEndpoints)...)
+
+	if srv := annotations.SRVRecordsFromAnnotations(svc.Annotations, resource); srv != nil {
+		endpoints = append(endpoints, sc.extractSRVEndpoints(svc, srv, hostname, srvHosts(hostname, endpoints), ttl, providerSpecific, setIdentifier)...)
+	}
+
 	return endpoints
 }
 
+// extractSRVEndpoints extracts the SRV endpoints of the named ports of a service published by its srv annotations.
+// The records point at the node port of NodePort services, at the target port of the pods of headless services,
+// and at the port of the service otherwise.
+func (sc *serviceSource) extractSRVEndpoints(svc *v1.Service, srv *annotations.SRVRecords, hostname string, hosts []string, ttl endpoint.TTL, providerSpecific endpoint.ProviderSpecific, setIdentifier string) []*endpoint.Endpoint {
+	var endpoints []*endpoint.Endpoint
+	var targetPorts map[string]int32
+	if svc.Spec.ClusterIP == v1.ClusterIPNone {
+		targetPorts = sc.headlessTargetPorts(svc)
+	}
+	resource := fmt.Sprintf("service/%s/%s", svc.Namespace, svc.Name)
+
+	for _, port := range svc.Spec.Ports {
+		if !srv.Publishes(port.Name) {
+			continue
+		}
+		number := port.Port
+		switch {
+		case svc.Spec.Type == v1.ServiceTypeNodePort:
+			number = port.NodePort
+		case svc.Spec.ClusterIP == v1.ClusterIPNone:
+			if targetPort, ok := targetPorts[port.Name]; ok {
+				number = targetPort
+			} else if port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal > 0 {
+				number = port.TargetPort.IntVal
+			}
+		}
+		protocol := string(port.Protocol)
+		if protocol == "" {
+			protocol = string(v1.ProtocolTCP)
+		}
+		if ep := srvEndpoint(srv, port.Name, protocol, number, hostname, hosts, ttl, providerSpecific, setIdentifier, resource); ep != nil {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints
+}
+
+// headlessTargetPorts returns the target ports of the named ports of a headless service, as resolved by its EndpointSlices.
+func (sc *serviceSource) headlessTargetPorts(svc *v1.Service) map[string]int32 {
+	endpointSlices, err := sc.endpointSlicesInformer.Lister().EndpointSlices(svc.Namespace).List(labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: svc.GetName()}))
+	if err != nil {
+		log.Errorf("List endpoint slices of service[%s] error:%v", svc.GetName(), err)
+		return nil
+	}
+	targetPorts := map[string]int32{}
+	for _, endpointSlice := range endpointSlices {
+		for _, port := range endpointSlice.Ports {
+			if port.Name != nil && port.Port != nil {
+				targetPorts[*port.Name] = *port.Port
+			}
+		}
+	}
+	return targetPorts
+}
+
 func extractServiceIps(svc *v1.Service) endpoint.Targets {
 	if svc.Spec.ClusterIP == v1.ClusterIPNone {
 		log.Debugf("Unable to associate %s headless service with a Cluster IP", svc.Name)
@@ -758,7 +867,7 @@ func (sc *serviceSource) AddEventHandler(_ context.Context, handler func()) {
 	// https://github.com/kubernetes/kubernetes/issues/79610
 	sc.serviceInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
 	if sc.listenEndpointEvents {
-		sc.endpointsInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+		sc.endpointSlicesInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
 	}
 }
 
@@ -19,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
@@ -32,7 +33,7 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 	for _, tt := range []struct {
 		title         string
 		services      []*v1.Service
-		endpoints     []*v1.Endpoints
+		endpoints     []*discoveryv1.EndpointSlice
 		fqdnTemplate  string
 		combineFQDN   bool
 		publishHostIp bool
@@ -346,23 +347,22 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 					},
 				},
 			},
-			endpoints: []*v1.Endpoints{
+			endpoints: []*discoveryv1.EndpointSlice{
 				{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "service-one",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "service-one"},
 					},
-					Subsets: []v1.EndpointSubset{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints: []discoveryv1.Endpoint{
 						{
-							Addresses: []v1.EndpointAddress{
-								{
-									IP:       "100.66.2.241",
-									Hostname: "ip-10-1-164-158.internal",
-									TargetRef: &v1.ObjectReference{
-										Kind:      "Pod",
-										Name:      "pod-1",
-										Namespace: "default",
-									}},
+							Addresses: []string{"100.66.2.241"},
+							Hostname:  testutils.ToPtr("ip-10-1-164-158.internal"),
+							TargetRef: &v1.ObjectReference{
+								Kind:      "Pod",
+								Name:      "pod-1",
+								Namespace: "default",
 							},
 						},
 					},
@@ -371,19 +371,17 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "service-two",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "service-two"},
 					},
-					Subsets: []v1.EndpointSubset{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints: []discoveryv1.Endpoint{
 						{
-							Addresses: []v1.EndpointAddress{
-								{
-									IP:       "100.66.2.244",
-									Hostname: "ip-10-1-164-152.internal",
-									TargetRef: &v1.ObjectReference{
-										Kind:      "Pod",
-										Name:      "pod-2",
-										Namespace: "default",
-									},
-								},
+							Addresses: []string{"100.66.2.244"},
+							Hostname:  testutils.ToPtr("ip-10-1-164-152.internal"),
+							TargetRef: &v1.ObjectReference{
+								Kind:      "Pod",
+								Name:      "pod-2",
+								Namespace: "default",
 							},
 						},
 					},
@@ -392,28 +390,26 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "service-three",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "service-three"},
 					},
-					Subsets: []v1.EndpointSubset{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints: []discoveryv1.Endpoint{
 						{
-							Addresses: []v1.EndpointAddress{
-								{
-									IP:       "100.66.2.246",
-									Hostname: "ip-10-1-164-158.internal",
-									TargetRef: &v1.ObjectReference{
-										Kind:      "Pod",
-										Name:      "pod-3",
-										Namespace: "default",
-									},
-								},
-								{
-									IP:       "100.66.2.247",
-									Hostname: "ip-10-1-164-158.internal",
-									TargetRef: &v1.ObjectReference{
-										Kind:      "Pod",
-										Name:      "pod-4",
-										Namespace: "default",
-									},
-								},
+							Addresses: []string{"100.66.2.246"},
+							Hostname:  testutils.ToPtr("ip-10-1-164-158.internal"),
+							TargetRef: &v1.ObjectReference{
+								Kind:      "Pod",
+								Name:      "pod-3",
+								Namespace: "default",
+							},
+						},
+						{
+							Addresses: []string{"100.66.2.247"},
+							Hostname:  testutils.ToPtr("ip-10-1-164-158.internal"),
+							TargetRef: &v1.ObjectReference{
+								Kind:      "Pod",
+								Name:      "pod-4",
+								Namespace: "default",
 							},
 						},
 					},
@@ -476,23 +472,22 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 					},
 				},
 			},
-			endpoints: []*v1.Endpoints{
+			endpoints: []*discoveryv1.EndpointSlice{
 				{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "service-one",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "service-one"},
 					},
-					Subsets: []v1.EndpointSubset{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints: []discoveryv1.Endpoint{
 						{
-							Addresses: []v1.EndpointAddress{
-								{
-									IP:       "100.66.2.241",
-									Hostname: "ip-10-1-164-158.internal",
-									TargetRef: &v1.ObjectReference{
-										Kind:      "Pod",
-										Name:      "pod-1",
-										Namespace: "default",
-									}},
+							Addresses: []string{"100.66.2.241"},
+							Hostname:  testutils.ToPtr("ip-10-1-164-158.internal"),
+							TargetRef: &v1.ObjectReference{
+								Kind:      "Pod",
+								Name:      "pod-1",
+								Namespace: "default",
 							},
 						},
 					},
@@ -501,19 +496,17 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "service-two",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "service-two"},
 					},
-					Subsets: []v1.EndpointSubset{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints: []discoveryv1.Endpoint{
 						{
-							Addresses: []v1.EndpointAddress{
-								{
-									IP:       "100.66.2.244",
-									Hostname: "ip-10-1-164-152.internal",
-									TargetRef: &v1.ObjectReference{
-										Kind:      "Pod",
-										Name:      "pod-2",
-										Namespace: "default",
-									},
-								},
+							Addresses: []string{"100.66.2.244"},
+							Hostname:  testutils.ToPtr("ip-10-1-164-152.internal"),
+							TargetRef: &v1.ObjectReference{
+								Kind:      "Pod",
+								Name:      "pod-2",
+								Namespace: "default",
 							},
 						},
 					},
@@ -522,28 +515,26 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
 						Name:      "service-three",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "service-three"},
 					},
-					Subsets: []v1.EndpointSubset{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints: []discoveryv1.Endpoint{
+						{
+							Addresses: []string{"100.66.2.246"},
+							Hostname:  testutils.ToPtr("ip-10-1-164-158.internal"),
+							TargetRef: &v1.ObjectReference{
+								Kind:      "Pod",
+								Name:      "pod-3",
+								Namespace: "default",
+							},
+						},
 						{
-							Addresses: []v1.EndpointAddress{
-								{
-									IP:       "100.66.2.246",
-									Hostname: "ip-10-1-164-158.internal",
-									TargetRef: &v1.ObjectReference{
-										Kind:      "Pod",
-										Name:      "pod-3",
-										Namespace: "default",
-									},
-								},
-								{
-									IP:       "100.66.2.247",
-									Hostname: "ip-10-1-164-158.internal",
-									TargetRef: &v1.ObjectReference{
-										Kind:      "Pod",
-										Name:      "pod-4",
-										Namespace: "default",
-									},
-								},
+							Addresses: []string{"100.66.2.247"},
+							Hostname:  testutils.ToPtr("ip-10-1-164-158.internal"),
+							TargetRef: &v1.ObjectReference{
+								Kind:      "Pod",
+								Name:      "pod-4",
+								Namespace: "default",
 							},
 						},
 					},
@@ -570,23 +561,21 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 
 			// Create endpoints and pods for the services
 			for _, el := range tt.endpoints {
-				_, err := kubeClient.CoreV1().Endpoints(el.Namespace).Create(t.Context(), el, metav1.CreateOptions{})
+				_, err := kubeClient.DiscoveryV1().EndpointSlices(el.Namespace).Create(t.Context(), el, metav1.CreateOptions{})
 				require.NoError(t, err)
-				for i, subset := range el.Subsets {
-					for idx, address := range subset.Addresses {
-						_, err = kubeClient.CoreV1().Pods(el.Namespace).Create(t.Context(), &v1.Pod{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      address.TargetRef.Name,
-								Namespace: el.Namespace,
-							},
-							Spec: v1.PodSpec{
-								Hostname: address.Hostname,
-							},
-							Status: v1.PodStatus{
-								HostIP: fmt.Sprintf("10.1.2%d.4%d", i, idx),
-							},
-						}, metav1.CreateOptions{})
-					}
+				for idx, ep := range el.Endpoints {
+					_, err = kubeClient.CoreV1().Pods(el.Namespace).Create(t.Context(), &v1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      ep.TargetRef.Name,
+							Namespace: el.Namespace,
+						},
+						Spec: v1.PodSpec{
+							Hostname: *ep.Hostname,
+						},
+						Status: v1.PodStatus{
+							HostIP: fmt.Sprintf("10.1.20.4%d", idx),
+						},
+					}, metav1.CreateOptions{})
 				}
 			}
 
@@ -607,6 +596,7 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 				false,
 				false,
 				true,
+				nil,
 			)
 			require.NoError(t, err)
 
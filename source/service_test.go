@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
@@ -30,8 +31,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -86,6 +89,7 @@ func (suite *ServiceSuite) SetupTest() {
 		false,
 		false,
 		false,
+		nil,
 	)
 	suite.NoError(err, "should initialize service source")
 }
@@ -169,6 +173,7 @@ func testServiceSourceNewServiceSource(t *testing.T) {
 				false,
 				false,
 				false,
+				nil,
 			)
 
 			if ti.expectError {
@@ -1142,6 +1147,7 @@ func testServiceSourceEndpoints(t *testing.T) {
 				tc.resolveLoadBalancerHostname,
 				false,
 				false,
+				nil,
 			)
 
 			require.NoError(t, err)
@@ -1358,6 +1364,7 @@ func testMultipleServicesEndpoints(t *testing.T) {
 				false,
 				false,
 				false,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -1663,6 +1670,7 @@ func TestClusterIpServices(t *testing.T) {
 				false,
 				false,
 				false,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -2054,9 +2062,9 @@ func TestServiceSourceNodePortServices(t *testing.T) {
 				accessAnnotationKey:   "private",
 			},
 			expected: []*endpoint.Endpoint{
-				{DNSName: "_foo._tcp.foo.example.org", Targets: endpoint.Targets{"0 50 30192 foo.example.org"}, RecordType: endpoint.RecordTypeSRV},
-				{DNSName: "foo.example.org", Targets: endpoint.Targets{"10.0.1.1", "10.0.1.2"}, RecordType: endpoint.RecordTypeA},
-				{DNSName: "foo.example.org", Targets: endpoint.Targets{"2001:DB8::1", "2001:DB8::2"}, RecordType: endpoint.RecordTypeAAAA},
+				{DNSName: "_foo._tcp.foo.example.org", Targets: endpoint.Targets{"0 50 30192 foo.example.org"}, RecordType: endpoint.RecordTypeSRV, ProviderSpecific: endpoint.ProviderSpecific{{Name: accessAnnotationKey, Value: "private"}}},
+				{DNSName: "foo.example.org", Targets: endpoint.Targets{"10.0.1.1", "10.0.1.2"}, RecordType: endpoint.RecordTypeA, ProviderSpecific: endpoint.ProviderSpecific{{Name: accessAnnotationKey, Value: "private"}}},
+				{DNSName: "foo.example.org", Targets: endpoint.Targets{"2001:DB8::1", "2001:DB8::2"}, RecordType: endpoint.RecordTypeAAAA, ProviderSpecific: endpoint.ProviderSpecific{{Name: accessAnnotationKey, Value: "private"}}},
 			},
 			nodes: []*v1.Node{{
 				ObjectMeta: metav1.ObjectMeta{
@@ -2094,9 +2102,9 @@ func TestServiceSourceNodePortServices(t *testing.T) {
 				accessAnnotationKey:   "public",
 			},
 			expected: []*endpoint.Endpoint{
-				{DNSName: "_foo._tcp.foo.example.org", Targets: endpoint.Targets{"0 50 30192 foo.example.org"}, RecordType: endpoint.RecordTypeSRV},
-				{DNSName: "foo.example.org", Targets: endpoint.Targets{"54.10.11.1", "54.10.11.2"}, RecordType: endpoint.RecordTypeA},
-				{DNSName: "foo.example.org", Targets: endpoint.Targets{"2001:DB8::1", "2001:DB8::2"}, RecordType: endpoint.RecordTypeAAAA},
+				{DNSName: "_foo._tcp.foo.example.org", Targets: endpoint.Targets{"0 50 30192 foo.example.org"}, RecordType: endpoint.RecordTypeSRV, ProviderSpecific: endpoint.ProviderSpecific{{Name: accessAnnotationKey, Value: "public"}}},
+				{DNSName: "foo.example.org", Targets: endpoint.Targets{"54.10.11.1", "54.10.11.2"}, RecordType: endpoint.RecordTypeA, ProviderSpecific: endpoint.ProviderSpecific{{Name: accessAnnotationKey, Value: "public"}}},
+				{DNSName: "foo.example.org", Targets: endpoint.Targets{"2001:DB8::1", "2001:DB8::2"}, RecordType: endpoint.RecordTypeAAAA, ProviderSpecific: endpoint.ProviderSpecific{{Name: accessAnnotationKey, Value: "public"}}},
 			},
 			nodes: []*v1.Node{{
 				ObjectMeta: metav1.ObjectMeta{
@@ -2385,6 +2393,7 @@ func TestServiceSourceNodePortServices(t *testing.T) {
 				false,
 				false,
 				tc.exposeInternalIPv6,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -3107,7 +3116,7 @@ func TestHeadlessServices(t *testing.T) {
 			_, err := kubernetes.CoreV1().Services(service.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
 			require.NoError(t, err)
 
-			var addresses, notReadyAddresses []v1.EndpointAddress
+			var podEndpoints []discoveryv1.Endpoint
 			for i, podname := range tc.podnames {
 				pod := &v1.Pod{
 					Spec: v1.PodSpec{
@@ -3129,35 +3138,20 @@ func TestHeadlessServices(t *testing.T) {
 				_, err = kubernetes.CoreV1().Pods(tc.svcNamespace).Create(context.Background(), pod, metav1.CreateOptions{})
 				require.NoError(t, err)
 
-				address := v1.EndpointAddress{
-					IP: tc.podIPs[i],
+				podEndpoints = append(podEndpoints, discoveryv1.Endpoint{
+					Addresses:  []string{tc.podIPs[i]},
+					Conditions: discoveryv1.EndpointConditions{Ready: &tc.podsReady[i]},
 					TargetRef: &v1.ObjectReference{
 						APIVersion: "",
 						Kind:       "Pod",
 						Name:       podname,
 					},
-				}
-				if tc.podsReady[i] {
-					addresses = append(addresses, address)
-				} else {
-					notReadyAddresses = append(notReadyAddresses, address)
-				}
+				})
 			}
-			endpointsObject := &v1.Endpoints{
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: tc.svcNamespace,
-					Name:      tc.svcName,
-					Labels:    tc.labels,
-				},
-				Subsets: []v1.EndpointSubset{
-					{
-						Addresses:         addresses,
-						NotReadyAddresses: notReadyAddresses,
-					},
-				},
+			for _, endpointSlice := range newTestEndpointSlices(tc.svcNamespace, tc.svcName, podEndpoints) {
+				_, err = kubernetes.DiscoveryV1().EndpointSlices(tc.svcNamespace).Create(context.Background(), endpointSlice, metav1.CreateOptions{})
+				require.NoError(t, err)
 			}
-			_, err = kubernetes.CoreV1().Endpoints(tc.svcNamespace).Create(context.Background(), endpointsObject, metav1.CreateOptions{})
-			require.NoError(t, err)
 			for _, node := range tc.nodes {
 				_, err = kubernetes.CoreV1().Nodes().Create(context.Background(), &node, metav1.CreateOptions{})
 				require.NoError(t, err)
@@ -3181,6 +3175,7 @@ func TestHeadlessServices(t *testing.T) {
 				false,
 				false,
 				tc.exposeInternalIPv6,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -3576,8 +3571,7 @@ func TestHeadlessServicesHostIP(t *testing.T) {
 			_, err := kubernetes.CoreV1().Services(service.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
 			require.NoError(t, err)
 
-			var addresses []v1.EndpointAddress
-			var notReadyAddresses []v1.EndpointAddress
+			var podEndpoints []discoveryv1.Endpoint
 			for i, podname := range tc.podnames {
 				pod := &v1.Pod{
 					Spec: v1.PodSpec{
@@ -3598,31 +3592,16 @@ func TestHeadlessServicesHostIP(t *testing.T) {
 				_, err = kubernetes.CoreV1().Pods(tc.svcNamespace).Create(context.Background(), pod, metav1.CreateOptions{})
 				require.NoError(t, err)
 
-				address := v1.EndpointAddress{
-					IP:        "4.3.2.1",
-					TargetRef: tc.targetRefs[i],
-				}
-				if tc.podsReady[i] {
-					addresses = append(addresses, address)
-				} else {
-					notReadyAddresses = append(notReadyAddresses, address)
-				}
+				podEndpoints = append(podEndpoints, discoveryv1.Endpoint{
+					Addresses:  []string{"4.3.2.1"},
+					Conditions: discoveryv1.EndpointConditions{Ready: &tc.podsReady[i]},
+					TargetRef:  tc.targetRefs[i],
+				})
 			}
-			endpointsObject := &v1.Endpoints{
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: tc.svcNamespace,
-					Name:      tc.svcName,
-					Labels:    tc.labels,
-				},
-				Subsets: []v1.EndpointSubset{
-					{
-						Addresses:         addresses,
-						NotReadyAddresses: notReadyAddresses,
-					},
-				},
+			for _, endpointSlice := range newTestEndpointSlices(tc.svcNamespace, tc.svcName, podEndpoints) {
+				_, err = kubernetes.DiscoveryV1().EndpointSlices(tc.svcNamespace).Create(context.Background(), endpointSlice, metav1.CreateOptions{})
+				require.NoError(t, err)
 			}
-			_, err = kubernetes.CoreV1().Endpoints(tc.svcNamespace).Create(context.Background(), endpointsObject, metav1.CreateOptions{})
-			require.NoError(t, err)
 
 			// Create our object under test and get the endpoints.
 			client, _ := NewServiceSource(
@@ -3642,6 +3621,7 @@ func TestHeadlessServicesHostIP(t *testing.T) {
 				false,
 				false,
 				false,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -3663,6 +3643,372 @@ func TestHeadlessServicesHostIP(t *testing.T) {
 	}
 }
 
+// TestHeadlessServicesEndpointSlices tests that headless services respect the address families, the
+// conditions and the zones of the endpoints of their endpoint slices.
+func TestHeadlessServicesEndpointSlices(t *testing.T) {
+	t.Parallel()
+
+	ready := func(zone string, addresses ...string) discoveryv1.Endpoint {
+		return discoveryv1.Endpoint{
+			Addresses:  addresses,
+			Conditions: discoveryv1.EndpointConditions{Ready: testutils.ToPtr(true)},
+			Zone:       testutils.ToPtr(zone),
+		}
+	}
+	withConditions := func(ep discoveryv1.Endpoint, ready, serving, terminating *bool) discoveryv1.Endpoint {
+		ep.Conditions = discoveryv1.EndpointConditions{Ready: ready, Serving: serving, Terminating: terminating}
+		return ep
+	}
+
+	for _, tc := range []struct {
+		title                    string
+		endpointSlices           map[discoveryv1.AddressType][]discoveryv1.Endpoint
+		podNames                 []string
+		hostIPs                  [][]string
+		publishHostIP            bool
+		publishNotReadyAddresses bool
+		topologyZones            []string
+		expected                 []*endpoint.Endpoint
+	}{
+		{
+			title: "dual-stack pods return A and AAAA records from the endpoint slices of both address families",
+			endpointSlices: map[discoveryv1.AddressType][]discoveryv1.Endpoint{
+				discoveryv1.AddressTypeIPv4: {ready("zone-a", "10.0.0.1"), ready("zone-b", "10.0.0.2")},
+				discoveryv1.AddressTypeIPv6: {ready("zone-a", "2001:db8::1"), ready("zone-b", "2001:db8::2")},
+			},
+			podNames: []string{"foo-0", "foo-1", "foo-0", "foo-1"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo-0.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "foo-0.service.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1"}},
+				{DNSName: "foo-1.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.2"}},
+				{DNSName: "foo-1.service.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::2"}},
+				{DNSName: "service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.2"}},
+				{DNSName: "service.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1", "2001:db8::2"}},
+			},
+		},
+		{
+			title: "endpoint slices with FQDN addresses are ignored",
+			endpointSlices: map[discoveryv1.AddressType][]discoveryv1.Endpoint{
+				discoveryv1.AddressTypeIPv4: {ready("zone-a", "10.0.0.1")},
+				discoveryv1.AddressTypeFQDN: {ready("zone-a", "foo-1.example.org")},
+			},
+			podNames: []string{"foo-0", "foo-1"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo-0.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+			},
+		},
+		{
+			title: "terminating endpoints are not published, even while serving",
+			endpointSlices: map[discoveryv1.AddressType][]discoveryv1.Endpoint{
+				discoveryv1.AddressTypeIPv4: {
+					ready("zone-a", "10.0.0.1"),
+					withConditions(ready("zone-a", "10.0.0.2"), testutils.ToPtr(false), testutils.ToPtr(true), testutils.ToPtr(true)),
+					withConditions(ready("zone-a", "10.0.0.3"), nil, testutils.ToPtr(false), nil),
+					withConditions(ready("zone-a", "10.0.0.4"), nil, nil, nil),
+				},
+			},
+			podNames: []string{"foo-0", "foo-1", "foo-2", "foo-3"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo-0.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "foo-3.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.4"}},
+				{DNSName: "service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.4"}},
+			},
+		},
+		{
+			title: "not ready endpoints are published with publishNotReadyAddresses, terminating endpoints are not",
+			endpointSlices: map[discoveryv1.AddressType][]discoveryv1.Endpoint{
+				discoveryv1.AddressTypeIPv4: {
+					withConditions(ready("zone-a", "10.0.0.1"), testutils.ToPtr(false), testutils.ToPtr(false), testutils.ToPtr(false)),
+					withConditions(ready("zone-a", "10.0.0.2"), testutils.ToPtr(true), testutils.ToPtr(true), testutils.ToPtr(true)),
+				},
+			},
+			podNames:                 []string{"foo-0", "foo-1"},
+			publishNotReadyAddresses: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo-0.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+			},
+		},
+		{
+			title: "only the endpoints in the topology zones are published",
+			endpointSlices: map[discoveryv1.AddressType][]discoveryv1.Endpoint{
+				discoveryv1.AddressTypeIPv4: {
+					ready("zone-a", "10.0.0.1"),
+					ready("zone-b", "10.0.0.2"),
+					ready("zone-c", "10.0.0.3"),
+					{Addresses: []string{"10.0.0.4"}},
+				},
+			},
+			podNames:      []string{"foo-0", "foo-1", "foo-2", "foo-3"},
+			topologyZones: []string{"zone-a", "zone-c"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo-0.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "foo-2.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.3"}},
+				{DNSName: "service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.3"}},
+			},
+		},
+		{
+			title: "the host IPs of both address families are published for dual-stack nodes",
+			endpointSlices: map[discoveryv1.AddressType][]discoveryv1.Endpoint{
+				discoveryv1.AddressTypeIPv4: {ready("zone-a", "10.0.0.1")},
+				discoveryv1.AddressTypeIPv6: {ready("zone-a", "2001:db8::1")},
+			},
+			podNames:      []string{"foo-0", "foo-0"},
+			hostIPs:       [][]string{{"192.168.0.1", "2001:db8:ffff::1"}},
+			publishHostIP: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo-0.service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.168.0.1"}},
+				{DNSName: "foo-0.service.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8:ffff::1"}},
+				{DNSName: "service.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"192.168.0.1"}},
+				{DNSName: "service.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8:ffff::1"}},
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			kubernetes := fake.NewClientset()
+			_, err := kubernetes.CoreV1().Services("testing").Create(context.Background(), &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "testing",
+					Name:        "foo",
+					Annotations: map[string]string{hostnameAnnotationKey: "service.example.org"},
+				},
+				Spec: v1.ServiceSpec{
+					Type:                     v1.ServiceTypeClusterIP,
+					ClusterIP:                v1.ClusterIPNone,
+					Selector:                 map[string]string{"component": "foo"},
+					PublishNotReadyAddresses: tc.publishNotReadyAddresses,
+				},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			i := 0
+			for _, addressType := range []discoveryv1.AddressType{discoveryv1.AddressTypeIPv4, discoveryv1.AddressTypeIPv6, discoveryv1.AddressTypeFQDN} {
+				endpoints, ok := tc.endpointSlices[addressType]
+				if !ok {
+					continue
+				}
+				for j := range endpoints {
+					endpoints[j].TargetRef = &v1.ObjectReference{Kind: "Pod", Name: tc.podNames[i]}
+					i++
+				}
+				// every endpoint is in its own slice, like with services with many endpoints
+				for j, ep := range endpoints {
+					_, err = kubernetes.DiscoveryV1().EndpointSlices("testing").Create(context.Background(), &discoveryv1.EndpointSlice{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "testing",
+							Name:      fmt.Sprintf("foo-%s-%d", strings.ToLower(string(addressType)), j),
+							Labels:    map[string]string{discoveryv1.LabelServiceName: "foo"},
+						},
+						AddressType: addressType,
+						Endpoints:   []discoveryv1.Endpoint{ep},
+					}, metav1.CreateOptions{})
+					require.NoError(t, err)
+				}
+			}
+
+			for i, podName := range slices.Compact(slices.Sorted(slices.Values(tc.podNames))) {
+				pod := &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "testing",
+						Name:      podName,
+						Labels:    map[string]string{"component": "foo"},
+					},
+					Spec: v1.PodSpec{Hostname: podName},
+				}
+				if i < len(tc.hostIPs) {
+					pod.Status.HostIP = tc.hostIPs[i][0]
+					for _, hostIP := range tc.hostIPs[i] {
+						pod.Status.HostIPs = append(pod.Status.HostIPs, v1.HostIP{IP: hostIP})
+					}
+				}
+				_, err = kubernetes.CoreV1().Pods("testing").Create(context.Background(), pod, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			client, err := NewServiceSource(
+				context.TODO(),
+				kubernetes,
+				"",
+				"",
+				"",
+				false,
+				"",
+				true,
+				tc.publishHostIP,
+				false,
+				[]string{},
+				false,
+				labels.Everything(),
+				false,
+				false,
+				false,
+				tc.topologyZones,
+			)
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+// TestServiceSourceSRVRecords tests that the srv annotations publish SRV records for the named ports of services.
+func TestServiceSourceSRVRecords(t *testing.T) {
+	t.Parallel()
+
+	ports := []v1.ServicePort{
+		{Name: "sip", Protocol: v1.ProtocolUDP, Port: 5060, TargetPort: intstr.FromString("sip"), NodePort: 30060},
+		{Name: "ldap", Port: 389, TargetPort: intstr.FromInt32(1389), NodePort: 30389},
+		{Name: "metrics", Port: 9090, NodePort: 30090},
+	}
+	srvAnnotations := func(srv string, extra ...string) map[string]string {
+		annots := map[string]string{hostnameAnnotationKey: "svc.example.org", annotations.SRVPortsKey: srv}
+		for i := 0; i < len(extra); i += 2 {
+			annots[extra[i]] = extra[i+1]
+		}
+		return annots
+	}
+
+	for _, tc := range []struct {
+		title    string
+		svc      v1.ServiceSpec
+		status   v1.LoadBalancerStatus
+		annots   map[string]string
+		expected []*endpoint.Endpoint
+	}{
+		{
+			title:  "load balancer services point at the port on the hostname",
+			svc:    v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer, Ports: ports},
+			status: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.4"}}},
+			annots: srvAnnotations("sip,ldap", annotations.SRVPriorityKey, "10", annotations.SRVWeightKey, "20"),
+			expected: []*endpoint.Endpoint{
+				{DNSName: "svc.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "_sip._udp.svc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"10 20 5060 svc.example.org"}},
+				{DNSName: "_ldap._tcp.svc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"10 20 389 svc.example.org"}},
+			},
+		},
+		{
+			title:  "load balancers with a hostname are the targets, since the target of an SRV record must not be an alias",
+			svc:    v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer, Ports: ports},
+			status: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{Hostname: "lb.example.com"}}},
+			annots: srvAnnotations("ldap"),
+			expected: []*endpoint.Endpoint{
+				{DNSName: "svc.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.com"}},
+				{DNSName: "_ldap._tcp.svc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 389 lb.example.com"}},
+			},
+		},
+		{
+			title:  "cluster IP services publish all named ports",
+			svc:    v1.ServiceSpec{Type: v1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1", Ports: append(ports, v1.ServicePort{Port: 8080})},
+			annots: map[string]string{internalHostnameAnnotationKey: "svc.example.org", annotations.SRVPortsKey: "*"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "svc.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "_sip._udp.svc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 5060 svc.example.org"}},
+				{DNSName: "_ldap._tcp.svc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 389 svc.example.org"}},
+				{DNSName: "_metrics._tcp.svc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 9090 svc.example.org"}},
+			},
+		},
+		{
+			title:  "node port services point at the node port",
+			svc:    v1.ServiceSpec{Type: v1.ServiceTypeNodePort, Ports: ports[1:2]},
+			annots: srvAnnotations("ldap"),
+			expected: []*endpoint.Endpoint{
+				{DNSName: "svc.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"54.10.11.1"}},
+				{DNSName: "_foo._tcp.svc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 30389 svc.example.org"}},
+				{DNSName: "_ldap._tcp.svc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 30389 svc.example.org"}},
+			},
+		},
+		{
+			title:  "headless services point at the target port on the hostname of every pod",
+			svc:    v1.ServiceSpec{Type: v1.ServiceTypeClusterIP, ClusterIP: v1.ClusterIPNone, Selector: map[string]string{"app": "foo"}, Ports: ports},
+			annots: srvAnnotations("sip,ldap"),
+			expected: []*endpoint.Endpoint{
+				{DNSName: "svc.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.1.0.1", "10.1.0.2"}},
+				{DNSName: "foo-0.svc.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.1.0.1"}},
+				{DNSName: "foo-1.svc.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.1.0.2"}},
+				{DNSName: "_sip._udp.svc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 15060 foo-0.svc.example.org", "0 50 15060 foo-1.svc.example.org"}},
+				{DNSName: "_ldap._tcp.svc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 1389 foo-0.svc.example.org", "0 50 1389 foo-1.svc.example.org"}},
+			},
+		},
+		{
+			title:  "external name services point at the external name",
+			svc:    v1.ServiceSpec{Type: v1.ServiceTypeExternalName, ExternalName: "ldap.example.com", Ports: ports},
+			annots: srvAnnotations("ldap"),
+			expected: []*endpoint.Endpoint{
+				{DNSName: "svc.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"ldap.example.com"}},
+				{DNSName: "_ldap._tcp.svc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 389 ldap.example.com"}},
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			kubernetes := fake.NewClientset()
+			_, err := kubernetes.CoreV1().Nodes().Create(context.Background(), &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Status:     v1.NodeStatus{Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "54.10.11.1"}}},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+			_, err = kubernetes.CoreV1().Services("testing").Create(context.Background(), &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testing", Name: "foo", Annotations: tc.annots},
+				Spec:       tc.svc,
+				Status:     v1.ServiceStatus{LoadBalancer: tc.status},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			var podEndpoints []discoveryv1.Endpoint
+			for i, podName := range []string{"foo-0", "foo-1"} {
+				_, err = kubernetes.CoreV1().Pods("testing").Create(context.Background(), &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testing", Name: podName, Labels: map[string]string{"app": "foo"}},
+					Spec:       v1.PodSpec{Hostname: podName, NodeName: "node1"},
+				}, metav1.CreateOptions{})
+				require.NoError(t, err)
+				podEndpoints = append(podEndpoints, discoveryv1.Endpoint{
+					Addresses: []string{fmt.Sprintf("10.1.0.%d", i+1)},
+					TargetRef: &v1.ObjectReference{Kind: "Pod", Name: podName},
+				})
+			}
+			for _, endpointSlice := range newTestEndpointSlices("testing", "foo", podEndpoints) {
+				endpointSlice.Ports = []discoveryv1.EndpointPort{
+					{Name: testutils.ToPtr("sip"), Port: testutils.ToPtr(int32(15060))},
+					{Name: testutils.ToPtr("ldap"), Port: testutils.ToPtr(int32(1389))},
+				}
+				_, err = kubernetes.DiscoveryV1().EndpointSlices("testing").Create(context.Background(), endpointSlice, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			client, err := NewServiceSource(
+				context.TODO(),
+				kubernetes,
+				"",
+				"",
+				"",
+				false,
+				"",
+				false,
+				false,
+				false,
+				[]string{},
+				false,
+				labels.Everything(),
+				false,
+				false,
+				false,
+				nil,
+			)
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
 // TestExternalServices tests that external services generate the correct endpoints.
 func TestExternalServices(t *testing.T) {
 	t.Parallel()
@@ -3827,6 +4173,7 @@ func TestExternalServices(t *testing.T) {
 				false,
 				false,
 				false,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -3889,6 +4236,7 @@ func BenchmarkServiceEndpoints(b *testing.B) {
 		false,
 		false,
 		false,
+		nil,
 	)
 	require.NoError(b, err)
 
@@ -3918,6 +4266,7 @@ func TestNewServiceSourceWithServiceTypeFilters_Unsupported(t *testing.T) {
 		false,
 		false,
 		false,
+		nil,
 	)
 	require.Errorf(t, err, "unsupported service type filter: \"UnknownType\". Supported types are: [\"ClusterIP\" \"NodePort\" \"LoadBalancer\" \"ExternalName\"]")
 	require.Nil(t, svc, "ServiceSource should be nil when an unsupported service type is provided")
@@ -4093,3 +4442,28 @@ func createTestServicesByType(namespace string, typeCounts map[v1.ServiceType]in
 	})
 	return services
 }
+
+// newTestEndpointSlices returns the endpoint slices of a service with the endpoints, one per address family,
+// like the EndpointSlice controller creates them.
+func newTestEndpointSlices(namespace, service string, endpoints []discoveryv1.Endpoint) []*discoveryv1.EndpointSlice {
+	var endpointSlices []*discoveryv1.EndpointSlice
+	for _, addressType := range []discoveryv1.AddressType{discoveryv1.AddressTypeIPv4, discoveryv1.AddressTypeIPv6} {
+		endpointSlice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%s", service, strings.ToLower(string(addressType))),
+				Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+			},
+			AddressType: addressType,
+		}
+		for _, ep := range endpoints {
+			if (addressType == discoveryv1.AddressTypeIPv6) == strings.Contains(ep.Addresses[0], ":") {
+				endpointSlice.Endpoints = append(endpointSlice.Endpoints, ep)
+			}
+		}
+		if len(endpointSlice.Endpoints) > 0 {
+			endpointSlices = append(endpointSlices, endpointSlice)
+		}
+	}
+	return endpointSlices
+}
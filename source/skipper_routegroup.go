@@ -281,6 +281,7 @@ func (sc *routeGroupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint
 			continue
 		}
 
+		eps = append(eps, recordEndpointsFromAnnotations(rg.Metadata.Annotations, eps)...)
 		log.Debugf("Endpoints generated from ingress: %s/%s: %v", rg.Metadata.Namespace, rg.Metadata.Name, eps)
 		endpoints = append(endpoints, eps...)
 	}
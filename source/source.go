@@ -18,12 +18,15 @@ package source
 
 import (
 	"context"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/source/annotations"
 )
 
@@ -38,6 +41,9 @@ const (
 	ingressHostnameSourceKey      = annotations.IngressHostnameSourceKey
 	controllerAnnotationValue     = annotations.ControllerValue
 	internalHostnameAnnotationKey = annotations.InternalHostnameKey
+	conflictPriorityAnnotationKey = annotations.ConflictPriorityKey
+	mergeTargetsAnnotationKey     = annotations.MergeTargetsKey
+	adoptAnnotationKey            = annotations.AdoptKey
 
 	EndpointsTypeNodeExternalIP = "NodeExternalIP"
 	EndpointsTypeHostIP         = "HostIP"
@@ -50,6 +56,44 @@ type Source interface {
 	AddEventHandler(context.Context, func())
 }
 
+// StatusReporter is implemented by sources which write the outcome of a reconciliation
+// back to the objects their endpoints were generated from.
+type StatusReporter interface {
+	// ReportStatus is called with the results of every reconciliation, after the changes were submitted to the registry.
+	ReportStatus(ctx context.Context, results []*plan.Result)
+}
+
+// reportStatus forwards the results to src if it implements StatusReporter.
+func reportStatus(ctx context.Context, src Source, results []*plan.Result) {
+	if reporter, ok := src.(StatusReporter); ok {
+		reporter.ReportStatus(ctx, results)
+	}
+}
+
+// setResourceMetadata labels the endpoints with the creation timestamp, the conflict priority, the merge
+// opt-in and the adoption opt-in of the object they were generated from, which the plan uses to resolve
+// conflicts between objects and with unowned records.
+func setResourceMetadata(endpoints []*endpoint.Endpoint, obj metav1.Object) {
+	created := obj.GetCreationTimestamp()
+	priority, hasPriority := obj.GetAnnotations()[conflictPriorityAnnotationKey]
+	merge := obj.GetAnnotations()[mergeTargetsAnnotationKey] == "true"
+	adopt := obj.GetAnnotations()[adoptAnnotationKey] == "true"
+	for _, ep := range endpoints {
+		if !created.IsZero() {
+			ep.WithLabel(endpoint.ResourceCreatedLabelKey, created.UTC().Format(time.RFC3339))
+		}
+		if hasPriority {
+			ep.WithLabel(endpoint.ResourcePriorityLabelKey, priority)
+		}
+		if merge {
+			ep.WithLabel(endpoint.MergeTargetsLabelKey, "true")
+		}
+		if adopt {
+			ep.WithLabel(endpoint.AdoptLabelKey, "true")
+		}
+	}
+}
+
 type kubeObject interface {
 	runtime.Object
 	metav1.Object
@@ -80,3 +124,11 @@ type eventHandlerFunc func()
 func (fn eventHandlerFunc) OnAdd(obj interface{}, isInInitialList bool) { fn() }
 func (fn eventHandlerFunc) OnUpdate(oldObj, newObj interface{})         { fn() }
 func (fn eventHandlerFunc) OnDelete(obj interface{})                    { fn() }
+
+// eventObject returns the object of an event, or the last known state of a deleted object.
+func eventObject(obj interface{}) interface{} {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		return tombstone.Obj
+	}
+	return obj
+}
@@ -18,9 +18,13 @@ package source
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
 func TestGetLabelSelector(t *testing.T) {
@@ -90,3 +94,28 @@ func TestMatchLabelSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestSetResourceMetadata(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeAAAA, "::1"),
+	}
+	setResourceMetadata(endpoints, &metav1.ObjectMeta{
+		CreationTimestamp: metav1.NewTime(created),
+		Annotations:       map[string]string{conflictPriorityAnnotationKey: "10", mergeTargetsAnnotationKey: "true", adoptAnnotationKey: "true"},
+	})
+	for _, ep := range endpoints {
+		assert.Equal(t, "2024-01-02T02:04:05Z", ep.Labels[endpoint.ResourceCreatedLabelKey])
+		assert.Equal(t, "10", ep.Labels[endpoint.ResourcePriorityLabelKey])
+		assert.Equal(t, "true", ep.Labels[endpoint.MergeTargetsLabelKey])
+		assert.Equal(t, "true", ep.Labels[endpoint.AdoptLabelKey])
+	}
+
+	ep := endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeA, "1.2.3.4")
+	setResourceMetadata([]*endpoint.Endpoint{ep}, &metav1.ObjectMeta{})
+	assert.NotContains(t, ep.Labels, endpoint.ResourceCreatedLabelKey)
+	assert.NotContains(t, ep.Labels, endpoint.ResourcePriorityLabelKey)
+	assert.NotContains(t, ep.Labels, endpoint.MergeTargetsLabelKey)
+	assert.NotContains(t, ep.Labels, endpoint.AdoptLabelKey)
+}
@@ -39,6 +39,7 @@ import (
 	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/events"
 )
 
 // ErrSourceNotFound is returned when a requested source doesn't exist.
@@ -68,9 +69,15 @@ type Config struct {
 	ConnectorServer                string
 	CRDSourceAPIVersion            string
 	CRDSourceKind                  string
+	UnstructuredResources          []string
+	UnstructuredHostnameJSONPath   string
+	UnstructuredTargetJSONPath     string
+	UnstructuredTTLJSONPath        string
+	UnstructuredRecordTypeJSONPath string
 	KubeConfig                     string
 	APIServerURL                   string
 	ServiceTypeFilter              []string
+	ServiceTopologyZones           []string
 	CFAPIEndpoint                  string
 	CFUsername                     string
 	CFPassword                     string
@@ -85,6 +92,7 @@ type Config struct {
 	TraefikDisableNew              bool
 	ExcludeUnschedulable           bool
 	ExposeInternalIPv6             bool
+	EventRecorder                  events.Recorder
 }
 
 func NewSourceConfig(cfg *externaldns.Config) *Config {
@@ -113,9 +121,15 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		ConnectorServer:                cfg.ConnectorSourceServer,
 		CRDSourceAPIVersion:            cfg.CRDSourceAPIVersion,
 		CRDSourceKind:                  cfg.CRDSourceKind,
+		UnstructuredResources:          cfg.UnstructuredSourceResources,
+		UnstructuredHostnameJSONPath:   cfg.UnstructuredSourceHostnameJSONPath,
+		UnstructuredTargetJSONPath:     cfg.UnstructuredSourceTargetJSONPath,
+		UnstructuredTTLJSONPath:        cfg.UnstructuredSourceTTLJSONPath,
+		UnstructuredRecordTypeJSONPath: cfg.UnstructuredSourceRecordTypeJSONPath,
 		KubeConfig:                     cfg.KubeConfig,
 		APIServerURL:                   cfg.APIServerURL,
 		ServiceTypeFilter:              cfg.ServiceTypeFilter,
+		ServiceTopologyZones:           cfg.ServiceTopologyZones,
 		CFAPIEndpoint:                  cfg.CFAPIEndpoint,
 		CFUsername:                     cfg.CFUsername,
 		CFPassword:                     cfg.CFPassword,
@@ -273,7 +287,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewServiceSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.Compatibility, cfg.PublishInternal, cfg.PublishHostIP, cfg.AlwaysPublishNotReadyAddresses, cfg.ServiceTypeFilter, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.ResolveLoadBalancerHostname, cfg.ListenEndpointEvents, cfg.ExposeInternalIPv6)
+		return NewServiceSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.Compatibility, cfg.PublishInternal, cfg.PublishHostIP, cfg.AlwaysPublishNotReadyAddresses, cfg.ServiceTypeFilter, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.ResolveLoadBalancerHostname, cfg.ListenEndpointEvents, cfg.ExposeInternalIPv6, cfg.ServiceTopologyZones)
 	case "ingress":
 		client, err := p.KubeClient()
 		if err != nil {
@@ -285,7 +299,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewPodSource(ctx, client, cfg.Namespace, cfg.Compatibility, cfg.IgnoreNonHostNetworkPods, cfg.PodSourceDomain, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation)
+		return NewPodSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.Compatibility, cfg.IgnoreNonHostNetworkPods, cfg.PodSourceDomain, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation)
 	case "gateway-httproute":
 		return NewGatewayHTTPRouteSource(p, cfg)
 	case "gateway-grpcroute":
@@ -377,7 +391,13 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewCRDSource(crdClient, cfg.Namespace, cfg.CRDSourceKind, cfg.AnnotationFilter, cfg.LabelFilter, scheme, cfg.UpdateEvents)
+		return NewCRDSource(crdClient, cfg.Namespace, cfg.CRDSourceKind, cfg.AnnotationFilter, cfg.LabelFilter, scheme, cfg.UpdateEvents, cfg.EventRecorder)
+	case "unstructured":
+		dynamicClient, err := p.DynamicKubernetesClient()
+		if err != nil {
+			return nil, err
+		}
+		return NewUnstructuredSource(ctx, dynamicClient, cfg.UnstructuredResources, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.UnstructuredHostnameJSONPath, cfg.UnstructuredTargetJSONPath, cfg.UnstructuredTTLJSONPath, cfg.UnstructuredRecordTypeJSONPath)
 	case "skipper-routegroup":
 		apiServerURL := cfg.APIServerURL
 		tokenPath := ""
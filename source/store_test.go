@@ -228,7 +228,7 @@ func (suite *ByNamesTestSuite) TestDynamicKubernetesClientFails() {
 	mockClientGenerator.On("DynamicKubernetesClient").Return(nil, errors.New("foo"))
 
 	sourcesDependentOnDynamicKubernetesClient := []string{"ambassador-host", "contour-httpproxy", "gloo-proxy", "traefik-proxy",
-		"kong-tcpingress", "f5-virtualserver", "f5-transportserver"}
+		"kong-tcpingress", "f5-virtualserver", "f5-transportserver", "unstructured"}
 
 	for _, source := range sourcesDependentOnDynamicKubernetesClient {
 		_, err := ByNames(context.TODO(), mockClientGenerator, []string{source}, &Config{})
@@ -22,6 +22,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
 )
 
 // targetFilterSource is a Source that removes endpoints matching the target filter from its wrapped source.
@@ -71,3 +72,7 @@ func (ms *targetFilterSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoi
 func (ms *targetFilterSource) AddEventHandler(ctx context.Context, handler func()) {
 	ms.source.AddEventHandler(ctx, handler)
 }
+
+func (ms *targetFilterSource) ReportStatus(ctx context.Context, results []*plan.Result) {
+	reportStatus(ctx, ms.source, results)
+}
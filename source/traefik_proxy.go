@@ -283,7 +283,9 @@ func (ts *traefikSource) ingressRouteTCPEndpoints() ([]*endpoint.Endpoint, error
 			continue
 		}
 
+		ingressEndpoints = append(ingressEndpoints, recordEndpointsFromAnnotations(ingressRouteTCP.Annotations, ingressEndpoints)...)
 		log.Debugf("Endpoints generated from IngressRouteTCP: %s: %v", fullname, ingressEndpoints)
+		setResourceMetadata(ingressEndpoints, ingressRouteTCP)
 		endpoints = append(endpoints, ingressEndpoints...)
 	}
 
@@ -887,7 +889,11 @@ func extractEndpoints[T any](
 			continue
 		}
 
+		ingressEndpoints = append(ingressEndpoints, recordEndpointsFromAnnotations(getAnnotations(item), ingressEndpoints)...)
 		log.Debugf("Endpoints generated from %s: %v", name, ingressEndpoints)
+		if obj, ok := any(item).(metav1.Object); ok {
+			setResourceMetadata(ingressEndpoints, obj)
+		}
 		endpoints = append(endpoints, ingressEndpoints...)
 	}
 
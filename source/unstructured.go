@@ -0,0 +1,351 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/jsonpath"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/fqdn"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// unstructuredSource is an implementation of Source for the objects of any resources, e.g. the custom resources
+// of an ingress controller without a source of its own. The hostnames, targets, TTL and record type of the
+// objects are read with JSONPath expressions, which are shared by the resources.
+type unstructuredSource struct {
+	resources                []schema.GroupVersionResource
+	namespace                string
+	annotationFilter         string
+	labelSelector            labels.Selector
+	fqdnTemplate             *template.Template
+	combineFQDNAnnotation    bool
+	ignoreHostnameAnnotation bool
+	hostnamePath             *jsonpath.JSONPath
+	targetPath               *jsonpath.JSONPath
+	ttlPath                  *jsonpath.JSONPath
+	recordTypePath           *jsonpath.JSONPath
+	informers                []kubeinformers.GenericInformer
+}
+
+// NewUnstructuredSource creates a new unstructuredSource for the resources, each given as resource.version.group,
+// e.g. "widgets.v1.example.com". The JSONPath expressions are optional, a missing record type is inferred
+// from the targets like for the other sources.
+func NewUnstructuredSource(
+	ctx context.Context,
+	dynamicKubeClient dynamic.Interface,
+	resources []string,
+	namespace string,
+	annotationFilter string,
+	labelSelector labels.Selector,
+	fqdnTemplate string,
+	combineFQDNAnnotation bool,
+	ignoreHostnameAnnotation bool,
+	hostnameJSONPath string,
+	targetJSONPath string,
+	ttlJSONPath string,
+	recordTypeJSONPath string,
+) (Source, error) {
+	if len(resources) == 0 {
+		return nil, errors.New("the unstructured source requires at least one resource")
+	}
+	var gvrs []schema.GroupVersionResource
+	for _, resource := range resources {
+		gvr, _ := schema.ParseResourceArg(resource)
+		if gvr == nil || gvr.Resource == "" || gvr.Version == "" {
+			return nil, fmt.Errorf("invalid resource %q of the unstructured source, it must be resource.version.group, e.g. widgets.v1.example.com", resource)
+		}
+		if !slices.Contains(gvrs, *gvr) {
+			gvrs = append(gvrs, *gvr)
+		}
+	}
+
+	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := &unstructuredSource{
+		resources:                gvrs,
+		namespace:                namespace,
+		annotationFilter:         annotationFilter,
+		labelSelector:            labelSelector,
+		fqdnTemplate:             tmpl,
+		combineFQDNAnnotation:    combineFQDNAnnotation,
+		ignoreHostnameAnnotation: ignoreHostnameAnnotation,
+	}
+	for _, path := range []struct {
+		name       string
+		expression string
+		jsonPath   **jsonpath.JSONPath
+	}{
+		{"hostname", hostnameJSONPath, &sc.hostnamePath},
+		{"target", targetJSONPath, &sc.targetPath},
+		{"ttl", ttlJSONPath, &sc.ttlPath},
+		{"record type", recordTypeJSONPath, &sc.recordTypePath},
+	} {
+		if *path.jsonPath, err = parseJSONPath(path.name, path.expression); err != nil {
+			return nil, err
+		}
+	}
+
+	// Use shared informer to listen for add/update/delete of the objects in the specified namespace.
+	// Set resync period to 0, to prevent processing when nothing has changed.
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	for _, gvr := range sc.resources {
+		informer := informerFactory.ForResource(gvr)
+
+		// Add default resource event handlers to properly initialize informer.
+		informer.Informer().AddEventHandler(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc: func(obj interface{}) {
+				},
+			},
+		)
+		sc.informers = append(sc.informers, informer)
+	}
+
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	return sc, nil
+}
+
+// parseJSONPath parses a JSONPath expression, e.g. "{.spec.hostnames[*]}", the braces being optional for
+// a single expression starting with a dot. Text outside of braces is literal, e.g. "TXT". An empty
+// expression returns nil.
+func parseJSONPath(name, expression string) (*jsonpath.JSONPath, error) {
+	if expression == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(expression, ".") {
+		expression = "{" + expression + "}"
+	}
+	jp := jsonpath.New(name).AllowMissingKeys(true)
+	if err := jp.Parse(expression); err != nil {
+		return nil, fmt.Errorf("invalid %s JSONPath expression %q of the unstructured source: %w", name, expression, err)
+	}
+	return jp, nil
+}
+
+// Endpoints returns endpoint objects for each host-target combination that should be processed.
+// Retrieves all objects of the resources in the source's namespace(s). The objects whose expressions
+// cannot be evaluated are skipped.
+func (sc *unstructuredSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	var objs []runtime.Object
+	for _, informer := range sc.informers {
+		resourceObjs, err := informer.Lister().ByNamespace(sc.namespace).List(sc.labelSelector)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, resourceObjs...)
+	}
+
+	selector, err := getLabelSelector(sc.annotationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []*endpoint.Endpoint{}
+
+	for _, obj := range objs {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, errors.New("could not convert")
+		}
+		if !matchLabelSelector(selector, u.GetAnnotations()) {
+			continue
+		}
+
+		// Check the controller annotation to see if we are responsible.
+		if controller, ok := u.GetAnnotations()[controllerAnnotationKey]; ok && controller != controllerAnnotationValue {
+			log.Debugf("Skipping %s %s/%s because controller value does not match, found: %s, required: %s",
+				u.GetKind(), u.GetNamespace(), u.GetName(), controller, controllerAnnotationValue)
+			continue
+		}
+
+		objEndpoints, err := sc.endpointsFromObject(u)
+		if err != nil {
+			log.Warnf("Skipping %s %s/%s: %v", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+			continue
+		}
+		if len(objEndpoints) == 0 {
+			log.Debugf("No endpoints could be generated from %s %s/%s", u.GetKind(), u.GetNamespace(), u.GetName())
+			continue
+		}
+
+		objEndpoints = append(objEndpoints, recordEndpointsFromAnnotations(u.GetAnnotations(), objEndpoints)...)
+		log.Debugf("Endpoints generated from %s %s/%s: %v", u.GetKind(), u.GetNamespace(), u.GetName(), objEndpoints)
+		setResourceMetadata(objEndpoints, u)
+		endpoints = append(endpoints, objEndpoints...)
+	}
+
+	for _, ep := range endpoints {
+		sort.Sort(ep.Targets)
+	}
+
+	return endpoints, nil
+}
+
+// endpointsFromObject extracts the endpoints of the hostnames of an object, from its hostname expression,
+// its hostname annotation and the FQDN template.
+func (sc *unstructuredSource) endpointsFromObject(u *unstructured.Unstructured) ([]*endpoint.Endpoint, error) {
+	resource := fmt.Sprintf("%s/%s/%s", strings.ToLower(u.GetKind()), u.GetNamespace(), u.GetName())
+	annots := u.GetAnnotations()
+
+	hostnames, err := evalJSONPath(sc.hostnamePath, u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the hostnames: %w", err)
+	}
+	if !sc.ignoreHostnameAnnotation {
+		hostnames = append(hostnames, annotations.HostnamesFromAnnotations(annots)...)
+	}
+	if (sc.combineFQDNAnnotation || len(hostnames) == 0) && sc.fqdnTemplate != nil {
+		tmplHostnames, err := fqdn.ExecTemplate(sc.fqdnTemplate, u)
+		if err != nil {
+			return nil, err
+		}
+		if sc.combineFQDNAnnotation {
+			hostnames = append(hostnames, tmplHostnames...)
+		} else {
+			hostnames = tmplHostnames
+		}
+	}
+
+	targets := annotations.TargetsFromTargetAnnotation(annots)
+	if len(targets) == 0 {
+		if targets, err = evalJSONPath(sc.targetPath, u); err != nil {
+			return nil, fmt.Errorf("failed to get the targets: %w", err)
+		}
+	}
+
+	ttl := annotations.TTLFromAnnotations(annots, resource)
+	if _, ok := annots[annotations.TtlKey]; !ok {
+		values, err := evalJSONPath(sc.ttlPath, u)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the TTL: %w", err)
+		}
+		if len(values) > 0 {
+			ttl = annotations.TTLFromValue(values[0], resource)
+		}
+	}
+
+	recordTypes, err := evalJSONPath(sc.recordTypePath, u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the record type: %w", err)
+	}
+
+	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(annots)
+
+	var endpoints []*endpoint.Endpoint
+	for _, hostname := range hostnames {
+		hostname = strings.TrimSuffix(hostname, ".")
+		if hostname == "" {
+			continue
+		}
+		if len(recordTypes) == 0 {
+			endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+			continue
+		}
+		if len(targets) == 0 {
+			continue
+		}
+		ep := endpoint.NewEndpointWithTTL(hostname, strings.ToUpper(recordTypes[0]), ttl, targets...)
+		if ep == nil {
+			continue
+		}
+		ep.ProviderSpecific = providerSpecific
+		ep.SetIdentifier = setIdentifier
+		ep.Labels[endpoint.ResourceLabelKey] = resource
+		endpoints = append(endpoints, ep)
+	}
+	return endpoints, nil
+}
+
+// evalJSONPath returns the non-empty values the expression finds in an object, the elements of the arrays
+// being values of their own. A nil expression finds nothing.
+func evalJSONPath(jp *jsonpath.JSONPath, u *unstructured.Unstructured) ([]string, error) {
+	if jp == nil {
+		return nil, nil
+	}
+	results, err := jp.FindResults(u.Object)
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			values = appendJSONPathValue(values, value)
+		}
+	}
+	return values, nil
+}
+
+func appendJSONPathValue(values []string, value reflect.Value) []string {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return values
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			values = appendJSONPathValue(values, value.Index(i))
+		}
+	case reflect.Map, reflect.Struct:
+		log.Debugf("Skipping the JSONPath value %v, it is not a scalar", value.Interface())
+	default:
+		if s := strings.TrimSpace(fmt.Sprint(value.Interface())); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+func (sc *unstructuredSource) AddEventHandler(_ context.Context, handler func()) {
+	for i, informer := range sc.informers {
+		log.Debugf("Adding event handler for %s", sc.resources[i].GroupResource())
+
+		// Right now there is no way to remove event handler from informer, see:
+		// https://github.com/kubernetes/kubernetes/issues/79610
+		informer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	}
+}
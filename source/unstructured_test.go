@@ -0,0 +1,308 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// This is a compile-time validation that unstructuredSource is a Source.
+var _ Source = &unstructuredSource{}
+
+var widgetGVR = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+func newTestWidget(name string, objLabels, annots map[string]string, spec map[string]interface{}, addresses ...string) *unstructured.Unstructured {
+	status := []interface{}{}
+	for _, address := range addresses {
+		status = append(status, map[string]interface{}{"value": address})
+	}
+	widget := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"spec":       spec,
+		"status":     map[string]interface{}{"addresses": status},
+	}}
+	widget.SetNamespace("default")
+	widget.SetName(name)
+	widget.SetLabels(objLabels)
+	widget.SetAnnotations(annots)
+	return widget
+}
+
+func TestUnstructuredSourceEndpoints(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		title            string
+		widgets          []*unstructured.Unstructured
+		annotationFilter string
+		labelSelector    labels.Selector
+		fqdnTemplate     string
+		combineFQDN      bool
+		ignoreHostname   bool
+		ttlPath          string
+		recordTypePath   string
+		expected         []*endpoint.Endpoint
+	}{
+		{
+			title: "hostnames and targets",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("foo", nil, nil, map[string]interface{}{"hostnames": []interface{}{"foo.example.org", "www.example.org."}}, "1.2.3.4", "2001:db8::1"),
+				newTestWidget("bar", nil, nil, map[string]interface{}{"hostnames": []interface{}{"bar.example.org"}}, "lb.example.com"),
+			},
+			expected: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				newTestEndpoint("foo.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+				newTestEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				newTestEndpoint("www.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+				newTestEndpoint("bar.example.org", endpoint.RecordTypeCNAME, "lb.example.com"),
+			},
+		},
+		{
+			title: "objects without targets",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("foo", nil, nil, map[string]interface{}{"hostnames": []interface{}{"foo.example.org"}}),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			title: "TTL expression",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("foo", nil, nil, map[string]interface{}{"hostnames": []interface{}{"foo.example.org"}, "ttl": int64(60)}, "1.2.3.4"),
+				newTestWidget("bar", nil, nil, map[string]interface{}{"hostnames": []interface{}{"bar.example.org"}, "ttl": "5m"}, "1.2.3.5"),
+			},
+			ttlPath: ".spec.ttl",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, RecordTTL: 60, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "bar.example.org", RecordType: endpoint.RecordTypeA, RecordTTL: 300, Targets: endpoint.Targets{"1.2.3.5"}},
+			},
+		},
+		{
+			title: "annotations override the expressions",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("foo", nil, map[string]string{
+					hostnameAnnotationKey: "annotation.example.org",
+					targetAnnotationKey:   "4.3.2.1",
+					ttlAnnotationKey:      "10",
+				}, map[string]interface{}{"hostnames": []interface{}{"foo.example.org"}, "ttl": int64(60)}, "1.2.3.4"),
+			},
+			ttlPath: ".spec.ttl",
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, RecordTTL: 10, Targets: endpoint.Targets{"4.3.2.1"}},
+				{DNSName: "annotation.example.org", RecordType: endpoint.RecordTypeA, RecordTTL: 10, Targets: endpoint.Targets{"4.3.2.1"}},
+			},
+		},
+		{
+			title: "ignored hostname annotation",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("foo", nil, map[string]string{hostnameAnnotationKey: "annotation.example.org"}, map[string]interface{}{"hostnames": []interface{}{"foo.example.org"}}, "1.2.3.4"),
+			},
+			ignoreHostname: true,
+			expected: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		},
+		{
+			title: "record type literal",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("foo", nil, nil, map[string]interface{}{"hostnames": []interface{}{"foo.example.org"}}, "verification=abc", "v=spf1 -all"),
+			},
+			recordTypePath: "TXT",
+			expected: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.org", endpoint.RecordTypeTXT, "v=spf1 -all", "verification=abc"),
+			},
+		},
+		{
+			title: "record type expression",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("foo", nil, nil, map[string]interface{}{"hostnames": []interface{}{"foo.example.org"}, "type": "mx"}, "10 mx.example.org"),
+			},
+			recordTypePath: "{.spec.type}",
+			expected: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.org", endpoint.RecordTypeMX, "10 mx.example.org"),
+			},
+		},
+		{
+			title: "objects whose expressions fail are skipped",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("foo", nil, nil, map[string]interface{}{"hostnames": "foo.example.org"}, "1.2.3.4"),
+				newTestWidget("bar", nil, nil, map[string]interface{}{"hostnames": []interface{}{"bar.example.org"}}, "1.2.3.5"),
+			},
+			expected: []*endpoint.Endpoint{
+				newTestEndpoint("bar.example.org", endpoint.RecordTypeA, "1.2.3.5"),
+			},
+		},
+		{
+			title: "FQDN template for the objects without hostnames",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("foo", nil, nil, map[string]interface{}{}, "1.2.3.4"),
+				newTestWidget("bar", nil, nil, map[string]interface{}{"hostnames": []interface{}{"bar.example.org"}}, "1.2.3.5"),
+			},
+			fqdnTemplate: "{{.GetName}}.widgets.example.org",
+			expected: []*endpoint.Endpoint{
+				newTestEndpoint("foo.widgets.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				newTestEndpoint("bar.example.org", endpoint.RecordTypeA, "1.2.3.5"),
+			},
+		},
+		{
+			title: "FQDN template combined with the hostnames",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("bar", nil, nil, map[string]interface{}{"hostnames": []interface{}{"bar.example.org"}}, "1.2.3.5"),
+			},
+			fqdnTemplate: "{{.GetName}}.widgets.example.org",
+			combineFQDN:  true,
+			expected: []*endpoint.Endpoint{
+				newTestEndpoint("bar.example.org", endpoint.RecordTypeA, "1.2.3.5"),
+				newTestEndpoint("bar.widgets.example.org", endpoint.RecordTypeA, "1.2.3.5"),
+			},
+		},
+		{
+			title: "annotation filter, label selector and controller annotation",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("foo", map[string]string{"team": "a"}, map[string]string{"dns": "yes"}, map[string]interface{}{"hostnames": []interface{}{"foo.example.org"}}, "1.2.3.4"),
+				newTestWidget("bar", map[string]string{"team": "b"}, map[string]string{"dns": "yes"}, map[string]interface{}{"hostnames": []interface{}{"bar.example.org"}}, "1.2.3.5"),
+				newTestWidget("baz", map[string]string{"team": "a"}, nil, map[string]interface{}{"hostnames": []interface{}{"baz.example.org"}}, "1.2.3.6"),
+				newTestWidget("qux", map[string]string{"team": "a"}, map[string]string{"dns": "yes", controllerAnnotationKey: "other"}, map[string]interface{}{"hostnames": []interface{}{"qux.example.org"}}, "1.2.3.7"),
+			},
+			annotationFilter: "dns=yes",
+			labelSelector:    labels.SelectorFromSet(labels.Set{"team": "a"}),
+			expected: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		},
+		{
+			title: "MX and TXT annotations",
+			widgets: []*unstructured.Unstructured{
+				newTestWidget("foo", nil, map[string]string{annotations.MXKey: "10 mx.example.org"}, map[string]interface{}{"hostnames": []interface{}{"foo.example.org"}}, "1.2.3.4"),
+			},
+			expected: []*endpoint.Endpoint{
+				newTestEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				newTestEndpoint("foo.example.org", endpoint.RecordTypeMX, "10 mx.example.org"),
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{widgetGVR: "WidgetList"})
+			for _, widget := range tc.widgets {
+				_, err := dynamicClient.Resource(widgetGVR).Namespace(widget.GetNamespace()).Create(context.Background(), widget, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			labelSelector := tc.labelSelector
+			if labelSelector == nil {
+				labelSelector = labels.Everything()
+			}
+
+			src, err := NewUnstructuredSource(
+				context.TODO(),
+				dynamicClient,
+				[]string{"widgets.v1.example.com"},
+				"",
+				tc.annotationFilter,
+				labelSelector,
+				tc.fqdnTemplate,
+				tc.combineFQDN,
+				tc.ignoreHostname,
+				"{.spec.hostnames[*]}",
+				".status.addresses[*].value",
+				tc.ttlPath,
+				tc.recordTypePath,
+			)
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func TestNewUnstructuredSourceErrors(t *testing.T) {
+	t.Parallel()
+
+	dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{widgetGVR: "WidgetList"})
+
+	_, err := NewUnstructuredSource(context.TODO(), dynamicClient, nil, "", "", labels.Everything(), "", false, false, "", "", "", "")
+	require.ErrorContains(t, err, "requires at least one resource")
+
+	_, err = NewUnstructuredSource(context.TODO(), dynamicClient, []string{"widgets.v1.example.com", "widgets"}, "", "", labels.Everything(), "", false, false, "", "", "", "")
+	require.ErrorContains(t, err, `invalid resource "widgets"`)
+
+	_, err = NewUnstructuredSource(context.TODO(), dynamicClient, []string{"widgets.v1.example.com"}, "", "", labels.Everything(), "", false, false, "{.spec.hostnames[*]", "", "", "")
+	require.ErrorContains(t, err, "invalid hostname JSONPath expression")
+}
+
+func TestUnstructuredSourceMultipleResources(t *testing.T) {
+	t.Parallel()
+
+	gadgetGVR := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "gadgets"}
+	dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		widgetGVR: "WidgetList",
+		gadgetGVR: "GadgetList",
+	})
+	widget := newTestWidget("foo", nil, nil, map[string]interface{}{"hostnames": []interface{}{"foo.example.org"}}, "1.2.3.4")
+	_, err := dynamicClient.Resource(widgetGVR).Namespace("default").Create(context.Background(), widget, metav1.CreateOptions{})
+	require.NoError(t, err)
+	// a resource of another shape, whose hostname is found by another expression
+	gadget := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Gadget",
+		"spec":       map[string]interface{}{"host": "bar.example.org"},
+		"status":     map[string]interface{}{"addresses": []interface{}{map[string]interface{}{"value": "1.2.3.5"}}},
+	}}
+	gadget.SetNamespace("default")
+	gadget.SetName("bar")
+	_, err = dynamicClient.Resource(gadgetGVR).Namespace("default").Create(context.Background(), gadget, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	src, err := NewUnstructuredSource(
+		context.TODO(),
+		dynamicClient,
+		[]string{"widgets.v1.example.com", "gadgets.v1.example.com", "widgets.v1.example.com"},
+		"",
+		"",
+		labels.Everything(),
+		"",
+		false,
+		false,
+		"{.spec.hostnames[*]}{.spec.host}",
+		".status.addresses[*].value",
+		"",
+		"",
+	)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		newTestEndpoint("bar.example.org", endpoint.RecordTypeA, "1.2.3.5"),
+	})
+}